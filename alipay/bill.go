@@ -0,0 +1,148 @@
+package alipay
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+)
+
+// 对账单业务明细列数（bill_type = trade），新版对账单追加的列忽略
+const tradeBillColumns = 25
+
+// TradeBillRecord 对账单业务明细（bill_type = trade），金额单位为元
+type TradeBillRecord struct {
+	TradeNo           string // 支付宝交易号
+	OutTradeNo        string // 商户订单号
+	BizType           string // 业务类型：交易、退款
+	Subject           string // 商品名称
+	CreateTime        string // 创建时间
+	FinishTime        string // 完成时间
+	StoreId           string // 门店编号
+	StoreName         string // 门店名称
+	Operator          string // 操作员
+	TerminalNo        string // 终端号
+	BuyerAccount      string // 对方账户
+	TotalAmount       string // 订单金额（元）
+	ReceiptAmount     string // 商家实收（元）
+	AlipayRedPacket   string // 支付宝红包（元）
+	JifenbaoAmount    string // 集分宝（元）
+	AlipayDiscount    string // 支付宝优惠（元）
+	MerchantDiscount  string // 商家优惠（元）
+	CouponAmount      string // 券核销金额（元）
+	CouponName        string // 券名称
+	MerchantRedPacket string // 商家红包消费金额（元）
+	CardAmount        string // 卡消费金额（元）
+	OutRequestNo      string // 退款批次号/请求号
+	ServiceFee        string // 服务费（元）
+	ShareProfit       string // 分润（元）
+	Remark            string // 备注
+}
+
+// 解析对账单zip文件中的业务明细（bill_type = trade）
+//	zipData：client.DataBillDownload() 下载的对账单zip文件内容
+//	decoder：对账单文件编码转换，支付宝对账单为GBK编码，推荐传 simplifiedchinese.GBK.NewDecoder().Bytes（golang.org/x/text），
+//		为 nil 时不转换，商品名称等中文字段可能为乱码，交易号、金额等字段不受影响
+//	注意：按列顺序解析，汇总文件（业务明细(汇总)）自动忽略
+func ParseTradeBill(zipData []byte, decoder func(b []byte) ([]byte, error)) (records []*TradeBillRecord, err error) {
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return nil, fmt.Errorf("zip.NewReader：%w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		bs, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if decoder != nil && !utf8.Valid(bs) {
+			if bs, err = decoder(bs); err != nil {
+				return nil, fmt.Errorf("decode %s：%w", f.Name, err)
+			}
+		}
+		rs, err := parseTradeBillCSV(bs)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s：%w", f.Name, err)
+		}
+		records = append(records, rs...)
+	}
+	return records, nil
+}
+
+func readZipFile(f *zip.File) (bs []byte, err error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open %s：%w", f.Name, err)
+	}
+	defer rc.Close()
+	if bs, err = ioutil.ReadAll(rc); err != nil {
+		return nil, fmt.Errorf("read %s：%w", f.Name, err)
+	}
+	return bs, nil
+}
+
+// parseTradeBillCSV 解析业务明细CSV，# 开头的行为说明及合计，第一个非 # 行为表头
+func parseTradeBillCSV(bs []byte) (records []*TradeBillRecord, err error) {
+	r := csv.NewReader(bytes.NewReader(bs))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	header := true
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header {
+			// 汇总文件列数较少，忽略
+			if len(row) < tradeBillColumns {
+				return nil, nil
+			}
+			header = false
+			continue
+		}
+		if len(row) < tradeBillColumns {
+			continue
+		}
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+		records = append(records, &TradeBillRecord{
+			TradeNo:           row[0],
+			OutTradeNo:        row[1],
+			BizType:           row[2],
+			Subject:           row[3],
+			CreateTime:        row[4],
+			FinishTime:        row[5],
+			StoreId:           row[6],
+			StoreName:         row[7],
+			Operator:          row[8],
+			TerminalNo:        row[9],
+			BuyerAccount:      row[10],
+			TotalAmount:       row[11],
+			ReceiptAmount:     row[12],
+			AlipayRedPacket:   row[13],
+			JifenbaoAmount:    row[14],
+			AlipayDiscount:    row[15],
+			MerchantDiscount:  row[16],
+			CouponAmount:      row[17],
+			CouponName:        row[18],
+			MerchantRedPacket: row[19],
+			CardAmount:        row[20],
+			OutRequestNo:      row[21],
+			ServiceFee:        row[22],
+			ShareProfit:       row[23],
+			Remark:            row[24],
+		})
+	}
+	return records, nil
+}
@@ -0,0 +1,64 @@
+package alipay
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestParseTradeBill(t *testing.T) {
+	detail := `#支付宝业务明细查询
+#账号：[20881234567890120156]
+#起始日期：[2021年09月01日 00:00:00]   终止日期：[2021年09月02日 00:00:00]
+#-----------------------------------------业务明细列表----------------------------------------
+支付宝交易号,商户订单号,业务类型,商品名称,创建时间,完成时间,门店编号,门店名称,操作员,终端号,对方账户,订单金额（元）,商家实收（元）,支付宝红包（元）,集分宝（元）,支付宝优惠（元）,商家优惠（元）,券核销金额（元）,券名称,商家红包消费金额（元）,卡消费金额（元）,退款批次号/请求号,服务费（元）,分润（元）,备注
+2021090122001401551430614892	,GZ202109011743431443	,交易,测试商品,2021-09-01 10:00:00,2021-09-01 10:00:05,,,,,185****2920,10.00,10.00,0.00,0.00,0.00,0.00,0.00,,0.00,0.00,,-0.06,0.00,
+2021090122001401551430614892	,GZ202109011743431443	,退款,测试商品,2021-09-01 11:00:00,2021-09-01 11:00:01,,,,,185****2920,-5.00,-5.00,0.00,0.00,0.00,0.00,0.00,,0.00,0.00,GZ202109011743431443R1	,0.03,0.00,部分退款
+#-----------------------------------------业务明细列表结束------------------------------------
+#交易合计：1笔，商家实收共10.00元，商家优惠共0.00元
+#退款合计：1笔，商家实收退款共-5.00元，商家优惠退款共0.00元
+#导出时间：[2021年09月02日 08:00:00]`
+	summary := `#支付宝业务汇总查询
+#-----------------------------------------业务汇总列表----------------------------------------
+门店编号,门店名称,交易订单总笔数,退款订单总笔数,订单金额（元）,商家实收（元）
+,,1,1,5.00,5.00
+#导出时间：[2021年09月02日 08:00:00]`
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"20881234567890120156_20210901_业务明细.csv":     detail,
+		"20881234567890120156_20210901_业务明细(汇总).csv": summary,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ParseTradeBill(buf.Bytes(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("len(records) = %d, want 2", len(records))
+	}
+	r := records[0]
+	if r.TradeNo != "2021090122001401551430614892" || r.OutTradeNo != "GZ202109011743431443" || r.BizType != "交易" || r.TotalAmount != "10.00" || r.ServiceFee != "-0.06" {
+		t.Errorf("records[0] = %+v", r)
+	}
+	r = records[1]
+	if r.BizType != "退款" || r.OutRequestNo != "GZ202109011743431443R1" || r.ReceiptAmount != "-5.00" || r.Remark != "部分退款" {
+		t.Errorf("records[1] = %+v", r)
+	}
+
+	if _, err = ParseTradeBill([]byte("not zip"), nil); err == nil {
+		t.Error("ParseTradeBill() with invalid zip should return error")
+	}
+}
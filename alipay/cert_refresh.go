@@ -0,0 +1,121 @@
+package alipay
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cedarwu/gopay"
+)
+
+// alipayCertSNError 网关响应报文中的支付宝公钥证书SN与当前使用的不一致
+type alipayCertSNError struct {
+	sn string
+}
+
+func (e *alipayCertSNError) Error() string {
+	return "当前使用的支付宝公钥证书SN与网关响应报文中的SN不匹配"
+}
+
+// alipayCertStore 按 alipay_cert_sn 缓存已下载并校验通过的支付宝公钥
+type alipayCertStore struct {
+	mu    sync.RWMutex
+	roots *x509.CertPool
+	keys  map[string]*rsa.PublicKey
+}
+
+// 开启支付宝公钥证书自动更新（公钥证书模式）
+//	网关响应报文中的 alipay_cert_sn 与当前支付宝公钥证书SN不一致时，自动调用 alipay.open.app.alipaycert.download 下载对应证书，
+//	校验证书链后按SN缓存，并使用新证书验签
+//	aliPayRootCertContent：支付宝根证书文件内容
+//	注意：需先调用 client.SetCertSnByContent() 或 client.SetCertSnByPath() 及 client.AutoVerifySign()
+func (a *Client) AutoRefreshAlipayCert(aliPayRootCertContent []byte) (err error) {
+	roots := x509.NewCertPool()
+	var (
+		block *pem.Block
+		rest  = aliPayRootCertContent
+		n     int
+	)
+	for {
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			// 国密根证书等不支持的证书忽略
+			continue
+		}
+		roots.AddCert(cert)
+		n++
+	}
+	if n == 0 {
+		return errors.New("failed to parse alipay root cert, please check your cert")
+	}
+	a.certStore = &alipayCertStore{roots: roots, keys: make(map[string]*rsa.PublicKey)}
+	return nil
+}
+
+// alipayCertPublicKey 获取指定SN的支付宝公钥，未缓存时下载证书
+func (a *Client) alipayCertPublicKey(sn string) (pubKey *rsa.PublicKey, err error) {
+	a.certStore.mu.RLock()
+	pubKey = a.certStore.keys[sn]
+	a.certStore.mu.RUnlock()
+	if pubKey != nil {
+		return pubKey, nil
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("alipay_cert_sn", sn)
+	aliRsp, err := a.PublicCertDownload(bm)
+	if err != nil {
+		return nil, fmt.Errorf("PublicCertDownload(%s)：%w", sn, err)
+	}
+	if pubKey, err = a.certStore.verifyCert([]byte(aliRsp.Response.AlipayCertContent), sn); err != nil {
+		return nil, err
+	}
+	a.certStore.mu.Lock()
+	a.certStore.keys[sn] = pubKey
+	a.certStore.mu.Unlock()
+	return pubKey, nil
+}
+
+// verifyCert 校验下载的支付宝公钥证书由支付宝根证书签发，且SN与网关响应报文中的一致
+func (s *alipayCertStore) verifyCert(certContent []byte, sn string) (pubKey *rsa.PublicKey, err error) {
+	var (
+		leaf          *x509.Certificate
+		intermediates = x509.NewCertPool()
+		block         *pem.Block
+		rest          = certContent
+	)
+	for {
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if leaf == nil && certSN(cert) == sn {
+			leaf = cert
+			continue
+		}
+		intermediates.AddCert(cert)
+	}
+	if leaf == nil {
+		return nil, fmt.Errorf("alipay cert with sn %s not found", sn)
+	}
+	if _, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         s.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("verify alipay cert %s：%w", sn, err)
+	}
+	pubKey, ok := leaf.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("alipay cert %s is not rsa public key", sn)
+	}
+	return pubKey, nil
+}
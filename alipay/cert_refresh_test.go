@@ -0,0 +1,94 @@
+package alipay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestClient_AutoRefreshAlipayCert(t *testing.T) {
+	newCert := func(cn string, serial int64, isCA bool, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, []byte) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: cn, Organization: []string{"Ant Financial"}},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(24 * time.Hour),
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+		}
+		if isCA {
+			tpl.KeyUsage = x509.KeyUsageCertSign
+		}
+		if parent == nil {
+			parent, parentKey = tpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	root, rootKey, rootPem := newCert("Ant Financial Certification Authority R1", 1, true, nil, nil)
+	ca, caKey, caPem := newCert("Ant Financial Certification Authority Class 2 R1", 2, true, root, rootKey)
+	leaf, leafKey, leafPem := newCert("支付宝(中国)网络技术有限公司", 3, false, ca, caKey)
+	sn := certSN(leaf)
+
+	a := &Client{AliPayPublicCertSN: "old_sn", autoSign: true, aliPayPublicKey: &rootKey.PublicKey}
+	if err := a.AutoRefreshAlipayCert([]byte("invalid")); err == nil {
+		t.Fatal("AutoRefreshAlipayCert() with invalid root cert should return error")
+	}
+	if err := a.AutoRefreshAlipayCert(rootPem); err != nil {
+		t.Fatal(err)
+	}
+
+	// 证书链校验
+	pubKey, err := a.certStore.verifyCert(append(leafPem, caPem...), sn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pubKey.N.Cmp(leafKey.N) != 0 {
+		t.Error("verifyCert() returned wrong public key")
+	}
+	if _, err = a.certStore.verifyCert(leafPem, sn); err == nil {
+		t.Error("verifyCert() without intermediate cert should return error")
+	}
+	if _, err = a.certStore.verifyCert(append(leafPem, caPem...), "other_sn"); err == nil {
+		t.Error("verifyCert() with wrong sn should return error")
+	}
+
+	// 网关响应报文SN与当前不一致时，使用对应SN的证书验签
+	a.certStore.keys[sn] = pubKey
+	signData := `{"code":"10000","msg":"Success"}`
+	bs := []byte(`{"alipay_trade_query_response":` + signData + `,"alipay_cert_sn":"` + sn + `","sign":"xxx"}`)
+	signData, signDataErr := a.getSignData(bs, sn)
+	if signDataErr == nil {
+		t.Fatal("getSignData() with different sn should return error")
+	}
+	h := sha256.Sum256([]byte(signData))
+	signBytes, err := rsa.SignPKCS1v15(rand.Reader, leafKey, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = a.autoVerifySignByCert(base64.StdEncoding.EncodeToString(signBytes), signData, signDataErr); err != nil {
+		t.Errorf("autoVerifySignByCert() error: %v", err)
+	}
+	if err = a.autoVerifySignByCert("xxx", signData, signDataErr); err == nil {
+		t.Error("autoVerifySignByCert() with invalid sign should return error")
+	}
+}
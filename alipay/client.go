@@ -4,9 +4,13 @@ import (
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
+	"html"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/sm2"
 	"github.com/cedarwu/gopay/pkg/util"
 	"github.com/cedarwu/gopay/pkg/xhttp"
 	"github.com/cedarwu/gopay/pkg/xlog"
@@ -26,17 +30,22 @@ type Client struct {
 	AppAuthToken       string
 	IsProd             bool
 	privateKey         *rsa.PrivateKey
-	aliPayPublicKey    *rsa.PublicKey // 支付宝证书公钥内容 alipayCertPublicKey_RSA2.crt
+	aliPayPublicKey    *rsa.PublicKey   // 支付宝证书公钥内容 alipayCertPublicKey_RSA2.crt
+	smPrivateKey       *sm2.PrivateKey  // 国密模式应用私钥，通过 NewClientSM() 初始化
+	smAliPayPublicKey  *sm2.PublicKey   // 国密模式支付宝公钥
+	certStore          *alipayCertStore // 支付宝公钥证书自动更新，通过 AutoRefreshAlipayCert() 开启
 	autoSign           bool
+	encryptKey         []byte // 接口内容加密 AES 密钥
 	DebugSwitch        gopay.DebugSwitch
 	location           *time.Location
+	apiUrl             string // 非空时替换正式、沙箱环境网关地址
 }
 
 // 初始化支付宝客户端
 //	注意：如果使用支付宝公钥证书验签，请设置 支付宝根证书SN（client.SetAlipayRootCertSN()）、应用公钥证书SN（client.SetAppCertSN()）
 //	appId：应用ID
 //	privateKey：应用私钥，支持PKCS1和PKCS8
-//	isProd：是否是正式环境
+//	isProd：是否是正式环境，false 时请求沙箱环境 openapi-sandbox.dl.alipaydev.com（需使用沙箱应用的 appId 及密钥）
 func NewClient(appId, privateKey string, isProd bool) (client *Client, err error) {
 	key := xrsa.FormatAlipayPrivateKey(privateKey)
 	priKey, err := xpem.DecodePrivateKey([]byte(key))
@@ -54,10 +63,42 @@ func NewClient(appId, privateKey string, isProd bool) (client *Client, err error
 	return client, nil
 }
 
+// 初始化支付宝客户端（国密模式）
+//	请求使用 SM2 签名（sign_type = SM2，SM3 摘要），自动验签请通过 client.AutoVerifySign() 传入支付宝 SM2 公钥
+//	appId：应用ID
+//	privateKey：应用 SM2 私钥
+//	isProd：是否是正式环境
+func NewClientSM(appId, privateKey string, isProd bool) (client *Client, err error) {
+	key := xrsa.FormatAlipayPrivateKey(privateKey)
+	priKey, err := sm2.ParsePrivateKey([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	client = &Client{
+		AppId:        appId,
+		Charset:      UTF8,
+		SignType:     SM2,
+		IsProd:       isProd,
+		smPrivateKey: priKey,
+		DebugSwitch:  gopay.DebugOff,
+	}
+	return client, nil
+}
+
 // 开启请求完自动验签功能（默认不开启，推荐开启，只支持证书模式）
-//	注意：只支持证书模式
+//	注意：只支持证书模式，国密模式（sign_type = SM2）传入支付宝 SM2 公钥（PEM格式）
 //	alipayPublicKeyContent：支付宝公钥证书文件内容[]byte
 func (a *Client) AutoVerifySign(alipayPublicKeyContent []byte) {
+	if a.SignType == SM2 {
+		pubKey, _, err := sm2.ParsePublicKey(alipayPublicKeyContent)
+		if err != nil {
+			xlog.Errorf("AutoVerifySign(%s),err:%+v", alipayPublicKeyContent, err)
+			return
+		}
+		a.smAliPayPublicKey = pubKey
+		a.autoSign = true
+		return
+	}
 	pubKey, err := xpem.DecodePublicKey(alipayPublicKeyContent)
 	if err != nil {
 		xlog.Errorf("AutoVerifySign(%s),err:%+v", alipayPublicKeyContent, err)
@@ -68,6 +109,15 @@ func (a *Client) AutoVerifySign(alipayPublicKeyContent []byte) {
 	}
 }
 
+// CloneWithAppAuthToken 第三方应用（ISV）代商户调用时，复制一个使用指定 app_auth_token 的 client，与原 client 共用私钥及证书
+//	appAuthToken：商户授权后获取的 app_auth_token
+//	注意：复制代价很小，可按商户或请求创建，并发安全；单次请求也可通过 bm.Set("app_auth_token", appAuthToken) 指定
+func (a *Client) CloneWithAppAuthToken(appAuthToken string) (client *Client) {
+	c := *a
+	c.AppAuthToken = appAuthToken
+	return &c
+}
+
 // Deprecated
 //	推荐使用 PostAliPayAPISelfV2()
 //	示例：请参考 client_test.go 的 TestClient_PostAliPayAPISelf() 方法
@@ -111,9 +161,9 @@ func (a *Client) RequestParam(bm gopay.BodyMap, method string) (string, error) {
 
 	// check sign
 	if bm.GetString("sign") == "" {
-		sign, err = GetRsaSign(bm, bm.GetString("sign_type"), a.privateKey)
+		sign, err = a.getSign(bm)
 		if err != nil {
-			return "", fmt.Errorf("GetRsaSign Error: %v", err)
+			return "", fmt.Errorf("GetSign Error: %v", err)
 		}
 		bm.Set("sign", sign)
 	}
@@ -159,9 +209,9 @@ func (a *Client) doAliPaySelf(bm gopay.BodyMap, method string) (bs []byte, err e
 	a.checkPublicParam(bm)
 	// check sign
 	if bm.GetString("sign") == "" {
-		sign, err = GetRsaSign(bm, bm.GetString("sign_type"), a.privateKey)
+		sign, err = a.getSign(bm)
 		if err != nil {
-			return nil, fmt.Errorf("GetRsaSign Error: %v", err)
+			return nil, fmt.Errorf("GetSign Error: %v", err)
 		}
 		bm.Set("sign", sign)
 	}
@@ -175,6 +225,9 @@ func (a *Client) doAliPaySelf(bm gopay.BodyMap, method string) (bs []byte, err e
 	} else {
 		url = sandboxBaseUrlUtf8
 	}
+	if a.apiUrl != util.NULL {
+		url = a.apiUrl
+	}
 	res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendString(bm.EncodeURLParams()).EndBytes()
 	if len(errs) > 0 {
 		return nil, errs[0]
@@ -194,10 +247,18 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 		bodyStr, url string
 		bodyBs       []byte
 		aat          string
+		returnUrl    string
 	)
 	if bm != nil {
+		// app_auth_token、return_url 从副本中移除，不修改调用方传入的 bm
+		bm = bm.Clone()
 		aat = bm.GetString("app_auth_token")
 		bm.Remove("app_auth_token")
+		// 手机网站支付、电脑网站支付 可单独指定 return_url，覆盖 client.SetReturnUrl() 的设置
+		if method == "alipay.trade.wap.pay" || method == "alipay.trade.page.pay" {
+			returnUrl = bm.GetString("return_url")
+			bm.Remove("return_url")
+		}
 		if bodyBs, err = json.Marshal(bm); err != nil {
 			return nil, fmt.Errorf("json.Marshal：%w", err)
 		}
@@ -222,6 +283,9 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 	if a.ReturnUrl != util.NULL {
 		pubBody.Set("return_url", a.ReturnUrl)
 	}
+	if returnUrl != util.NULL {
+		pubBody.Set("return_url", returnUrl)
+	}
 	if a.location != nil {
 		pubBody.Set("timestamp", time.Now().In(a.location).Format(util.TimeLayout))
 	}
@@ -237,12 +301,24 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 	if method == "alipay.user.info.share" {
 		pubBody.Set("auth_token", authToken[0])
 	}
+	// 接口内容加密，前端调起的接口不加密
+	encrypt := a.encryptKey != nil && bodyStr != util.NULL
+	switch method {
+	case "alipay.trade.app.pay", "alipay.fund.auth.order.app.freeze", "alipay.trade.wap.pay", "alipay.trade.page.pay", "alipay.user.certify.open.certify":
+		encrypt = false
+	}
+	if encrypt {
+		if bodyStr, err = aesEncrypt(bodyBs, a.encryptKey); err != nil {
+			return nil, fmt.Errorf("aesEncrypt：%w", err)
+		}
+		pubBody.Set("encrypt_type", "AES")
+	}
 	if bodyStr != util.NULL {
 		pubBody.Set("biz_content", bodyStr)
 	}
-	sign, err := GetRsaSign(pubBody, pubBody.GetString("sign_type"), a.privateKey)
+	sign, err := a.getSign(pubBody)
 	if err != nil {
-		return nil, fmt.Errorf("GetRsaSign Error: %v", err)
+		return nil, fmt.Errorf("GetSign Error: %v", err)
 	}
 	pubBody.Set("sign", sign)
 	if a.DebugSwitch == gopay.DebugOn {
@@ -263,6 +339,9 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 		if !a.IsProd {
 			url = sandboxBaseUrlUtf8
 		}
+		if a.apiUrl != util.NULL {
+			url = a.apiUrl
+		}
 		res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendString(param).EndBytes()
 		if len(errs) > 0 {
 			return nil, errs[0]
@@ -273,10 +352,81 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 		if res.StatusCode != 200 {
 			return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
 		}
+		if a.encryptKey != nil {
+			return a.decryptResponse(bs, method)
+		}
 		return bs, nil
 	}
 }
 
+// 向支付宝发送文件上传请求（multipart/form-data）
+//	公共参数及签名放在URL中，业务参数（非 biz_content）及文件放在请求体中，签名不包含文件参数
+func (a *Client) doAliPayFile(bm gopay.BodyMap, method string) (bs []byte, err error) {
+	var (
+		aat  = bm.GetString("app_auth_token")
+		body = make(gopay.BodyMap)
+	)
+	pubBody := make(gopay.BodyMap)
+	pubBody.Set("app_id", a.AppId).
+		Set("method", method).
+		Set("format", "JSON").
+		Set("charset", a.Charset).
+		Set("sign_type", a.SignType).
+		Set("version", "1.0").
+		Set("timestamp", time.Now().Format(util.TimeLayout))
+	if a.location != nil {
+		pubBody.Set("timestamp", time.Now().In(a.location).Format(util.TimeLayout))
+	}
+	if a.AppCertSN != util.NULL {
+		pubBody.Set("app_cert_sn", a.AppCertSN)
+	}
+	if a.AliPayRootCertSN != util.NULL {
+		pubBody.Set("alipay_root_cert_sn", a.AliPayRootCertSN)
+	}
+	if a.AppAuthToken != util.NULL {
+		pubBody.Set("app_auth_token", a.AppAuthToken)
+	}
+	if aat != util.NULL {
+		pubBody.Set("app_auth_token", aat)
+	}
+
+	// 签名参数：公共参数 + 业务文本参数
+	signBody := pubBody.Clone()
+	for k, v := range bm {
+		if k == "app_auth_token" {
+			continue
+		}
+		body[k] = v
+		if _, ok := v.(*util.File); !ok {
+			signBody.Set(k, v)
+		}
+	}
+	sign, err := a.getSign(signBody)
+	if err != nil {
+		return nil, fmt.Errorf("GetSign Error: %v", err)
+	}
+	pubBody.Set("sign", sign)
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_Request: %s", signBody.JsonBody())
+	}
+
+	url := baseUrl
+	if !a.IsProd {
+		url = sandboxBaseUrl
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeMultipartFormData).Post(url + "?" + pubBody.EncodeURLParams()).SendMultipartBodyMap(body).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	return bs, nil
+}
+
 // 公共参数检查
 func (a *Client) checkPublicParam(bm gopay.BodyMap) {
 	bm.Set("format", "JSON").
@@ -307,3 +457,26 @@ func (a *Client) checkPublicParam(bm gopay.BodyMap) {
 		bm.Set("app_auth_token", a.AppAuthToken)
 	}
 }
+
+// buildPayForm 将 alipay.trade.page.pay、alipay.trade.wap.pay 的GET支付链接转换为自动提交的POST表单
+//	biz_content 作为表单字段提交，其余公共参数保留在 action 中
+func buildPayForm(payUrl string) (form string, err error) {
+	u, err := url.Parse(payUrl)
+	if err != nil {
+		return util.NULL, fmt.Errorf("url.Parse(%s)：%w", payUrl, err)
+	}
+	query := u.Query()
+	bizContent := query.Get("biz_content")
+	query.Del("biz_content")
+	u.RawQuery = query.Encode()
+
+	var b strings.Builder
+	b.WriteString(`<form name="punchout_form" method="post" action="` + html.EscapeString(u.String()) + `">` + "\n")
+	if bizContent != util.NULL {
+		b.WriteString(`<input type="hidden" name="biz_content" value="` + html.EscapeString(bizContent) + `">` + "\n")
+	}
+	b.WriteString(`<input type="submit" value="立即支付" style="display:none">` + "\n")
+	b.WriteString("</form>\n")
+	b.WriteString("<script>document.forms[0].submit();</script>")
+	return b.String(), nil
+}
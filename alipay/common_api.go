@@ -108,6 +108,40 @@ func DecryptOpenDataToBodyMap(encryptedData, secretKey string) (bm gopay.BodyMap
 	return
 }
 
+// DecryptPhoneNumber 验签并解密支付宝小程序 my.getPhoneNumber 获取的手机号
+//	content：my.getPhoneNumber 成功回调 res.response 的完整JSON字符串，包含 response、sign、sign_type、encrypt_type
+//	secretKey：AES密钥，支付宝管理平台配置
+//	alipayPublicKey：支付宝公钥，验签内容为带双引号的密文，为空时不验签（不推荐）
+//	文档：https://opendocs.alipay.com/mini/api/getphonenumber
+func DecryptPhoneNumber(content, secretKey, alipayPublicKey string) (phone *UserPhone, err error) {
+	var encrypted struct {
+		Response    string `json:"response"`
+		Sign        string `json:"sign"`
+		SignType    string `json:"sign_type"`
+		EncryptType string `json:"encrypt_type"`
+	}
+	if err = json.Unmarshal([]byte(content), &encrypted); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", content, err)
+	}
+	if encrypted.EncryptType != util.NULL && encrypted.EncryptType != "AES" {
+		return nil, fmt.Errorf("unsupported encrypt_type: %s", encrypted.EncryptType)
+	}
+	if alipayPublicKey != util.NULL {
+		signData := `"` + encrypted.Response + `"`
+		if err = verifySign(signData, encrypted.Sign, encrypted.SignType, xrsa.FormatAlipayPublicKey(alipayPublicKey)); err != nil {
+			return nil, fmt.Errorf("verify sign error: %w", err)
+		}
+	}
+	phone = new(UserPhone)
+	if err = DecryptOpenDataToStruct(encrypted.Response, secretKey, phone); err != nil {
+		return nil, err
+	}
+	if phone.Code != util.NULL && phone.Code != CodeSuccess {
+		return phone, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, phone.Code, phone.Msg, phone.SubCode, phone.SubMsg)
+	}
+	return phone, nil
+}
+
 // SystemOauthToken 换取授权访问令牌（默认使用utf-8，RSA2）
 //	appId：应用ID
 //	privateKey：应用私钥
@@ -1,8 +1,16 @@
 package alipay
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"testing"
 
+	xaes "github.com/cedarwu/gopay/pkg/aes"
 	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
@@ -18,3 +26,53 @@ func TestMonitorHeartbeatSyn(t *testing.T) {
 	}
 	xlog.Info("rsp.Response:", *rsp.Response)
 }
+
+func TestDecryptPhoneNumber(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubBs, err := x509.MarshalPKIXPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aesKey := []byte("0123456789abcdef")
+	secretKey := base64.StdEncoding.EncodeToString(aesKey)
+
+	// 模拟 my.getPhoneNumber 返回的加密报文
+	secretData, err := xaes.CBCEncryptIvData([]byte(`{"code":"10000","msg":"Success","mobile":"13800138000"}`), aesKey, make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := base64.StdEncoding.EncodeToString(secretData)
+	h := sha256.Sum256([]byte(`"` + response + `"`))
+	signBs, err := rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := json.Marshal(map[string]string{
+		"response":     response,
+		"sign":         base64.StdEncoding.EncodeToString(signBs),
+		"sign_type":    RSA2,
+		"encrypt_type": "AES",
+		"charset":      "UTF-8",
+	})
+
+	phone, err := DecryptPhoneNumber(string(content), secretKey, base64.StdEncoding.EncodeToString(pubBs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if phone.Mobile != "13800138000" {
+		t.Errorf("phone.Mobile = %s", phone.Mobile)
+	}
+
+	// 篡改密文，验签失败
+	tampered, _ := json.Marshal(map[string]string{
+		"response":  base64.StdEncoding.EncodeToString(append(secretData[:len(secretData)-16:len(secretData)-16], secretData[:16]...)),
+		"sign":      base64.StdEncoding.EncodeToString(signBs),
+		"sign_type": RSA2,
+	})
+	if _, err = DecryptPhoneNumber(string(tampered), secretKey, base64.StdEncoding.EncodeToString(pubBs)); err == nil {
+		t.Error("DecryptPhoneNumber() with tampered response should return error")
+	}
+}
@@ -3,8 +3,10 @@ package alipay
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/xhttp"
 )
 
 // Deprecated
@@ -52,3 +54,103 @@ func (a *Client) DataBillDownloadUrlQuery(bm gopay.BodyMap) (aliRsp *DataBillDow
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
+
+// alipay.data.bill.ereceipt.apply(申请电子回单(incubating))
+//	返回的 file_id 用于 client.DataBillEreceiptQuery() 查询回单文件
+//	文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.bill.ereceipt.apply
+func (a *Client) DataBillEreceiptApply(bm gopay.BodyMap) (aliRsp *DataBillEreceiptApplyRsp, err error) {
+	err = bm.CheckEmptyError("type", "key")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.data.bill.ereceipt.apply"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(DataBillEreceiptApplyRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.data.bill.ereceipt.query(查询电子回单状态(incubating))
+//	status = SUCCESS 时 download_url 为回单文件下载地址，地址有效期较短，请及时下载
+//	文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.bill.ereceipt.query
+func (a *Client) DataBillEreceiptQuery(bm gopay.BodyMap) (aliRsp *DataBillEreceiptQueryRsp, err error) {
+	err = bm.CheckEmptyError("file_id")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.data.bill.ereceipt.query"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(DataBillEreceiptQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// 下载对账单，查询对账单下载地址后下载对账单zip文件
+//	bm：同 client.DataBillDownloadUrlQuery() 请求参数，bill_type、bill_date 必传
+//	返回参数zipData：对账单zip文件内容，可通过 alipay.ParseTradeBill() 解析业务明细
+//	文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.dataservice.bill.downloadurl.query
+func (a *Client) DataBillDownload(bm gopay.BodyMap) (zipData []byte, err error) {
+	aliRsp, err := a.DataBillDownloadUrlQuery(bm)
+	if err != nil {
+		return nil, err
+	}
+	if aliRsp.Response == nil || aliRsp.Response.BillDownloadUrl == "" {
+		return nil, fmt.Errorf("bill_download_url is empty")
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeForm).Get(aliRsp.Response.BillDownloadUrl).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	return bs, nil
+}
+
+// 下载电子回单文件，查询电子回单状态后下载回单文件
+//	fileId：client.DataBillEreceiptApply() 返回的 file_id
+//	注意：回单生成为异步处理，status 不为 SUCCESS 时返回错误，请稍后重试
+//	文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.bill.ereceipt.query
+func (a *Client) DataBillEreceiptDownload(fileId string) (fileData []byte, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("file_id", fileId)
+	aliRsp, err := a.DataBillEreceiptQuery(bm)
+	if err != nil {
+		return nil, err
+	}
+	if aliRsp.Response == nil || !aliRsp.Response.IsSuccess() || aliRsp.Response.DownloadUrl == "" {
+		var status, msg string
+		if aliRsp.Response != nil {
+			status, msg = aliRsp.Response.Status, aliRsp.Response.ErrorMessage
+		}
+		return nil, fmt.Errorf("ereceipt not ready, status = %s, error_message = %s", status, msg)
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeForm).Get(aliRsp.Response.DownloadUrl).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	return bs, nil
+}
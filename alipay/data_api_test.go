@@ -18,3 +18,34 @@ func TestClient_DataBillDownloadUrlQuery(t *testing.T) {
 	}
 	xlog.Debug("rsp:", rsp)
 }
+
+func TestClient_DataBillEreceipt(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("type", EreceiptTypeFundDetail).
+		Set("key", "20210901110070001506550012345678")
+	// 申请电子回单
+	aliRsp, err := client.DataBillEreceiptApply(bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
+
+	// 查询电子回单状态
+	bm = make(gopay.BodyMap)
+	bm.Set("file_id", aliRsp.Response.FileId)
+	queryRsp, err := client.DataBillEreceiptQuery(bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("queryRsp:", *queryRsp.Response)
+
+	// 下载回单文件
+	fileData, err := client.DataBillEreceiptDownload(aliRsp.Response.FileId)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("fileData len:", len(fileData))
+}
@@ -0,0 +1,131 @@
+package alipay
+
+import (
+	"crypto/aes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	xaes "github.com/cedarwu/gopay/pkg/aes"
+)
+
+// 接口内容加密（encrypt_type = AES）
+//	文档地址：https://opendocs.alipay.com/common/02mse3
+
+// 解密后的响应报文中保存原始密文（验签内容）的字段
+const encryptSignDataField = "encrypt_sign_data"
+
+// 设置接口内容加密密钥，设置后请求的 biz_content 自动使用 AES 加密，加密的同步响应自动解密
+//	注意：需先在开放平台开通接口内容加密，APP支付、手机网站支付、电脑网站支付等前端调起的接口不加密
+//	encryptKey：开放平台设置的 AES 密钥（Base64编码）
+func (a *Client) SetEncryptKey(encryptKey string) (err error) {
+	key, err := decodeEncryptKey(encryptKey)
+	if err != nil {
+		return err
+	}
+	a.encryptKey = key
+	return nil
+}
+
+// 解密支付宝异步通知中加密的 biz_content（开放平台消息通知开启内容加密时），解密后替换 bm 中的 biz_content
+//	注意：请先验签（alipay.VerifySign()）后再解密，验签内容为加密后的 biz_content
+//	encryptKey：开放平台设置的 AES 密钥（Base64编码）
+func DecryptNotifyBizContent(bm gopay.BodyMap, encryptKey string) (err error) {
+	content := bm.GetString("biz_content")
+	if content == "" || strings.HasPrefix(content, "{") {
+		return nil
+	}
+	key, err := decodeEncryptKey(encryptKey)
+	if err != nil {
+		return err
+	}
+	plain, err := aesDecrypt(content, key)
+	if err != nil {
+		return err
+	}
+	bm.Set("biz_content", string(plain))
+	return nil
+}
+
+func decodeEncryptKey(encryptKey string) (key []byte, err error) {
+	if key, err = base64.StdEncoding.DecodeString(encryptKey); err != nil {
+		return nil, fmt.Errorf("encryptKey base64.StdEncoding.DecodeString：%w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("invalid encryptKey length %d", len(key))
+	}
+}
+
+// aesEncrypt AES/CBC/PKCS5Padding，IV 全为 0，返回 Base64 编码的密文
+func aesEncrypt(data, key []byte) (content string, err error) {
+	bs, err := xaes.CBCEncryptIvData(data, key, make([]byte, aes.BlockSize))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bs), nil
+}
+
+// aesDecrypt 解密 Base64 编码的密文
+func aesDecrypt(content string, key []byte) (data []byte, err error) {
+	bs, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	if len(bs) == 0 || len(bs)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid encrypted content length")
+	}
+	return xaes.CBCDecryptIvData(bs, key, make([]byte, aes.BlockSize))
+}
+
+// decryptResponse 解密同步响应，响应内容为加密字符串时替换为解密后的 JSON，
+// 原始密文保存在 encrypt_sign_data 字段中供 getSignData() 验签使用，未加密的响应（如网关错误）原样返回
+func (a *Client) decryptResponse(bs []byte, method string) (rsp []byte, err error) {
+	var m map[string]json.RawMessage
+	if err = json.Unmarshal(bs, &m); err != nil {
+		return bs, nil
+	}
+	if _, ok := m[encryptSignDataField]; ok {
+		return nil, fmt.Errorf("响应报文包含保留字段 %s", encryptSignDataField)
+	}
+	key := strings.ReplaceAll(method, ".", "_") + "_response"
+	raw := m[key]
+	if len(raw) == 0 || raw[0] != '"' {
+		return bs, nil
+	}
+	var content string
+	if err = json.Unmarshal(raw, &content); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", key, err)
+	}
+	plain, err := aesDecrypt(content, a.encryptKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s：%w", key, err)
+	}
+	if !json.Valid(plain) {
+		return nil, fmt.Errorf("decrypt %s：invalid json", key)
+	}
+	signData, _ := json.Marshal(string(raw))
+	m[key] = plain
+	m[encryptSignDataField] = signData
+	return json.Marshal(m)
+}
+
+// encryptSignData 获取 decryptResponse() 保存的原始密文
+func (a *Client) encryptSignData(bs []byte) (signData string, ok bool) {
+	if a.encryptKey == nil || !strings.Contains(string(bs), `"`+encryptSignDataField+`":`) {
+		return "", false
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(bs, &m); err != nil {
+		return "", false
+	}
+	if err := json.Unmarshal(m[encryptSignDataField], &signData); err != nil || signData == "" {
+		return "", false
+	}
+	return signData, true
+}
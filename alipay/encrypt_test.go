@@ -0,0 +1,65 @@
+package alipay
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestClient_DecryptResponse(t *testing.T) {
+	encryptKey := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	a := &Client{AliPayPublicCertSN: "4a4e6e5a1c9f2d0b"}
+	if err := a.SetEncryptKey("invalid"); err == nil {
+		t.Fatal("SetEncryptKey() with invalid key should return error")
+	}
+	if err := a.SetEncryptKey(encryptKey); err != nil {
+		t.Fatal(err)
+	}
+
+	plain := `{"code":"10000","msg":"Success","trade_no":"2020010222001401551430614892","out_trade_no":"GZ202001021743431443","trade_status":"TRADE_SUCCESS"}`
+	content, err := aesEncrypt([]byte(plain), a.encryptKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := []byte(`{"alipay_trade_query_response":"` + content + `","alipay_cert_sn":"4a4e6e5a1c9f2d0b","sign":"xxx"}`)
+	rsp, err := a.decryptResponse(bs, "alipay.trade.query")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliRsp := new(TradeQueryResponse)
+	if err = json.Unmarshal(rsp, aliRsp); err != nil {
+		t.Fatal(err)
+	}
+	if aliRsp.Response == nil || aliRsp.Response.TradeNo != "2020010222001401551430614892" || !aliRsp.Response.IsPaid() {
+		t.Fatalf("aliRsp.Response = %+v", aliRsp.Response)
+	}
+	if aliRsp.Sign != "xxx" || aliRsp.AlipayCertSn != "4a4e6e5a1c9f2d0b" {
+		t.Errorf("aliRsp = %+v", aliRsp)
+	}
+	// 验签内容为加密后的响应内容
+	signData, err := a.getSignData(rsp, aliRsp.AlipayCertSn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signData != `"`+content+`"` {
+		t.Errorf("signData = %s, want %q", signData, content)
+	}
+
+	// 未加密的响应原样返回
+	errBs := []byte(`{"alipay_trade_query_response":{"code":"40002","msg":"Invalid Arguments"},"sign":"xxx"}`)
+	if rsp, err = a.decryptResponse(errBs, "alipay.trade.query"); err != nil || string(rsp) != string(errBs) {
+		t.Errorf("decryptResponse() = %s, %v", rsp, err)
+	}
+
+	// 异步通知
+	bm := make(gopay.BodyMap)
+	bm.Set("biz_content", content)
+	if err = DecryptNotifyBizContent(bm, encryptKey); err != nil {
+		t.Fatal(err)
+	}
+	if bm.GetString("biz_content") != plain {
+		t.Errorf("biz_content = %s", bm.GetString("biz_content"))
+	}
+}
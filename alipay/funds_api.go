@@ -8,6 +8,8 @@ import (
 )
 
 // alipay.fund.trans.uni.transfer(单笔转账接口)
+//	payee_info 可使用 alipay.TransPayeeInfo
+//	业务失败时返回 aliRsp 及 err，可通过 alipay.TransErrDesc(aliRsp.Response.SubCode) 获取错误说明
 //	文档地址：https://opendocs.alipay.com/apis/api_28/alipay.fund.trans.uni.transfer
 func (a *Client) FundTransUniTransfer(bm gopay.BodyMap) (aliRsp *FundTransUniTransferResponse, err error) {
 	err = bm.CheckEmptyError("out_biz_no", "trans_amount", "product_code", "payee_info")
@@ -401,3 +403,21 @@ func (a *Client) FundTransPagePay(bm gopay.BodyMap) (aliRsp *FundTransPagePayRsp
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
+
+var transErrDesc = map[string]string{
+	TransErrPayeeNotExist:         "收款账号不存在或姓名有误，请确认收款方账号",
+	TransErrPayeeUserInfoError:    "收款方姓名或其他信息不一致，请确认收款方信息",
+	TransErrPayeeAccountStatus:    "收款方账户状态异常，请收款方联系支付宝客服",
+	TransErrPayeeAccountNotAuth:   "收款方未实名认证，请收款方完成实名认证后重试",
+	TransErrPayerBalanceNotEnough: "付款方余额不足，请充值后使用相同的 out_biz_no 重试",
+	TransErrPayerStatusError:      "付款方账户状态异常，请联系支付宝客服",
+	TransErrExceedLimitSmAmount:   "单笔转账金额超过限额，请调整转账金额",
+	TransErrExceedLimitDmAmount:   "日累计转账金额超过限额，请次日重试",
+	TransErrPaymentInconsistency:  "相同 out_biz_no 的两次请求参数不一致，请确认是否为同一笔转账",
+	TransErrSystemError:           "系统繁忙，请使用相同的 out_biz_no 重试，避免重复转账",
+}
+
+// TransErrDesc 单笔转账业务错误码 sub_code 对应的说明及处理建议，未收录的错误码返回空
+func TransErrDesc(subCode string) (desc string) {
+	return transErrDesc[subCode]
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
 	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
@@ -26,6 +27,38 @@ func TestFundTransUniTransfer(t *testing.T) {
 	xlog.Debug("aliRsp.Response:", aliRsp.Response)
 }
 
+func TestFundTransUniTransferPayeeInfo(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("out_biz_no", util.GetRandomString(32)).
+		Set("trans_amount", "0.01").
+		Set("product_code", "TRANS_ACCOUNT_NO_PWD").
+		Set("biz_scene", "DIRECT_TRANSFER").
+		Set("payee_info", &TransPayeeInfo{
+			Identity:     "85411418@qq.com",
+			IdentityType: IdentityTypeLogonId,
+			Name:         "测试",
+		})
+
+	aliRsp, err := client.FundTransUniTransfer(bm)
+	if err != nil {
+		if aliRsp != nil && aliRsp.Response != nil {
+			xlog.Errorf("%s：%s", aliRsp.Response.SubCode, TransErrDesc(aliRsp.Response.SubCode))
+		}
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp.Response:", aliRsp.Response)
+}
+
+func TestTransErrDesc(t *testing.T) {
+	if TransErrDesc(TransErrPayeeNotExist) == "" {
+		t.Errorf("TransErrDesc(%s) is empty", TransErrPayeeNotExist)
+	}
+	if TransErrDesc("UNKNOWN") != "" {
+		t.Error("TransErrDesc(UNKNOWN) should be empty")
+	}
+}
+
 func TestFundAccountQuery(t *testing.T) {
 	bm := make(gopay.BodyMap)
 	bm.Set("alipay_user_id", "2088301409188095") /*.Set("account_type", "ACCTRANS_ACCOUNT")*/
@@ -30,3 +30,150 @@ func (a *Client) OpenAppQrcodeCreate(bm gopay.BodyMap) (aliRsp *OpenAppQrcodeCre
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
+
+// alipay.marketing.activity.ordervoucher.create(创建商家券活动)
+//	文档地址：https://opendocs.alipay.com/apis/api_5/alipay.marketing.activity.ordervoucher.create
+func (a *Client) MarketingActivityOrderVoucherCreate(bm gopay.BodyMap) (aliRsp *MarketingActivityOrderVoucherCreateRsp, err error) {
+	err = bm.CheckEmptyError("out_biz_no", "activity_name", "activity_begin_time", "activity_end_time", "voucher_send_mode_info", "voucher_deduct_info")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.marketing.activity.ordervoucher.create"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(MarketingActivityOrderVoucherCreateRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.marketing.activity.ordervoucher.query(查询商家券活动)
+//	文档地址：https://opendocs.alipay.com/apis/api_5/alipay.marketing.activity.ordervoucher.query
+func (a *Client) MarketingActivityOrderVoucherQuery(bm gopay.BodyMap) (aliRsp *MarketingActivityOrderVoucherQueryRsp, err error) {
+	err = bm.CheckEmptyError("activity_id")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.marketing.activity.ordervoucher.query"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(MarketingActivityOrderVoucherQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.marketing.activity.ordervoucher.codedeposit(同步商家券券码)
+//	券码模式为商户自有券码（MERCHANT_UPLOAD）时，需先同步券码才可发放
+//	文档地址：https://opendocs.alipay.com/apis/api_5/alipay.marketing.activity.ordervoucher.codedeposit
+func (a *Client) MarketingActivityOrderVoucherCodeDeposit(bm gopay.BodyMap) (aliRsp *MarketingActivityOrderVoucherCodeDepositRsp, err error) {
+	err = bm.CheckEmptyError("activity_id", "out_biz_no", "voucher_codes")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.marketing.activity.ordervoucher.codedeposit"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(MarketingActivityOrderVoucherCodeDepositRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.marketing.voucher.send(发券接口)
+//	user_id、login_id 二选一
+//	文档地址：https://opendocs.alipay.com/apis/api_5/alipay.marketing.voucher.send
+func (a *Client) MarketingVoucherSend(bm gopay.BodyMap) (aliRsp *MarketingVoucherSendRsp, err error) {
+	err = bm.CheckEmptyError("template_id", "out_biz_no")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.marketing.voucher.send"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(MarketingVoucherSendRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.marketing.voucher.query(券查询)
+//	文档地址：https://opendocs.alipay.com/apis/api_5/alipay.marketing.voucher.query
+func (a *Client) MarketingVoucherQuery(bm gopay.BodyMap) (aliRsp *MarketingVoucherQueryRsp, err error) {
+	err = bm.CheckEmptyError("voucher_id")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.marketing.voucher.query"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(MarketingVoucherQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.offline.material.image.upload(上传门店照片和视频接口)
+//	image_content：图片文件，通过 bm.SetFormFile("image_content", &util.File{Name: "logo.jpg", Content: bs}) 设置
+//	文档地址：https://opendocs.alipay.com/apis/api_3/alipay.offline.material.image.upload
+func (a *Client) OfflineMaterialImageUpload(bm gopay.BodyMap) (aliRsp *OfflineMaterialImageUploadRsp, err error) {
+	err = bm.CheckEmptyError("image_type", "image_name", "image_content")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPayFile(bm, "alipay.offline.material.image.upload"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(OfflineMaterialImageUploadRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
 	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
@@ -22,3 +23,50 @@ func TestOpenAppQrcodeCreate(t *testing.T) {
 	}
 	xlog.Debug("aliRsp.Response:", aliRsp.Response)
 }
+
+func TestMarketingVoucherSend(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("template_id", "20171030000730015359000EW5N3").
+		Set("login_id", "85411418@qq.com").
+		Set("out_biz_no", "201710300000000001").
+		Set("memo", "发券测试")
+
+	// 发起请求
+	aliRsp, err := client.MarketingVoucherSend(bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp.Response:", aliRsp.Response)
+}
+
+func TestMarketingActivityOrderVoucherQuery(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("activity_id", "2016042700826004508401111111")
+
+	// 发起请求
+	aliRsp, err := client.MarketingActivityOrderVoucherQuery(bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp.Response:", aliRsp.Response)
+}
+
+func TestOfflineMaterialImageUpload(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("image_type", "png").
+		Set("image_name", "logo.png").
+		SetFormFile("image_content", &util.File{Name: "logo.png", Content: []byte("\x89PNG\r\n\x1a\n")})
+
+	// 发起请求
+	aliRsp, err := client.OfflineMaterialImageUpload(bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp.Response:", aliRsp.Response)
+}
@@ -121,6 +121,22 @@ func (a *Client) UserAgreementPageSign(bm gopay.BodyMap) (aliRsp *UserAgreementP
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
+// alipay.user.agreement.page.sign(支付宝个人协议页面签约接口)，生成签约参数
+//	bm：biz_content 业务参数，personal_product_code 必传，周期扣款时传 period_rule_params
+//	返回参数signParam：已签名的完整请求参数
+//		电脑网站、手机网站签约：跳转 https://openapi.alipay.com/gateway.do?{signParam}
+//		App签约：跳转 alipays://platformapi/startapp?appId=60000157&appClearTop=false&startMultApp=YES&sign_params={url.QueryEscape(signParam)}
+//	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.agreement.page.sign
+func (a *Client) UserAgreementPageSignParam(bm gopay.BodyMap) (signParam string, err error) {
+	err = bm.CheckEmptyError("personal_product_code")
+	if err != nil {
+		return util.NULL, err
+	}
+	pubBm := make(gopay.BodyMap)
+	pubBm.Set("biz_content", bm)
+	return a.RequestParam(pubBm, "alipay.user.agreement.page.sign")
+}
+
 // alipay.user.agreement.unsign(支付宝个人代扣协议解约接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.agreement.page.unsign
 func (a *Client) UserAgreementPageUnSign(bm gopay.BodyMap) (aliRsp *UserAgreementPageUnSignRsp, err error) {
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"encoding/json"
+	"net/url"
 	"testing"
 
 	"github.com/cedarwu/gopay"
@@ -65,6 +67,42 @@ func TestClient_UserCertifyOpenQuery(t *testing.T) {
 	xlog.Debug("aliRsp.Response.Passed:", aliRsp.Response.Passed)
 }
 
+func TestUserAgreementPageSignParam(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("personal_product_code", "CYCLE_PAY_AUTH_P").
+		Set("sign_scene", "INDUSTRY|DIGITAL_MEDIA").
+		Set("external_agreement_no", "test20190701").
+		SetBodyMap("access_params", func(bm gopay.BodyMap) {
+			bm.Set("channel", "ALIPAYAPP")
+		}).
+		SetBodyMap("period_rule_params", func(bm gopay.BodyMap) {
+			bm.Set("period_type", "DAY").
+				Set("period", "7").
+				Set("execute_time", "2019-07-01").
+				Set("single_amount", "10.00")
+		})
+
+	signParam, err := client.UserAgreementPageSignParam(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xlog.Debug("signParam:", signParam)
+	values, err := url.ParseQuery(signParam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("method") != "alipay.user.agreement.page.sign" || values.Get("sign") == "" {
+		t.Errorf("signParam = %s", signParam)
+	}
+	bizContent := make(gopay.BodyMap)
+	if err = json.Unmarshal([]byte(values.Get("biz_content")), &bizContent); err != nil {
+		t.Fatal(err)
+	}
+	if bizContent.GetString("personal_product_code") != "CYCLE_PAY_AUTH_P" {
+		t.Errorf("biz_content = %s", values.Get("biz_content"))
+	}
+}
+
 func TestUserAgreementExecutionplanModify(t *testing.T) {
 	// 请求参数
 	bm := make(gopay.BodyMap)
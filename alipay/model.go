@@ -1,18 +1,70 @@
 package alipay
 
 const (
-	// URL
+	// URL，沙箱环境：https://open.alipay.com/develop/sandbox/app
 	baseUrl            = "https://openapi.alipay.com/gateway.do"
-	sandboxBaseUrl     = "https://openapi.alipaydev.com/gateway.do"
+	sandboxBaseUrl     = "https://openapi-sandbox.dl.alipaydev.com/gateway.do"
 	baseUrlUtf8        = "https://openapi.alipay.com/gateway.do?charset=utf-8"
-	sandboxBaseUrlUtf8 = "https://openapi.alipaydev.com/gateway.do?charset=utf-8"
+	sandboxBaseUrlUtf8 = "https://openapi-sandbox.dl.alipaydev.com/gateway.do?charset=utf-8"
 
 	LocationShanghai          = "Asia/Shanghai"
 	PKCS1            PKCSType = 1 // 非Java
 	PKCS8            PKCSType = 2 // Java
 	RSA                       = "RSA"
 	RSA2                      = "RSA2"
+	SM2                       = "SM2" // 国密 SM2 签名（SM3 摘要）
 	UTF8                      = "utf-8"
+
+	// 公共响应码
+	CodeSuccess    = "10000" // 接口调用成功
+	CodeUserPaying = "10003" // 业务处理中，条码支付等待用户付款
+
+	// 条码支付场景
+	SceneBarCode  = "bar_code"  // 当面付条码支付
+	SceneWaveCode = "wave_code" // 当面付声波支付
+
+	// 交易状态 trade_status
+	TradeStatusWaitBuyerPay = "WAIT_BUYER_PAY" // 交易创建，等待买家付款
+	TradeStatusClosed       = "TRADE_CLOSED"   // 未付款交易超时关闭，或支付完成后全额退款
+	TradeStatusSuccess      = "TRADE_SUCCESS"  // 交易支付成功
+	TradeStatusFinished     = "TRADE_FINISHED" // 交易结束，不可退款
+
+	// 退款状态 refund_status
+	RefundStatusSuccess = "REFUND_SUCCESS" // 退款处理成功
+
+	// 电子回单类型 type
+	EreceiptTypeFundDetail = "FUND_DETAIL" // 账务明细回单，key 为账务流水号
+
+	// 电子回单状态 status
+	EreceiptStatusInit    = "INIT"    // 初始化
+	EreceiptStatusProcess = "PROCESS" // 处理中
+	EreceiptStatusSuccess = "SUCCESS" // 成功，可下载回单文件
+	EreceiptStatusFail    = "FAIL"    // 失败，见 error_message
+
+	// 订单信息同步业务类型 biz_type
+	OrderInfoSyncBizTypeCreditAuth   = "CREDIT_AUTH"   // 信用授权场景下的订单状态同步
+	OrderInfoSyncBizTypeCreditDeduct = "CREDIT_DEDUCT" // 信用代扣场景下的订单状态同步
+
+	// 订单信息同步状态 order_biz_info.status
+	OrderBizStatusComplete = "COMPLETE" // 用户已履约
+	OrderBizStatusClosed   = "CLOSED"   // 履约取消或订单关闭
+	OrderBizStatusViolated = "VIOLATED" // 用户已违约
+
+	// 转账参与方标识类型 identity_type
+	IdentityTypeUserId  = "ALIPAY_USER_ID"  // 支付宝用户ID
+	IdentityTypeLogonId = "ALIPAY_LOGON_ID" // 支付宝登录号
+
+	// 单笔转账常见业务错误码 sub_code
+	TransErrPayeeNotExist         = "PAYEE_NOT_EXIST"            // 收款账号不存在
+	TransErrPayeeUserInfoError    = "PAYEE_USER_INFO_ERROR"      // 收款方姓名或其他信息不一致
+	TransErrPayeeAccountStatus    = "PAYEE_ACCOUNT_STATUS_ERROR" // 收款方账户状态异常
+	TransErrPayeeAccountNotAuth   = "PAYEE_NOT_RELNAME_CERTIFY"  // 收款方未实名认证
+	TransErrPayerBalanceNotEnough = "PAYER_BALANCE_NOT_ENOUGH"   // 付款方余额不足
+	TransErrPayerStatusError      = "PAYER_STATUS_ERROR"         // 付款方账户状态异常
+	TransErrExceedLimitSmAmount   = "EXCEED_LIMIT_SM_AMOUNT"     // 单笔转账金额超限
+	TransErrExceedLimitDmAmount   = "EXCEED_LIMIT_DM_AMOUNT"     // 日累计转账金额超限
+	TransErrPaymentInconsistency  = "PAYMENT_INFO_INCONSISTENCY" // 相同 out_biz_no 的两次请求参数不一致
+	TransErrSystemError           = "SYSTEM_ERROR"               // 系统繁忙，请使用相同的 out_biz_no 重试
 )
 
 type PKCSType uint8
@@ -175,6 +227,11 @@ type TradeQuery struct {
 	CreditBizOrderId    string           `json:"credit_biz_order_id"`
 }
 
+// IsPaid 交易是否已支付成功（TRADE_SUCCESS、TRADE_FINISHED）
+func (t *TradeQuery) IsPaid() bool {
+	return t.TradeStatus == TradeStatusSuccess || t.TradeStatus == TradeStatusFinished
+}
+
 type TradeSettleInfo struct {
 	TradeSettleDetailList *TradeSettleDetail `json:"trade_settle_detail_list,omitempty"`
 }
@@ -337,6 +394,11 @@ type TradeRefundQuery struct {
 	DepositBackInfo      *DepositBackInfo `json:"deposit_back_info,omitempty"`
 }
 
+// IsRefunded 退款是否成功，查询结果未返回 refund_status 时表示退款未成功或不存在
+func (t *TradeRefundQuery) IsRefunded() bool {
+	return t.RefundStatus == RefundStatusSuccess
+}
+
 type RefundRoyalty struct {
 	RefundAmount  string `json:"refund_amount,omitempty"`
 	RoyaltyType   string `json:"royalty_type,omitempty"`
@@ -2079,3 +2141,221 @@ type FundTransPagePay struct {
 	OrderID  string `json:"order_id,omitempty"`
 	Status   string `json:"status"`
 }
+
+// ===================================================
+type TradeOrderSettleQueryRsp struct {
+	Response     *TradeOrderSettleQuery `json:"alipay_trade_order_settle_query_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type TradeOrderSettleQuery struct {
+	ErrorResponse
+	OutRequestNo      string               `json:"out_request_no"`
+	OperationDt       string               `json:"operation_dt"`
+	RoyaltyDetailList []*RoyaltyDetailInfo `json:"royalty_detail_list"`
+}
+
+type RoyaltyDetailInfo struct {
+	OperationType string `json:"operation_type"`
+	ExecuteDt     string `json:"execute_dt"`
+	TransOut      string `json:"trans_out"`
+	TransOutType  string `json:"trans_out_type"`
+	TransIn       string `json:"trans_in"`
+	TransInType   string `json:"trans_in_type"`
+	Amount        string `json:"amount"`
+	State         string `json:"state"`
+	DetailId      string `json:"detail_id,omitempty"`
+	ErrorCode     string `json:"error_code,omitempty"`
+	ErrorDesc     string `json:"error_desc,omitempty"`
+}
+
+// ===================================================
+type TradeRoyaltyRelationBindRsp struct {
+	Response     *TradeRoyaltyRelation `json:"alipay_trade_royalty_relation_bind_response"`
+	AlipayCertSn string                `json:"alipay_cert_sn,omitempty"`
+	SignData     string                `json:"-"`
+	Sign         string                `json:"sign"`
+}
+
+// ===================================================
+type TradeRoyaltyRelationUnbindRsp struct {
+	Response     *TradeRoyaltyRelation `json:"alipay_trade_royalty_relation_unbind_response"`
+	AlipayCertSn string                `json:"alipay_cert_sn,omitempty"`
+	SignData     string                `json:"-"`
+	Sign         string                `json:"sign"`
+}
+
+type TradeRoyaltyRelation struct {
+	ErrorResponse
+	ResultCode string `json:"result_code"`
+}
+
+// ===================================================
+type TradeRoyaltyRelationBatchQueryRsp struct {
+	Response     *TradeRoyaltyRelationBatchQuery `json:"alipay_trade_royalty_relation_batchquery_response"`
+	AlipayCertSn string                          `json:"alipay_cert_sn,omitempty"`
+	SignData     string                          `json:"-"`
+	Sign         string                          `json:"sign"`
+}
+
+type TradeRoyaltyRelationBatchQuery struct {
+	ErrorResponse
+	ResultCode      string             `json:"result_code"`
+	ReceiverList    []*RoyaltyReceiver `json:"receiver_list"`
+	TotalPageNum    int                `json:"total_page_num"`
+	TotalRecordNum  int                `json:"total_record_num"`
+	CurrentPageNum  int                `json:"current_page_num"`
+	CurrentPageSize int                `json:"current_page_size"`
+}
+
+type RoyaltyReceiver struct {
+	Type          string `json:"type"`
+	Account       string `json:"account"`
+	Memo          string `json:"memo,omitempty"`
+	LoginName     string `json:"login_name,omitempty"`
+	BindLoginName string `json:"bind_login_name,omitempty"`
+}
+
+// ===================================================
+type MarketingActivityOrderVoucherCreateRsp struct {
+	Response     *MarketingActivityOrderVoucherCreate `json:"alipay_marketing_activity_ordervoucher_create_response"`
+	AlipayCertSn string                               `json:"alipay_cert_sn,omitempty"`
+	SignData     string                               `json:"-"`
+	Sign         string                               `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherCreate struct {
+	ErrorResponse
+	ActivityId string `json:"activity_id"`
+}
+
+// ===================================================
+type MarketingActivityOrderVoucherQueryRsp struct {
+	Response     *MarketingActivityOrderVoucherQuery `json:"alipay_marketing_activity_ordervoucher_query_response"`
+	AlipayCertSn string                              `json:"alipay_cert_sn,omitempty"`
+	SignData     string                              `json:"-"`
+	Sign         string                              `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherQuery struct {
+	ErrorResponse
+	ActivityId              string                `json:"activity_id"`
+	ActivityStatus          string                `json:"activity_status"`
+	ActivityName            string                `json:"activity_name"`
+	ActivityBeginTime       string                `json:"activity_begin_time"`
+	ActivityEndTime         string                `json:"activity_end_time"`
+	ActivityOperationStatus string                `json:"activity_operation_status,omitempty"`
+	VoucherInventoryInfo    *VoucherInventoryInfo `json:"voucher_inventory_info,omitempty"`
+}
+
+type VoucherInventoryInfo struct {
+	VoucherQuantity     int `json:"voucher_quantity"`
+	SendVoucherQuantity int `json:"send_voucher_quantity"`
+	UsedVoucherQuantity int `json:"used_voucher_quantity,omitempty"`
+}
+
+// ===================================================
+type MarketingActivityOrderVoucherCodeDepositRsp struct {
+	Response     *MarketingActivityOrderVoucherCodeDeposit `json:"alipay_marketing_activity_ordervoucher_codedeposit_response"`
+	AlipayCertSn string                                    `json:"alipay_cert_sn,omitempty"`
+	SignData     string                                    `json:"-"`
+	Sign         string                                    `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherCodeDeposit struct {
+	ErrorResponse
+	SuccessCount          int                `json:"success_count"`
+	FailCount             int                `json:"fail_count"`
+	FailVoucherCodeDetail []*VoucherCodeFail `json:"fail_voucher_code_detail,omitempty"`
+}
+
+type VoucherCodeFail struct {
+	VoucherCode string `json:"voucher_code"`
+	ErrorCode   string `json:"error_code"`
+	ErrorMsg    string `json:"error_msg"`
+}
+
+// ===================================================
+type MarketingVoucherSendRsp struct {
+	Response     *MarketingVoucherSend `json:"alipay_marketing_voucher_send_response"`
+	AlipayCertSn string                `json:"alipay_cert_sn,omitempty"`
+	SignData     string                `json:"-"`
+	Sign         string                `json:"sign"`
+}
+
+type MarketingVoucherSend struct {
+	ErrorResponse
+	VoucherId string `json:"voucher_id"`
+	UserId    string `json:"user_id"`
+}
+
+// ===================================================
+type MarketingVoucherQueryRsp struct {
+	Response     *MarketingVoucherQuery `json:"alipay_marketing_voucher_query_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type MarketingVoucherQuery struct {
+	ErrorResponse
+	VoucherId   string `json:"voucher_id"`
+	TemplateId  string `json:"template_id"`
+	Name        string `json:"name"`
+	VoucherType string `json:"voucher_type"`
+	Amount      string `json:"amount"`
+	Status      string `json:"status"`
+	UserId      string `json:"user_id"`
+	GmtCreate   string `json:"gmt_create"`
+	GmtActive   string `json:"gmt_active"`
+	GmtExpired  string `json:"gmt_expired"`
+}
+
+// ===================================================
+type DataBillEreceiptApplyRsp struct {
+	Response     *DataBillEreceiptApply `json:"alipay_data_bill_ereceipt_apply_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type DataBillEreceiptApply struct {
+	ErrorResponse
+	FileId string `json:"file_id"`
+}
+
+// ===================================================
+type DataBillEreceiptQueryRsp struct {
+	Response     *DataBillEreceiptQuery `json:"alipay_data_bill_ereceipt_query_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type DataBillEreceiptQuery struct {
+	ErrorResponse
+	Status       string `json:"status"`
+	DownloadUrl  string `json:"download_url,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// IsSuccess 电子回单是否已生成，可通过 download_url 下载
+func (d *DataBillEreceiptQuery) IsSuccess() bool {
+	return d.Status == EreceiptStatusSuccess
+}
+
+// ===================================================
+type OfflineMaterialImageUploadRsp struct {
+	Response     *OfflineMaterialImageUpload `json:"alipay_offline_material_image_upload_response"`
+	AlipayCertSn string                      `json:"alipay_cert_sn,omitempty"`
+	SignData     string                      `json:"-"`
+	Sign         string                      `json:"sign"`
+}
+
+type OfflineMaterialImageUpload struct {
+	ErrorResponse
+	ImageId  string `json:"image_id"`
+	ImageUrl string `json:"image_url"`
+}
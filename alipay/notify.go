@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strings"
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/util"
@@ -44,6 +46,64 @@ func ParseNotifyByURLValues(value url.Values) (bm gopay.BodyMap, err error) {
 	return
 }
 
+// 将支付宝异步通知参数解析到结构体，fund_bill_list、voucher_detail_list 等JSON字符串参数自动解析为对应的结构体字段
+//	bm：alipay.ParseNotifyToBodyMap() 或 alipay.ParseNotifyByURLValues() 解析的 BodyMap，请先验签
+//	ptr：结构体指针，按 json tag 匹配参数名，如 *alipay.NotifyRequest
+//	文档：https://opendocs.alipay.com/open/203/105286
+func ParseNotifyToStruct(bm gopay.BodyMap, ptr interface{}) (err error) {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ptr must be a struct pointer, got %T", ptr)
+	}
+	fields := make(map[string]reflect.Type)
+	notifyFieldTypes(rv.Elem().Type(), fields)
+	m := make(map[string]json.RawMessage, len(bm))
+	for k := range bm {
+		v := bm.GetString(k)
+		ft, ok := fields[k]
+		if !ok {
+			continue
+		}
+		// 字符串字段按原值解析，其余字段（数组、结构体）参数值为JSON字符串
+		if ft.Kind() == reflect.String {
+			bs, _ := json.Marshal(v)
+			m[k] = bs
+			continue
+		}
+		if v == util.NULL {
+			continue
+		}
+		m[k] = json.RawMessage(v)
+	}
+	bs, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("json.Marshal：%w", err)
+	}
+	if err = json.Unmarshal(bs, ptr); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
+
+// notifyFieldTypes 获取结构体（含匿名嵌入结构体）json tag 对应的字段类型
+func notifyFieldTypes(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Anonymous && name == util.NULL && f.Type.Kind() == reflect.Struct {
+			notifyFieldTypes(f.Type, fields)
+			continue
+		}
+		if name == "-" || f.PkgPath != util.NULL {
+			continue
+		}
+		if name == util.NULL {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+}
+
 // Deprecated
 // 解析支付宝支付异步通知的参数到Struct
 //	req：*http.Request
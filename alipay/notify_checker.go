@@ -0,0 +1,253 @@
+package alipay
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cedarwu/gopay"
+)
+
+var (
+	// ErrNotifyDuplicate 异步通知 notify_id 已处理过（支付宝重复通知或重放）
+	ErrNotifyDuplicate = errors.New("alipay: duplicate notify_id")
+	// ErrNotifyProcessing 异步通知 notify_id 正在处理中（并发的重复通知）
+	ErrNotifyProcessing = errors.New("alipay: notify_id is being processed")
+)
+
+// NotifyState 异步通知 notify_id 的处理状态
+type NotifyState int
+
+const (
+	NotifyStateNone       NotifyState = iota // 无记录，或处理中记录已过期
+	NotifyStateProcessing                    // 处理中
+	NotifyStateDone                          // 已处理
+)
+
+// 处理中记录默认有效期，超过后未提交的通知（如进程崩溃）可被支付宝重试通知重新处理
+const defaultNotifyProcessingTTL = time.Minute
+
+// TradeStatusTransitionError 异步通知的 trade_status 不是当前交易状态的合法后续状态（乱序或重放的通知）
+type TradeStatusTransitionError struct {
+	OutTradeNo string
+	From       string
+	To         string
+}
+
+func (e *TradeStatusTransitionError) Error() string {
+	return fmt.Sprintf("alipay: illegal trade_status transition of %s: %s -> %s", e.OutTradeNo, e.From, e.To)
+}
+
+// NotifyStore 异步通知去重及交易状态存储，多实例部署时请使用 Redis、数据库等实现
+type NotifyStore interface {
+	// MarkNotify 无记录（或处理中记录已过期）时将 notifyId 记录为处理中，ttl 后过期，返回 NotifyStateNone
+	// 已有未过期记录时不修改，返回当前状态，需保证并发安全，如 Redis SET key value NX PX ttl
+	MarkNotify(notifyId string, ttl time.Duration) (state NotifyState, err error)
+	// CommitNotify 将 notifyId 记录为已处理，业务处理成功后调用，记录需保留到支付宝停止重试通知（约25小时）之后
+	CommitNotify(notifyId string) (err error)
+	// UnmarkNotify 删除 notifyId 记录，业务处理失败时调用，以便支付宝重试通知时重新处理
+	UnmarkNotify(notifyId string) (err error)
+	// GetTradeStatus 获取订单当前交易状态，无记录时返回空字符串
+	GetTradeStatus(outTradeNo string) (status string, err error)
+	// CompareAndSwapTradeStatus 订单当前交易状态为 oldStatus（无记录视为空字符串）时更新为 newStatus 并返回 true，否则不更新并返回 false
+	// 需保证比较与更新的原子性，如 Redis Lua 脚本、数据库 UPDATE ... WHERE status = oldStatus
+	CompareAndSwapTradeStatus(outTradeNo, oldStatus, newStatus string) (swapped bool, err error)
+}
+
+// 合法的交易状态跃迁，相同状态（如部分退款的通知）始终合法
+var tradeStatusTransitions = map[string][]string{
+	TradeStatusWaitBuyerPay: {TradeStatusSuccess, TradeStatusFinished, TradeStatusClosed},
+	TradeStatusSuccess:      {TradeStatusFinished, TradeStatusClosed},
+}
+
+// IsValidTradeStatusTransition 交易状态 from 是否可以跃迁到 to
+//	WAIT_BUYER_PAY → TRADE_SUCCESS → TRADE_FINISHED，TRADE_CLOSED、TRADE_FINISHED 为终态，from 为空时任意状态合法
+func IsValidTradeStatusTransition(from, to string) bool {
+	if from == "" || from == to {
+		return true
+	}
+	for _, s := range tradeStatusTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyChecker 支付宝异步通知去重及交易状态跃迁校验
+type NotifyChecker struct {
+	store NotifyStore
+	ttl   time.Duration
+}
+
+// 初始化异步通知校验器
+//	store：去重及交易状态存储，为 nil 时使用内存存储（仅适用于单实例部署及测试）
+//	ttl：notify_id 处理中记录的有效期，需大于业务处理耗时，<=0 时默认1分钟
+func NewNotifyChecker(store NotifyStore, ttl time.Duration) (checker *NotifyChecker) {
+	if store == nil {
+		store = NewMemoryNotifyStore()
+	}
+	if ttl <= 0 {
+		ttl = defaultNotifyProcessingTTL
+	}
+	return &NotifyChecker{store: store, ttl: ttl}
+}
+
+// Check 校验异步通知，先将 notify_id 记录为处理中，再以 CAS 方式更新交易状态
+//	注意：请先验签（alipay.VerifySign()）后再校验
+//	bm：异步通知参数，alipay.ParseNotifyToBodyMap() 解析
+//	prevStatus：更新前的交易状态，业务处理失败时传给 checker.Rollback()
+//	返回 ErrNotifyDuplicate：通知已处理过，直接响应 success 即可
+//	返回 ErrNotifyProcessing：通知正在处理中，不应处理，响应非 success 等待支付宝重试
+//	返回 *TradeStatusTransitionError：乱序或重放的通知，不应处理，notify_id 记录为已处理，重试的通知按重复通知处理
+//	业务处理成功后请调用 checker.Commit()，失败时请调用 checker.Rollback()，以便支付宝重试通知时重新处理
+//	未调用 checker.Commit() 或 checker.Rollback()（如进程崩溃）时，处理中记录 ttl 后过期，重试的通知可重新处理
+func (c *NotifyChecker) Check(bm gopay.BodyMap) (prevStatus string, err error) {
+	var (
+		notifyId    = bm.GetString("notify_id")
+		outTradeNo  = bm.GetString("out_trade_no")
+		tradeStatus = bm.GetString("trade_status")
+	)
+	if notifyId == "" {
+		return "", errors.New("notify_id : cannot be empty")
+	}
+	state, err := c.store.MarkNotify(notifyId, c.ttl)
+	if err != nil {
+		return "", err
+	}
+	switch state {
+	case NotifyStateProcessing:
+		return "", ErrNotifyProcessing
+	case NotifyStateDone:
+		return "", ErrNotifyDuplicate
+	}
+	if outTradeNo == "" || tradeStatus == "" {
+		return "", nil
+	}
+	if prevStatus, err = c.swapTradeStatus(outTradeNo, tradeStatus); err != nil {
+		var tErr *TradeStatusTransitionError
+		if errors.As(err, &tErr) {
+			// 乱序或重放的通知不再处理，重试的通知按重复通知处理
+			if cErr := c.store.CommitNotify(notifyId); cErr != nil {
+				return "", fmt.Errorf("%w, commit notify_id error: %v", err, cErr)
+			}
+			return "", err
+		}
+		// 存储出错时删除 notify_id 记录，以便支付宝重试通知时重新处理
+		if uErr := c.store.UnmarkNotify(notifyId); uErr != nil {
+			return "", fmt.Errorf("%w, unmark notify_id error: %v", err, uErr)
+		}
+		return "", err
+	}
+	return prevStatus, nil
+}
+
+// Commit 业务处理成功后调用，将 notify_id 记录为已处理，重试的通知按重复通知处理
+func (c *NotifyChecker) Commit(bm gopay.BodyMap) (err error) {
+	return c.store.CommitNotify(bm.GetString("notify_id"))
+}
+
+// swapTradeStatus 校验状态跃迁并以 CAS 方式更新交易状态，并发更新时重新读取后重试
+func (c *NotifyChecker) swapTradeStatus(outTradeNo, to string) (from string, err error) {
+	for i := 0; i < 5; i++ {
+		if from, err = c.store.GetTradeStatus(outTradeNo); err != nil {
+			return "", err
+		}
+		if !IsValidTradeStatusTransition(from, to) {
+			return "", &TradeStatusTransitionError{OutTradeNo: outTradeNo, From: from, To: to}
+		}
+		if from == to {
+			return from, nil
+		}
+		swapped, err := c.store.CompareAndSwapTradeStatus(outTradeNo, from, to)
+		if err != nil {
+			return "", err
+		}
+		if swapped {
+			return from, nil
+		}
+	}
+	return "", fmt.Errorf("alipay: trade_status of %s is being updated concurrently", outTradeNo)
+}
+
+// Rollback 业务处理失败时调用，删除 notify_id 记录，并将交易状态恢复为 prevStatus
+//	prevStatus：checker.Check() 返回的更新前交易状态
+//	注意：交易状态已被其他通知更新时不再恢复
+func (c *NotifyChecker) Rollback(bm gopay.BodyMap, prevStatus string) (err error) {
+	var (
+		outTradeNo  = bm.GetString("out_trade_no")
+		tradeStatus = bm.GetString("trade_status")
+	)
+	if outTradeNo != "" && tradeStatus != "" && tradeStatus != prevStatus {
+		if _, err = c.store.CompareAndSwapTradeStatus(outTradeNo, tradeStatus, prevStatus); err != nil {
+			return err
+		}
+	}
+	return c.store.UnmarkNotify(bm.GetString("notify_id"))
+}
+
+// MemoryNotifyStore 内存实现的 NotifyStore，已处理记录不会过期，仅适用于单实例部署及测试
+type MemoryNotifyStore struct {
+	mu       sync.Mutex
+	notifies map[string]notifyRecord
+	statuses map[string]string
+}
+
+// notifyRecord notify_id 记录，处理中记录在 expireAt 后过期
+type notifyRecord struct {
+	state    NotifyState
+	expireAt time.Time
+}
+
+// 初始化内存存储
+func NewMemoryNotifyStore() (store *MemoryNotifyStore) {
+	return &MemoryNotifyStore{
+		notifies: make(map[string]notifyRecord),
+		statuses: make(map[string]string),
+	}
+}
+
+func (s *MemoryNotifyStore) MarkNotify(notifyId string, ttl time.Duration) (state NotifyState, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.notifies[notifyId]; ok && (r.state == NotifyStateDone || time.Now().Before(r.expireAt)) {
+		return r.state, nil
+	}
+	s.notifies[notifyId] = notifyRecord{state: NotifyStateProcessing, expireAt: time.Now().Add(ttl)}
+	return NotifyStateNone, nil
+}
+
+func (s *MemoryNotifyStore) CommitNotify(notifyId string) (err error) {
+	s.mu.Lock()
+	s.notifies[notifyId] = notifyRecord{state: NotifyStateDone}
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryNotifyStore) UnmarkNotify(notifyId string) (err error) {
+	s.mu.Lock()
+	delete(s.notifies, notifyId)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryNotifyStore) GetTradeStatus(outTradeNo string) (status string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statuses[outTradeNo], nil
+}
+
+func (s *MemoryNotifyStore) CompareAndSwapTradeStatus(outTradeNo, oldStatus, newStatus string) (swapped bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.statuses[outTradeNo] != oldStatus {
+		return false, nil
+	}
+	if newStatus == "" {
+		delete(s.statuses, outTradeNo)
+	} else {
+		s.statuses[outTradeNo] = newStatus
+	}
+	return true, nil
+}
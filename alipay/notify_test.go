@@ -0,0 +1,195 @@
+package alipay
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestParseNotifyToStruct(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubBs, err := x509.MarshalPKIXPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := url.Values{}
+	values.Set("notify_type", "trade_status_sync")
+	values.Set("notify_id", "2020010200222161821001551453140885")
+	values.Set("app_id", "2015102700040153")
+	values.Set("trade_no", "2020010222001401551430614892")
+	values.Set("out_trade_no", "1086209247658383466")
+	values.Set("trade_status", TradeStatusSuccess)
+	values.Set("total_amount", "0.02")
+	values.Set("passback_params", `{"order":"1"}`)
+	values.Set("fund_bill_list", `[{"amount":"0.02","fundChannel":"PCREDIT"}]`)
+	bm, err := ParseNotifyByURLValues(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign, err := GetRsaSign(bm, RSA2, priKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm.Set("sign", sign).Set("sign_type", RSA2)
+
+	// 验签
+	ok, err := VerifySign(base64.StdEncoding.EncodeToString(pubBs), bm)
+	if err != nil || !ok {
+		t.Fatalf("VerifySign() = %v, %v", ok, err)
+	}
+	if bm.GetString("sign") == "" || bm.GetString("sign_type") == "" {
+		t.Fatal("VerifySign() should not remove sign and sign_type from bm")
+	}
+
+	// 解析到结构体
+	notifyReq := new(NotifyRequest)
+	if err = ParseNotifyToStruct(bm, notifyReq); err != nil {
+		t.Fatal(err)
+	}
+	if notifyReq.TradeNo != "2020010222001401551430614892" || notifyReq.TradeStatus != TradeStatusSuccess || notifyReq.Sign != sign {
+		t.Errorf("notifyReq = %+v", notifyReq)
+	}
+	if notifyReq.PassbackParams != `{"order":"1"}` {
+		t.Errorf("PassbackParams = %s", notifyReq.PassbackParams)
+	}
+	if len(notifyReq.FundBillList) != 1 || notifyReq.FundBillList[0].Amount != "0.02" {
+		t.Errorf("FundBillList = %+v", notifyReq.FundBillList)
+	}
+
+	if err = ParseNotifyToStruct(bm, *notifyReq); err == nil {
+		t.Error("ParseNotifyToStruct() with non-pointer should return error")
+	}
+}
+
+func TestNotifyChecker(t *testing.T) {
+	checker := NewNotifyChecker(nil, 0)
+	notify := func(notifyId, status string) gopay.BodyMap {
+		bm := make(gopay.BodyMap)
+		bm.Set("notify_id", notifyId).
+			Set("out_trade_no", "GZ201909081743431443").
+			Set("trade_status", status)
+		return bm
+	}
+
+	if _, err := checker.Check(notify("n1", TradeStatusWaitBuyerPay)); err != nil {
+		t.Fatal(err)
+	}
+	prev, err := checker.Check(notify("n2", TradeStatusSuccess))
+	if err != nil || prev != TradeStatusWaitBuyerPay {
+		t.Fatalf("Check() = %s, %v", prev, err)
+	}
+	// 处理中的重复通知
+	if _, err = checker.Check(notify("n2", TradeStatusSuccess)); err != ErrNotifyProcessing {
+		t.Errorf("Check() processing = %v, want ErrNotifyProcessing", err)
+	}
+	// 处理成功后的重复通知
+	if err = checker.Commit(notify("n2", TradeStatusSuccess)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = checker.Check(notify("n2", TradeStatusSuccess)); err != ErrNotifyDuplicate {
+		t.Errorf("Check() duplicate = %v, want ErrNotifyDuplicate", err)
+	}
+	// 乱序通知
+	_, err = checker.Check(notify("n0", TradeStatusWaitBuyerPay))
+	if e, ok := err.(*TradeStatusTransitionError); !ok || e.From != TradeStatusSuccess || e.To != TradeStatusWaitBuyerPay {
+		t.Errorf("Check() out of order = %v", err)
+	}
+	// 乱序通知重试时按重复通知处理
+	if _, err = checker.Check(notify("n0", TradeStatusWaitBuyerPay)); err != ErrNotifyDuplicate {
+		t.Errorf("Check() out of order retry = %v, want ErrNotifyDuplicate", err)
+	}
+	// 业务处理失败回滚后，交易状态恢复，重试通知可重新处理
+	bm := notify("n3", TradeStatusFinished)
+	if prev, err = checker.Check(bm); err != nil {
+		t.Fatal(err)
+	}
+	if err = checker.Rollback(bm, prev); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := checker.store.GetTradeStatus("GZ201909081743431443"); status != TradeStatusSuccess {
+		t.Errorf("trade_status after Rollback() = %s, want %s", status, TradeStatusSuccess)
+	}
+	if _, err = checker.Check(bm); err != nil {
+		t.Errorf("Check() after Rollback() = %v", err)
+	}
+	if err = checker.Commit(bm); err != nil {
+		t.Fatal(err)
+	}
+	// 终态
+	if _, err = checker.Check(notify("n4", TradeStatusSuccess)); err == nil {
+		t.Error("Check() TRADE_FINISHED -> TRADE_SUCCESS should return error")
+	}
+	if _, err = checker.Check(make(gopay.BodyMap)); err == nil {
+		t.Error("Check() without notify_id should return error")
+	}
+}
+
+func TestNotifyChecker_ProcessingTTL(t *testing.T) {
+	checker := NewNotifyChecker(nil, 50*time.Millisecond)
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_id", "n1").
+		Set("out_trade_no", "GZ201909081743431443").
+		Set("trade_status", TradeStatusSuccess)
+
+	if _, err := checker.Check(bm); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checker.Check(bm); err != ErrNotifyProcessing {
+		t.Fatalf("Check() processing = %v, want ErrNotifyProcessing", err)
+	}
+	// 未提交（如进程崩溃）的处理中记录过期后，重试通知可重新处理
+	time.Sleep(100 * time.Millisecond)
+	if _, err := checker.Check(bm); err != nil {
+		t.Fatalf("Check() after ttl = %v", err)
+	}
+	if err := checker.Commit(bm); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := checker.Check(bm); err != ErrNotifyDuplicate {
+		t.Errorf("Check() after Commit() = %v, want ErrNotifyDuplicate", err)
+	}
+}
+
+// failingNotifyStore 更新交易状态时返回错误
+type failingNotifyStore struct {
+	*MemoryNotifyStore
+}
+
+func (s *failingNotifyStore) CompareAndSwapTradeStatus(outTradeNo, oldStatus, newStatus string) (bool, error) {
+	return false, errors.New("store unavailable")
+}
+
+func TestNotifyChecker_StoreError(t *testing.T) {
+	store := &failingNotifyStore{NewMemoryNotifyStore()}
+	checker := NewNotifyChecker(store, 0)
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_id", "n1").
+		Set("out_trade_no", "GZ201909081743431443").
+		Set("trade_status", TradeStatusSuccess)
+
+	if _, err := checker.Check(bm); err == nil {
+		t.Fatal("Check() with store error should return error")
+	}
+	// 存储出错时 notify_id 不保留记录，重试通知可重新处理
+	if state, _ := store.MarkNotify("n1", time.Minute); state != NotifyStateNone {
+		t.Error("notify_id should be unmarked after store error")
+	}
+	if status, _ := store.GetTradeStatus("GZ201909081743431443"); status != "" {
+		t.Errorf("trade_status = %s, want empty", status)
+	}
+	if err := checker.Rollback(bm, TradeStatusWaitBuyerPay); err == nil {
+		t.Error("Rollback() with store error should return error")
+	}
+}
@@ -1,10 +1,12 @@
 package alipay
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/util"
 )
 
@@ -30,6 +32,83 @@ type RoyaltyDetailInfoPojo struct {
 	Desc         string `json:"desc,omitempty"`
 }
 
+// TradePagePayBiz 电脑网站支付 biz_content 常用字段，通过 ToBodyMap() 转为 BodyMap 后调用 client.TradePagePay()、client.TradePagePayForm()
+type TradePagePayBiz struct {
+	OutTradeNo     string `json:"out_trade_no"`              // 商户订单号
+	TotalAmount    string `json:"total_amount"`              // 订单总金额，单位为元，精确到小数点后两位
+	Subject        string `json:"subject"`                   // 订单标题
+	ProductCode    string `json:"product_code,omitempty"`    // 销售产品码，固定为 FAST_INSTANT_TRADE_PAY，为空时自动设置
+	ReturnUrl      string `json:"return_url,omitempty"`      // 支付完成后的同步跳转地址，不为空时覆盖 client.SetReturnUrl() 的设置
+	Body           string `json:"body,omitempty"`            // 订单附加信息
+	TimeExpire     string `json:"time_expire,omitempty"`     // 订单绝对超时时间，格式为 yyyy-MM-dd HH:mm:ss
+	PassbackParams string `json:"passback_params,omitempty"` // 公用回传参数，异步通知时原样返回，需 UrlEncode
+	QrPayMode      string `json:"qr_pay_mode,omitempty"`     // PC扫码支付的方式
+	QrcodeWidth    string `json:"qrcode_width,omitempty"`    // 商户自定义二维码宽度，qr_pay_mode = 4 时有效
+}
+
+// ToBodyMap 转为 BodyMap，可继续 Set 其他字段
+func (b *TradePagePayBiz) ToBodyMap() (bm gopay.BodyMap) {
+	return toBodyMap(b)
+}
+
+// TradeWapPayBiz 手机网站支付 biz_content 常用字段，通过 ToBodyMap() 转为 BodyMap 后调用 client.TradeWapPay()、client.TradeWapPayForm()
+type TradeWapPayBiz struct {
+	OutTradeNo     string `json:"out_trade_no"`              // 商户订单号
+	TotalAmount    string `json:"total_amount"`              // 订单总金额，单位为元，精确到小数点后两位
+	Subject        string `json:"subject"`                   // 订单标题
+	QuitUrl        string `json:"quit_url"`                  // 用户付款中途退出返回商户网站的地址
+	ProductCode    string `json:"product_code,omitempty"`    // 销售产品码，固定为 QUICK_WAP_WAY，为空时自动设置
+	ReturnUrl      string `json:"return_url,omitempty"`      // 支付完成后的同步跳转地址，不为空时覆盖 client.SetReturnUrl() 的设置
+	Body           string `json:"body,omitempty"`            // 订单附加信息
+	TimeExpire     string `json:"time_expire,omitempty"`     // 订单绝对超时时间，格式为 yyyy-MM-dd HH:mm:ss
+	PassbackParams string `json:"passback_params,omitempty"` // 公用回传参数，异步通知时原样返回，需 UrlEncode
+}
+
+// ToBodyMap 转为 BodyMap，可继续 Set 其他字段
+func (b *TradeWapPayBiz) ToBodyMap() (bm gopay.BodyMap) {
+	return toBodyMap(b)
+}
+
+// TradeOrderInfoSyncBiz 订单信息同步 biz_content 字段，通过 ToBodyMap() 转为 BodyMap 后调用 client.TradeOrderInfoSync()
+type TradeOrderInfoSyncBiz struct {
+	OutRequestNo  string        `json:"out_request_no"`            // 外部请求号，标识一次同步请求
+	TradeNo       string        `json:"trade_no"`                  // 支付宝交易号
+	BizType       string        `json:"biz_type"`                  // 同步信息对应的业务类型：CREDIT_AUTH、CREDIT_DEDUCT
+	OrigRequestNo string        `json:"orig_request_no,omitempty"` // 原始业务请求单号，如对某一次退款进行履约时，该字段传退款时的退款请求号
+	OrderBizInfo  *OrderBizInfo `json:"-"`                         // 商户传入同步信息，ToBodyMap() 时转为JSON字符串
+}
+
+// OrderBizInfo 订单信息同步的 order_biz_info
+type OrderBizInfo struct {
+	Status string `json:"status"` // 订单状态：COMPLETE、CLOSED、VIOLATED
+}
+
+// ToBodyMap 转为 BodyMap，可继续 Set 其他字段
+func (b *TradeOrderInfoSyncBiz) ToBodyMap() (bm gopay.BodyMap) {
+	bm = toBodyMap(b)
+	if b.OrderBizInfo != nil {
+		bs, _ := json.Marshal(b.OrderBizInfo)
+		bm.Set("order_biz_info", string(bs))
+	}
+	return bm
+}
+
+// TransPayeeInfo 单笔转账收款方信息，可直接 Set 到 client.FundTransUniTransfer() 的 payee_info 字段
+//
+//	bm.Set("payee_info", &alipay.TransPayeeInfo{Identity: "2088123412341234", IdentityType: alipay.IdentityTypeUserId})
+type TransPayeeInfo struct {
+	Identity     string `json:"identity"`       // 参与方的标识ID
+	IdentityType string `json:"identity_type"`  // 参与方的标识类型：ALIPAY_USER_ID、ALIPAY_LOGON_ID
+	Name         string `json:"name,omitempty"` // 参与方真实姓名，identity_type = ALIPAY_LOGON_ID 时必填
+}
+
+func toBodyMap(v interface{}) (bm gopay.BodyMap) {
+	bm = make(gopay.BodyMap)
+	bs, _ := json.Marshal(v)
+	_ = json.Unmarshal(bs, &bm)
+	return bm
+}
+
 // Deprecated
 func (a *Client) SetPrivateKeyType(t PKCSType) (client *Client) {
 	return a
@@ -33,6 +33,105 @@ func (a *Client) TradePay(bm gopay.BodyMap) (aliRsp *TradePayResponse, err error
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
+// alipay.trade.pay(统一收单交易支付接口)，当面付条码支付（商家扫用户付款码）
+//	scene 为空时默认 bar_code，auth_code 为用户付款码
+//	返回 code = 10003（等待用户付款，如需用户输入密码）时，err 为 nil，aliRsp.Response.Code = alipay.CodeUserPaying，
+//	此时需调用 client.TradeQuery() 轮询订单状态，超时未支付时调用 client.TradeCancel() 撤销订单
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.pay
+func (a *Client) TradePayBarCode(bm gopay.BodyMap) (aliRsp *TradePayResponse, err error) {
+	if bm.GetString("scene") == util.NULL {
+		bm.Set("scene", SceneBarCode)
+	}
+	err = bm.CheckEmptyError("out_trade_no", "total_amount", "subject", "auth_code")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.trade.pay"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(TradePayResponse)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != CodeSuccess && aliRsp.Response.Code != CodeUserPaying {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.trade.pay(统一收单交易支付接口)，资金预授权转支付
+//	auth_no：资金授权冻结接口返回的资金授权订单号
+//	product_code 为空时默认 PRE_AUTH（当面资金授权），线上资金授权请传 PRE_AUTH_ONLINE
+//	auth_confirm_mode 为 COMPLETE 时转支付后剩余冻结金额自动解冻，为 NOT_COMPLETE 时需调用 client.FundAuthOrderUnfreeze() 解冻
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.pay
+func (a *Client) TradePayWithAuth(bm gopay.BodyMap) (aliRsp *TradePayResponse, err error) {
+	if bm.GetString("product_code") == util.NULL {
+		bm.Set("product_code", "PRE_AUTH")
+	}
+	err = bm.CheckEmptyError("auth_no", "total_amount")
+	if err != nil {
+		return nil, err
+	}
+	return a.TradePay(bm)
+}
+
+// alipay.trade.pay(统一收单交易支付接口)，协议代扣
+//	agreementNo：支付宝个人代扣协议号，签约通知或 client.UserAgreementQuery() 返回的 agreement_no
+//	product_code 为空时默认 GENERAL_WITHHOLDING（商户代扣），周期扣款请传 CYCLE_PAY_AUTH
+//	agreement_params 可为 gopay.BodyMap、map[string]interface{} 或 JSON 字符串，agreement_no 合并到其中
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.pay
+func (a *Client) TradePayWithAgreement(agreementNo string, bm gopay.BodyMap) (aliRsp *TradePayResponse, err error) {
+	if agreementNo == util.NULL {
+		return nil, errors.New("agreementNo is empty")
+	}
+	if bm, err = setAgreementParams(bm, agreementNo); err != nil {
+		return nil, err
+	}
+	err = bm.CheckEmptyError("total_amount")
+	if err != nil {
+		return nil, err
+	}
+	return a.TradePay(bm)
+}
+
+// setAgreementParams 返回设置了 product_code 及 agreement_params.agreement_no 的 bm 副本，不修改调用方传入的 bm
+func setAgreementParams(bm gopay.BodyMap, agreementNo string) (gopay.BodyMap, error) {
+	bm = bm.Clone()
+	if bm == nil {
+		bm = make(gopay.BodyMap)
+	}
+	if bm.GetString("product_code") == util.NULL {
+		bm.Set("product_code", "GENERAL_WITHHOLDING")
+	}
+	params := make(gopay.BodyMap)
+	switch v := bm.GetInterface("agreement_params").(type) {
+	case nil:
+	case gopay.BodyMap:
+		for k, vv := range v {
+			params[k] = vv
+		}
+	case map[string]interface{}:
+		for k, vv := range v {
+			params[k] = vv
+		}
+	case string:
+		if v != util.NULL {
+			if err := json.Unmarshal([]byte(v), &params); err != nil {
+				return nil, fmt.Errorf("agreement_params json.Unmarshal(%s)：%w", v, err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("agreement_params type %T is not supported", v)
+	}
+	params.Set("agreement_no", agreementNo)
+	bm.Set("agreement_params", params)
+	return bm, nil
+}
+
 // alipay.trade.precreate(统一收单线下交易预创建)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.precreate
 func (a *Client) TradePrecreate(bm gopay.BodyMap) (aliRsp *TradePrecreateResponse, err error) {
@@ -77,6 +176,8 @@ func (a *Client) TradeAppPay(bm gopay.BodyMap) (payParam string, err error) {
 }
 
 // alipay.trade.wap.pay(手机网站支付接口2.0)
+//	bm 中的 quit_url 为用户付款中途退出返回商户网站的地址
+//	bm 中的 return_url 不为空时，作为公共参数覆盖 client.SetReturnUrl() 的设置
 //	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.wap.pay
 func (a *Client) TradeWapPay(bm gopay.BodyMap) (payUrl string, err error) {
 	bm.Set("product_code", "QUICK_WAP_WAY")
@@ -92,7 +193,19 @@ func (a *Client) TradeWapPay(bm gopay.BodyMap) (payUrl string, err error) {
 	return payUrl, nil
 }
 
+// alipay.trade.wap.pay(手机网站支付接口2.0)，返回自动提交的HTML表单
+//	将 form 直接输出到浏览器页面，即可跳转至支付宝收银台
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.wap.pay
+func (a *Client) TradeWapPayForm(bm gopay.BodyMap) (form string, err error) {
+	payUrl, err := a.TradeWapPay(bm)
+	if err != nil {
+		return util.NULL, err
+	}
+	return buildPayForm(payUrl)
+}
+
 // alipay.trade.page.pay(统一收单下单并支付页面接口)
+//	bm 中的 return_url 不为空时，作为公共参数覆盖 client.SetReturnUrl() 的设置
 //	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.page.pay
 func (a *Client) TradePagePay(bm gopay.BodyMap) (payUrl string, err error) {
 	bm.Set("product_code", "FAST_INSTANT_TRADE_PAY")
@@ -108,6 +221,17 @@ func (a *Client) TradePagePay(bm gopay.BodyMap) (payUrl string, err error) {
 	return payUrl, nil
 }
 
+// alipay.trade.page.pay(统一收单下单并支付页面接口)，返回自动提交的HTML表单
+//	将 form 直接输出到浏览器页面，即可跳转至支付宝收银台
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.page.pay
+func (a *Client) TradePagePayForm(bm gopay.BodyMap) (form string, err error) {
+	payUrl, err := a.TradePagePay(bm)
+	if err != nil {
+		return util.NULL, err
+	}
+	return buildPayForm(payUrl)
+}
+
 // alipay.trade.create(统一收单交易创建接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.create
 func (a *Client) TradeCreate(bm gopay.BodyMap) (aliRsp *TradeCreateResponse, err error) {
@@ -202,6 +326,7 @@ func (a *Client) TradeClose(bm gopay.BodyMap) (aliRsp *TradeCloseResponse, err e
 }
 
 // alipay.trade.refund(统一收单交易退款接口)
+//	部分退款、同一笔交易多次退款时必须传 out_request_no，同一 out_request_no 重复请求视为同一笔退款，推荐使用 client.TradePartialRefund()
 //	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.refund
 func (a *Client) TradeRefund(bm gopay.BodyMap) (aliRsp *TradeRefundResponse, err error) {
 	if bm.GetString("out_trade_no") == util.NULL && bm.GetString("trade_no") == util.NULL {
@@ -228,6 +353,18 @@ func (a *Client) TradeRefund(bm gopay.BodyMap) (aliRsp *TradeRefundResponse, err
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
+// alipay.trade.refund(统一收单交易退款接口)，部分退款
+//	out_request_no：退款请求号，同一笔交易多次部分退款时需保证唯一，重试时使用相同的 out_request_no 避免重复退款
+//	退款结果可通过 client.TradeFastPayRefundQuery() 使用相同的 out_request_no 查询
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.refund
+func (a *Client) TradePartialRefund(bm gopay.BodyMap) (aliRsp *TradeRefundResponse, err error) {
+	err = bm.CheckEmptyError("out_request_no")
+	if err != nil {
+		return nil, err
+	}
+	return a.TradeRefund(bm)
+}
+
 // alipay.trade.page.refund(统一收单退款页面接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.page.refund
 func (a *Client) TradePageRefund(bm gopay.BodyMap) (aliRsp *TradePageRefundResponse, err error) {
@@ -306,6 +443,27 @@ func (a *Client) TradeOrderSettle(bm gopay.BodyMap) (aliRsp *TradeOrderSettleRes
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
+// alipay.trade.order.settle.query(交易分账查询接口)
+//	settle_no 或 out_request_no、trade_no 二选一
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.order.settle.query
+func (a *Client) TradeOrderSettleQuery(bm gopay.BodyMap) (aliRsp *TradeOrderSettleQueryRsp, err error) {
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.trade.order.settle.query"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(TradeOrderSettleQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
 // alipay.trade.orderinfo.sync(支付宝订单信息同步接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.orderinfo.sync
 func (a *Client) TradeOrderInfoSync(bm gopay.BodyMap) (aliRsp *TradeOrderInfoSyncRsp, err error) {
@@ -537,3 +695,75 @@ func (a *Client) TradeRepaybillQuery(bm gopay.BodyMap) (aliRsp *TradeRepaybillQu
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
+
+// alipay.trade.royalty.relation.bind(分账关系绑定)
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.royalty.relation.bind
+func (a *Client) TradeRoyaltyRelationBind(bm gopay.BodyMap) (aliRsp *TradeRoyaltyRelationBindRsp, err error) {
+	err = bm.CheckEmptyError("receiver_list", "out_request_no")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.trade.royalty.relation.bind"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(TradeRoyaltyRelationBindRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.trade.royalty.relation.unbind(分账关系解绑)
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.royalty.relation.unbind
+func (a *Client) TradeRoyaltyRelationUnbind(bm gopay.BodyMap) (aliRsp *TradeRoyaltyRelationUnbindRsp, err error) {
+	err = bm.CheckEmptyError("receiver_list", "out_request_no")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.trade.royalty.relation.unbind"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(TradeRoyaltyRelationUnbindRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
+
+// alipay.trade.royalty.relation.batchquery(分账关系查询)
+//	文档地址：https://opendocs.alipay.com/apis/api_1/alipay.trade.royalty.relation.batchquery
+func (a *Client) TradeRoyaltyRelationBatchQuery(bm gopay.BodyMap) (aliRsp *TradeRoyaltyRelationBatchQueryRsp, err error) {
+	err = bm.CheckEmptyError("out_request_no")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(bm, "alipay.trade.royalty.relation.batchquery"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(TradeRoyaltyRelationBatchQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	aliRsp.SignData = signData
+	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+}
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/cedarwu/gopay"
@@ -52,6 +54,115 @@ func TestClient_TradeCreate(t *testing.T) {
 	xlog.Debug("aliRsp.TradeNo:", aliRsp.Response.TradeNo)
 }
 
+func TestClient_TradePayBarCode(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("subject", "条码支付").
+		Set("out_trade_no", "GZ201909081743431443").
+		Set("total_amount", "0.01")
+
+	// 缺少付款码
+	if _, err := client.TradePayBarCode(bm); err == nil {
+		t.Fatal("missing auth_code should return error")
+	}
+	if bm.GetString("scene") != SceneBarCode {
+		t.Errorf("scene = %s, want %s", bm.GetString("scene"), SceneBarCode)
+	}
+
+	// 条码支付请求
+	bm.Set("auth_code", "289756915257123456")
+	aliRsp, err := client.TradePayBarCode(bm)
+	if err != nil {
+		xlog.Errorf("client.TradePayBarCode(%+v),error:%+v", bm, err)
+		return
+	}
+	if aliRsp.Response.Code == CodeUserPaying {
+		xlog.Debug("等待用户付款，请轮询查询订单状态")
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
+}
+
+func TestClient_TradePayWithAuth(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("subject", "预授权转支付").
+		Set("out_trade_no", util.GetRandomString(32)).
+		Set("total_amount", "0.01").
+		Set("auth_confirm_mode", "COMPLETE")
+
+	// 缺少资金授权订单号
+	if _, err := client.TradePayWithAuth(bm); err == nil {
+		t.Fatal("missing auth_no should return error")
+	}
+
+	// 预授权转支付请求
+	bm.Set("auth_no", "2016110310002001760201905725")
+	aliRsp, err := client.TradePayWithAuth(bm)
+	if err != nil {
+		xlog.Errorf("client.TradePayWithAuth(%+v),error:%+v", bm, err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
+}
+
+func TestClient_TradePayWithAgreement(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("subject", "协议代扣").
+		Set("out_trade_no", util.GetRandomString(32)).
+		Set("total_amount", "0.01")
+
+	// 缺少协议号
+	if _, err := client.TradePayWithAgreement("", bm); err == nil {
+		t.Fatal("missing agreementNo should return error")
+	}
+
+	// 协议代扣请求
+	aliRsp, err := client.TradePayWithAgreement("20170322450983769228", bm)
+	if err != nil {
+		xlog.Errorf("client.TradePayWithAgreement(%+v),error:%+v", bm, err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
+}
+
+func TestSetAgreementParams(t *testing.T) {
+	for _, params := range []interface{}{
+		gopay.BodyMap{"deduct_permission": "9988"},
+		map[string]interface{}{"deduct_permission": "9988"},
+		`{"deduct_permission":"9988"}`,
+	} {
+		bm := make(gopay.BodyMap)
+		bm.Set("total_amount", "0.01").
+			Set("agreement_params", params)
+		rbm, err := setAgreementParams(bm, "20170322450983769228")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ap, ok := rbm.GetInterface("agreement_params").(gopay.BodyMap)
+		if !ok || ap.GetString("agreement_no") != "20170322450983769228" || ap.GetString("deduct_permission") != "9988" {
+			t.Fatalf("agreement_params = %+v", rbm.GetInterface("agreement_params"))
+		}
+		if rbm.GetString("product_code") != "GENERAL_WITHHOLDING" {
+			t.Fatalf("product_code = %s", rbm.GetString("product_code"))
+		}
+		// 调用方传入的 bm 不应被修改
+		if _, ok = bm["product_code"]; ok || bm.GetInterface("agreement_params") == nil {
+			t.Fatalf("caller BodyMap was mutated: %+v", bm)
+		}
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("agreement_params", "invalid")
+	if _, err := setAgreementParams(bm, "20170322450983769228"); err == nil {
+		t.Fatal("invalid agreement_params should return error")
+	}
+	bm.Set("agreement_params", []string{"invalid"})
+	if _, err := setAgreementParams(bm, "20170322450983769228"); err == nil {
+		t.Fatal("unsupported agreement_params type should return error")
+	}
+}
+
 func TestClient_TradeAppPay(t *testing.T) {
 	// 请求参数
 	bm := make(gopay.BodyMap)
@@ -161,6 +272,36 @@ func TestClient_TradeWapPay(t *testing.T) {
 	xlog.Debug("payUrl:", payUrl)
 }
 
+func TestClient_TradeWapPayForm(t *testing.T) {
+	// 请求参数
+	biz := &TradeWapPayBiz{
+		OutTradeNo:  "GZ201909081743431443",
+		TotalAmount: "100.00",
+		Subject:     "手机网站测试支付",
+		QuitUrl:     "https://www.fmm.ink/quit",
+		ReturnUrl:   "https://www.fmm.ink/return",
+	}
+
+	// 手机网站支付请求，返回自动提交的表单
+	bm := biz.ToBodyMap()
+	form, err := client.TradeWapPayForm(bm)
+	if err != nil {
+		t.Fatalf("client.TradeWapPayForm(),error:%+v", err)
+	}
+	if bm.GetString("return_url") != biz.ReturnUrl {
+		t.Error("caller BodyMap return_url was removed")
+	}
+	xlog.Debug("form:", form)
+	for _, v := range []string{"method=alipay.trade.wap.pay", "return_url=https%3A%2F%2Fwww.fmm.ink%2Freturn", "QUICK_WAP_WAY", "https://www.fmm.ink/quit"} {
+		if !strings.Contains(form, v) {
+			t.Errorf("form missing %s", v)
+		}
+	}
+	if strings.Contains(form, "&#34;return_url&#34;") {
+		t.Error("return_url should not be in biz_content")
+	}
+}
+
 func TestClient_TradePagePay(t *testing.T) {
 	// 请求参数
 	bm := make(gopay.BodyMap)
@@ -176,6 +317,53 @@ func TestClient_TradePagePay(t *testing.T) {
 		return
 	}
 	xlog.Debug("payUrl:", payUrl)
+	// 沙箱环境
+	if !strings.HasPrefix(payUrl, "https://openapi-sandbox.dl.alipaydev.com/gateway.do?") {
+		t.Errorf("payUrl = %s, want sandbox gateway", payUrl)
+	}
+}
+
+func TestClient_TradePagePayForm(t *testing.T) {
+	// 请求参数
+	biz := &TradePagePayBiz{
+		OutTradeNo:  "GZ201909081743431443",
+		TotalAmount: "88.88",
+		Subject:     "网站测试支付",
+	}
+
+	// 电脑网站支付请求，返回自动提交的表单
+	form, err := client.TradePagePayForm(biz.ToBodyMap())
+	if err != nil {
+		t.Fatalf("client.TradePagePayForm(),error:%+v", err)
+	}
+	xlog.Debug("form:", form)
+	for _, v := range []string{`method="post"`, "method=alipay.trade.page.pay", "sign=", `name="biz_content"`, "FAST_INSTANT_TRADE_PAY", "document.forms[0].submit()"} {
+		if !strings.Contains(form, v) {
+			t.Errorf("form missing %s", v)
+		}
+	}
+}
+
+func TestClient_TradePartialRefund(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "GZ201909081743431443").
+		Set("refund_amount", "5").
+		Set("refund_reason", "测试部分退款")
+
+	// 缺少退款请求号
+	if _, err := client.TradePartialRefund(bm); err == nil {
+		t.Fatal("missing out_request_no should return error")
+	}
+
+	// 部分退款请求
+	bm.Set("out_request_no", util.GetRandomString(32))
+	aliRsp, err := client.TradePartialRefund(bm)
+	if err != nil {
+		xlog.Error("err:", err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
 }
 
 func TestClient_TradeRefund(t *testing.T) {
@@ -246,6 +434,28 @@ func TestClient_TradeOrderSettle(t *testing.T) {
 	xlog.Debug("aliRsp:", *aliRsp)
 }
 
+func TestClient_TradeOrderInfoSync(t *testing.T) {
+	// 请求参数
+	biz := &TradeOrderInfoSyncBiz{
+		OutRequestNo: "GZ201909081743431443S1",
+		TradeNo:      "2019072522001484690549776067",
+		BizType:      OrderInfoSyncBizTypeCreditAuth,
+		OrderBizInfo: &OrderBizInfo{Status: OrderBizStatusComplete},
+	}
+	bm := biz.ToBodyMap()
+	if bm.GetString("order_biz_info") != `{"status":"COMPLETE"}` {
+		t.Errorf("order_biz_info = %s", bm.GetString("order_biz_info"))
+	}
+
+	// 订单信息同步
+	aliRsp, err := client.TradeOrderInfoSync(bm)
+	if err != nil {
+		xlog.Errorf("client.TradeOrderInfoSync(%+v),error:%+v", bm, err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
+}
+
 // 订单咨询服务测试
 func TestClient_TradeAdvanceConsult(t *testing.T) {
 	// 请求参数
@@ -404,3 +614,67 @@ func TestClient_TradeRepaybillQuery(t *testing.T) {
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
 }
+
+func TestClient_TradeRoyaltyRelationBind(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("out_request_no", util.GetRandomString(32)).
+		Set("receiver_list", []gopay.BodyMap{
+			{"type": "userId", "account": "2088101126708402", "memo": "分账给测试商户"},
+		})
+
+	// 分账关系绑定
+	aliRsp, err := client.TradeRoyaltyRelationBind(bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
+}
+
+func TestClient_TradeOrderSettleQuery(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("settle_no", "20211231002530000001")
+
+	// 交易分账查询
+	aliRsp, err := client.TradeOrderSettleQuery(bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp.Response)
+}
+
+func TestClient_CloneWithAppAuthToken(t *testing.T) {
+	isvClient := client.CloneWithAppAuthToken("202109BB4c2b7b0a4d0d4e0f8b1a2c3d4e5f6X18")
+	bm := make(gopay.BodyMap)
+	bm.Set("subject", "网站测试支付").
+		Set("out_trade_no", "GZ201909081743431443").
+		Set("total_amount", "88.88")
+
+	payUrl, err := isvClient.TradePagePay(bm)
+	if err != nil {
+		xlog.Errorf("isvClient.TradePagePay(%+v),error:%+v", bm, err)
+		return
+	}
+	u, err := url.Parse(payUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token := u.Query().Get("app_auth_token"); token != "202109BB4c2b7b0a4d0d4e0f8b1a2c3d4e5f6X18" {
+		t.Errorf("app_auth_token = %s", token)
+	}
+	if client.AppAuthToken != "" {
+		t.Errorf("client.AppAuthToken = %s, should not be modified", client.AppAuthToken)
+	}
+
+	// 单次请求指定 app_auth_token，覆盖 client 设置
+	bm.Set("app_auth_token", "202109BBother")
+	if payUrl, err = isvClient.TradePagePay(bm); err != nil {
+		t.Fatal(err)
+	}
+	if u, _ = url.Parse(payUrl); u.Query().Get("app_auth_token") != "202109BBother" {
+		t.Errorf("payUrl = %s", payUrl)
+	}
+}
@@ -0,0 +1,278 @@
+package alipay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xpem"
+	"github.com/cedarwu/gopay/pkg/xrsa"
+)
+
+// ProviderName 支付宝在 gopay.RegisterProvider() 中注册的渠道名称
+const ProviderName = "alipay"
+
+func init() {
+	gopay.RegisterProvider(ProviderName, newProvider)
+}
+
+// provider 支付宝的 gopay.Provider 实现
+type provider struct {
+	client          *Client
+	alipayPublicKey string
+}
+
+// newProvider 根据配置创建支付宝 Provider，同步应答及异步通知均使用支付宝公钥验签
+//	config 参数：
+//	app_id、private_key：必填，应用ID、应用私钥
+//	alipay_public_key：必填，支付宝公钥（公钥模式，不含 PEM 头尾）
+//	is_prod：是否是正式环境，true 或 false
+//	notify_url、return_url：默认异步通知地址、支付完成跳转地址
+func newProvider(config gopay.BodyMap) (p gopay.Provider, err error) {
+	if err = config.CheckEmptyError("app_id", "private_key", "alipay_public_key"); err != nil {
+		return nil, err
+	}
+	var isProd bool
+	if s := config.GetString("is_prod"); s != util.NULL {
+		if isProd, err = strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("is_prod error：%w", err)
+		}
+	}
+	client, err := NewClient(config.GetString("app_id"), config.GetString("private_key"), isProd)
+	if err != nil {
+		return nil, err
+	}
+	publicKey := config.GetString("alipay_public_key")
+	pemKey := []byte(xrsa.FormatAlipayPublicKey(publicKey))
+	if _, err = xpem.DecodePublicKey(pemKey); err != nil {
+		return nil, err
+	}
+	client.AutoVerifySign(pemKey)
+	client.SetNotifyUrl(config.GetString("notify_url")).
+		SetReturnUrl(config.GetString("return_url"))
+	return &provider{client: client, alipayPublicKey: publicKey}, nil
+}
+
+// CreateOrder 下单，支付宝接口不支持 ctx
+//	Scene 与接口对应关系：NATIVE alipay.trade.precreate、JSAPI alipay.trade.create、APP alipay.trade.app.pay、
+//	H5 alipay.trade.wap.pay、PAGE alipay.trade.page.pay
+//	PayInfo：NATIVE 返回 qr_code，JSAPI 返回 trade_no，APP 返回支付参数，H5、PAGE 返回支付跳转地址
+func (p *provider) CreateOrder(ctx context.Context, req *gopay.OrderRequest) (rsp *gopay.OrderResponse, err error) {
+	client := p.client
+	if req.NotifyUrl != util.NULL {
+		c := *p.client
+		c.NotifyUrl = req.NotifyUrl
+		client = &c
+	}
+	bm := make(gopay.BodyMap)
+	for k, v := range req.Extra {
+		bm.Set(k, v)
+	}
+	bm.Set("out_trade_no", req.OutTradeNo).
+		Set("total_amount", formatYuan(req.Amount)).
+		Set("subject", req.Subject)
+	rsp = &gopay.OrderResponse{OutTradeNo: req.OutTradeNo}
+	switch req.Scene {
+	case gopay.SceneNative:
+		aliRsp, err := client.TradePrecreate(bm)
+		if err != nil {
+			return nil, err
+		}
+		if aliRsp.Response == nil {
+			return nil, errors.New("alipay: empty alipay_trade_precreate_response")
+		}
+		rsp.PayInfo, rsp.Raw = aliRsp.Response.QrCode, toBodyMap(aliRsp.Response)
+	case gopay.SceneJSAPI:
+		bm.Set("buyer_id", req.OpenId)
+		aliRsp, err := client.TradeCreate(bm)
+		if err != nil {
+			return nil, err
+		}
+		if aliRsp.Response == nil {
+			return nil, errors.New("alipay: empty alipay_trade_create_response")
+		}
+		rsp.TradeNo, rsp.PayInfo, rsp.Raw = aliRsp.Response.TradeNo, aliRsp.Response.TradeNo, toBodyMap(aliRsp.Response)
+	case gopay.SceneApp:
+		if rsp.PayInfo, err = client.TradeAppPay(bm); err != nil {
+			return nil, err
+		}
+	case gopay.SceneH5, gopay.ScenePage:
+		if req.ReturnUrl != util.NULL {
+			bm.Set("return_url", req.ReturnUrl)
+		}
+		if req.Scene == gopay.SceneH5 {
+			rsp.PayInfo, err = client.TradeWapPay(bm)
+		} else {
+			rsp.PayInfo, err = client.TradePagePay(bm)
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("alipay: scene [%s]：%w", req.Scene, gopay.ErrNotSupported)
+	}
+	return rsp, nil
+}
+
+// QueryOrder 查询订单
+func (p *provider) QueryOrder(ctx context.Context, req *gopay.QueryRequest) (rsp *gopay.TradeResult, err error) {
+	bm := make(gopay.BodyMap)
+	setTradeNo(bm, req.OutTradeNo, req.TradeNo)
+	aliRsp, err := p.client.TradeQuery(bm)
+	if err != nil {
+		return nil, err
+	}
+	if aliRsp.Response == nil {
+		return nil, errors.New("alipay: empty alipay_trade_query_response")
+	}
+	info := aliRsp.Response
+	return &gopay.TradeResult{
+		OutTradeNo: info.OutTradeNo,
+		TradeNo:    info.TradeNo,
+		Status:     tradeStatus(info.TradeStatus),
+		Amount:     parseYuan(info.TotalAmount),
+		Raw:        toBodyMap(info),
+	}, nil
+}
+
+// Refund 申请退款，OutRefundNo 作为 out_request_no
+//	fund_change = Y 时 Status 为 gopay.RefundStatusSuccess，否则为 gopay.RefundStatusProcessing，需通过 QueryRefund 查询
+func (p *provider) Refund(ctx context.Context, req *gopay.RefundRequest) (rsp *gopay.RefundResult, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("out_request_no", req.OutRefundNo).
+		Set("refund_amount", formatYuan(req.RefundAmount))
+	if req.Reason != util.NULL {
+		bm.Set("refund_reason", req.Reason)
+	}
+	setTradeNo(bm, req.OutTradeNo, req.TradeNo)
+	aliRsp, err := p.client.TradePartialRefund(bm)
+	if err != nil {
+		return nil, err
+	}
+	if aliRsp.Response == nil {
+		return nil, errors.New("alipay: empty alipay_trade_refund_response")
+	}
+	rsp = &gopay.RefundResult{
+		OutRefundNo: req.OutRefundNo,
+		Status:      gopay.RefundStatusProcessing,
+		Amount:      req.RefundAmount,
+		Raw:         toBodyMap(aliRsp.Response),
+	}
+	if aliRsp.Response.FundChange == "Y" {
+		rsp.Status = gopay.RefundStatusSuccess
+	}
+	return rsp, nil
+}
+
+// QueryRefund 查询退款，OutRefundNo 必填
+//	未查询到退款或 refund_status 不为 REFUND_SUCCESS 时 Status 为 gopay.RefundStatusProcessing
+func (p *provider) QueryRefund(ctx context.Context, req *gopay.RefundQueryRequest) (rsp *gopay.RefundResult, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("out_request_no", req.OutRefundNo)
+	setTradeNo(bm, req.OutTradeNo, req.TradeNo)
+	aliRsp, err := p.client.TradeFastPayRefundQuery(bm)
+	if err != nil {
+		return nil, err
+	}
+	if aliRsp.Response == nil {
+		return nil, errors.New("alipay: empty alipay_trade_fastpay_refund_query_response")
+	}
+	info := aliRsp.Response
+	rsp = &gopay.RefundResult{
+		OutRefundNo: req.OutRefundNo,
+		Status:      gopay.RefundStatusProcessing,
+		Amount:      parseYuan(info.RefundAmount),
+		Raw:         toBodyMap(info),
+	}
+	if info.IsRefunded() {
+		rsp.Status = gopay.RefundStatusSuccess
+	}
+	return rsp, nil
+}
+
+// Close 关闭订单
+func (p *provider) Close(ctx context.Context, req *gopay.QueryRequest) (err error) {
+	bm := make(gopay.BodyMap)
+	setTradeNo(bm, req.OutTradeNo, req.TradeNo)
+	_, err = p.client.TradeClose(bm)
+	return err
+}
+
+// VerifyNotify 解析并验签支付结果通知，验签失败时返回错误
+func (p *provider) VerifyNotify(req *http.Request) (notify *gopay.TradeResult, err error) {
+	bm, err := ParseNotifyToBodyMap(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = VerifySign(p.alipayPublicKey, bm); err != nil {
+		return nil, fmt.Errorf("alipay: notify sign verify failed：%w", err)
+	}
+	return &gopay.TradeResult{
+		OutTradeNo: bm.GetString("out_trade_no"),
+		TradeNo:    bm.GetString("trade_no"),
+		Status:     tradeStatus(bm.GetString("trade_status")),
+		Amount:     parseYuan(bm.GetString("total_amount")),
+		Raw:        bm,
+	}, nil
+}
+
+// setTradeNo 优先使用商户订单号
+func setTradeNo(bm gopay.BodyMap, outTradeNo, tradeNo string) {
+	if outTradeNo != util.NULL {
+		bm.Set("out_trade_no", outTradeNo)
+		return
+	}
+	if tradeNo != util.NULL {
+		bm.Set("trade_no", tradeNo)
+	}
+}
+
+// tradeStatus 支付宝 trade_status 转换为通用交易状态
+func tradeStatus(status string) gopay.TradeStatus {
+	switch status {
+	case TradeStatusWaitBuyerPay:
+		return gopay.TradeStatusNotPay
+	case TradeStatusSuccess:
+		return gopay.TradeStatusSuccess
+	case TradeStatusClosed:
+		return gopay.TradeStatusClosed
+	case TradeStatusFinished:
+		return gopay.TradeStatusFinished
+	default:
+		return gopay.TradeStatusUnknown
+	}
+}
+
+// formatYuan 分转换为元，保留两位小数
+func formatYuan(fen int64) string {
+	sign := ""
+	if fen < 0 {
+		sign, fen = "-", -fen
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, fen/100, fen%100)
+}
+
+// parseYuan 元转换为分，不使用浮点数避免精度丢失，格式错误时返回 0
+func parseYuan(yuan string) int64 {
+	if yuan == util.NULL {
+		return 0
+	}
+	intPart, fracPart := yuan, ""
+	if i := strings.IndexByte(yuan, '.'); i >= 0 {
+		intPart, fracPart = yuan[:i], yuan[i+1:]
+	}
+	if len(fracPart) > 2 {
+		return 0
+	}
+	fracPart += strings.Repeat("0", 2-len(fracPart))
+	n, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
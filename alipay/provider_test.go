@@ -0,0 +1,260 @@
+package alipay
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/alipay/cert"
+)
+
+// newProviderTestKey 生成模拟支付宝公钥模式的密钥对，返回私钥及不含 PEM 头尾的公钥
+func newProviderTestKey(t *testing.T) (key *rsa.PrivateKey, publicKey string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, base64.StdEncoding.EncodeToString(der)
+}
+
+func rsa2Sign(t *testing.T, key *rsa.PrivateKey, data string) string {
+	h := sha256.Sum256([]byte(data))
+	sign, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(sign)
+}
+
+// newProviderTestServer 模拟支付宝网关：校验请求签名，按 method 返回 alipayKey 签名后的应答
+func newProviderTestServer(t *testing.T, appKey *rsa.PublicKey, alipayKey *rsa.PrivateKey) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+			return
+		}
+		req := make(gopay.BodyMap)
+		for k := range r.PostForm {
+			req.Set(k, r.PostForm.Get(k))
+		}
+		sign := req.GetString("sign")
+		req.Remove("sign")
+		if err := verifySignRSA2(req.EncodeAliPaySignParams(), sign, appKey); err != nil {
+			t.Errorf("request sign verify failed：%v", err)
+			return
+		}
+		biz := make(gopay.BodyMap)
+		_ = json.Unmarshal([]byte(req.GetString("biz_content")), &biz)
+		rsp := make(gopay.BodyMap)
+		rsp.Set("code", "10000").Set("msg", "Success")
+		switch req.GetString("method") {
+		case "alipay.trade.precreate":
+			rsp.Set("out_trade_no", biz.GetString("out_trade_no")).
+				Set("qr_code", "https://qr.alipay.com/bavh4wjlxf12tper3a")
+		case "alipay.trade.query":
+			if biz.GetString("out_trade_no") == "GOPAY_NOT_EXIST" {
+				rsp.Set("code", "40004").Set("msg", "Business Failed").
+					Set("sub_code", "ACQ.TRADE_NOT_EXIST").Set("sub_msg", "交易不存在")
+				break
+			}
+			rsp.Set("out_trade_no", biz.GetString("out_trade_no")).
+				Set("trade_no", "2022110122001450071438803941").
+				Set("trade_status", TradeStatusSuccess).
+				Set("total_amount", "1.01")
+		case "alipay.trade.refund":
+			rsp.Set("out_trade_no", biz.GetString("out_trade_no")).
+				Set("trade_no", "2022110122001450071438803941").
+				Set("fund_change", "Y").
+				Set("refund_fee", biz.GetString("refund_amount"))
+		case "alipay.trade.fastpay.refund.query":
+			rsp.Set("out_trade_no", biz.GetString("out_trade_no")).
+				Set("out_request_no", biz.GetString("out_request_no")).
+				Set("refund_amount", "1.00").
+				Set("refund_status", RefundStatusSuccess)
+		case "alipay.trade.close":
+			rsp.Set("out_trade_no", biz.GetString("out_trade_no"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content := rsp.JsonBody()
+		key := strings.Replace(req.GetString("method"), ".", "_", -1) + "_response"
+		_, _ = w.Write([]byte(`{"` + key + `":` + content + `,"sign":"` + rsa2Sign(t, alipayKey, content) + `"}`))
+	}))
+}
+
+func TestProvider(t *testing.T) {
+	alipayKey, alipayPublicKey := newProviderTestKey(t)
+	config := make(gopay.BodyMap)
+	config.Set("app_id", cert.Appid).
+		Set("private_key", cert.PrivateKey).
+		Set("alipay_public_key", alipayPublicKey).
+		Set("is_prod", false).
+		Set("notify_url", "https://www.fmm.ink/alipay/notify")
+	p, err := gopay.NewProvider(ProviderName, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := p.(*provider).client
+	ts := newProviderTestServer(t, &client.privateKey.PublicKey, alipayKey)
+	defer ts.Close()
+	client.apiUrl = ts.URL
+	ctx := context.Background()
+
+	orderRsp, err := p.CreateOrder(ctx, &gopay.OrderRequest{
+		OutTradeNo: "GOPAY20221101001",
+		Amount:     101,
+		Subject:    "测试商品",
+		Scene:      gopay.SceneNative,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orderRsp.PayInfo != "https://qr.alipay.com/bavh4wjlxf12tper3a" || orderRsp.Raw.GetString("out_trade_no") != "GOPAY20221101001" {
+		t.Errorf("CreateOrder() = %+v", orderRsp)
+	}
+	// 电脑网站支付返回跳转地址，不请求网关
+	pageRsp, err := p.CreateOrder(ctx, &gopay.OrderRequest{
+		OutTradeNo: "GOPAY20221101002",
+		Amount:     101,
+		Subject:    "测试商品",
+		Scene:      gopay.ScenePage,
+		ReturnUrl:  "https://www.fmm.ink/return",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(pageRsp.PayInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Query().Get("return_url") != "https://www.fmm.ink/return" || !strings.Contains(u.Query().Get("biz_content"), `"total_amount":"1.01"`) {
+		t.Errorf("CreateOrder(PAGE) = %s", pageRsp.PayInfo)
+	}
+
+	queryRsp, err := p.QueryOrder(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY20221101001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queryRsp.Status != gopay.TradeStatusSuccess || queryRsp.Amount != 101 || queryRsp.TradeNo != "2022110122001450071438803941" {
+		t.Errorf("QueryOrder() = %+v", queryRsp)
+	}
+	if _, err = p.QueryOrder(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY_NOT_EXIST"}); err == nil || !strings.Contains(err.Error(), "ACQ.TRADE_NOT_EXIST") {
+		t.Errorf("QueryOrder() of not exist order error = %v", err)
+	}
+
+	refundRsp, err := p.Refund(ctx, &gopay.RefundRequest{
+		OutTradeNo:   "GOPAY20221101001",
+		OutRefundNo:  "GOPAY20221101001R",
+		TotalAmount:  101,
+		RefundAmount: 100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refundRsp.Status != gopay.RefundStatusSuccess || refundRsp.Amount != 100 || refundRsp.Raw.GetString("refund_fee") != "1.00" {
+		t.Errorf("Refund() = %+v", refundRsp)
+	}
+
+	refundQueryRsp, err := p.QueryRefund(ctx, &gopay.RefundQueryRequest{OutTradeNo: "GOPAY20221101001", OutRefundNo: "GOPAY20221101001R"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refundQueryRsp.Status != gopay.RefundStatusSuccess || refundQueryRsp.Amount != 100 {
+		t.Errorf("QueryRefund() = %+v", refundQueryRsp)
+	}
+
+	if err = p.Close(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY20221101001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 应答不是支付宝签名
+	otherKey, _ := newProviderTestKey(t)
+	ts2 := newProviderTestServer(t, &client.privateKey.PublicKey, otherKey)
+	defer ts2.Close()
+	client.apiUrl = ts2.URL
+	if _, err = p.QueryOrder(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY20221101001"}); err == nil {
+		t.Error("QueryOrder() with forged response sign should return error")
+	}
+}
+
+func TestProvider_VerifyNotify(t *testing.T) {
+	alipayKey, alipayPublicKey := newProviderTestKey(t)
+	config := make(gopay.BodyMap)
+	config.Set("app_id", cert.Appid).
+		Set("private_key", cert.PrivateKey).
+		Set("alipay_public_key", alipayPublicKey)
+	p, err := gopay.NewProvider(ProviderName, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notify := make(gopay.BodyMap)
+	notify.Set("notify_id", "2022110100222143522014501441138439").
+		Set("notify_type", "trade_status_sync").
+		Set("app_id", cert.Appid).
+		Set("out_trade_no", "GOPAY20221101001").
+		Set("trade_no", "2022110122001450071438803941").
+		Set("trade_status", TradeStatusSuccess).
+		Set("total_amount", "1.01")
+	newRequest := func(bm gopay.BodyMap) *http.Request {
+		form := url.Values{}
+		for k := range bm {
+			form.Set(k, bm.GetString(k))
+		}
+		form.Set("sign_type", RSA2)
+		form.Set("sign", rsa2Sign(t, alipayKey, notify.EncodeAliPaySignParams()))
+		req := httptest.NewRequest(http.MethodPost, "/alipay/notify", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	rsp, err := p.VerifyNotify(newRequest(notify))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Status != gopay.TradeStatusSuccess || rsp.Amount != 101 || rsp.OutTradeNo != "GOPAY20221101001" {
+		t.Errorf("VerifyNotify() = %+v", rsp)
+	}
+	// 通知金额被篡改
+	tampered := notify.Clone().Set("total_amount", "0.01")
+	if _, err = p.VerifyNotify(newRequest(tampered)); err == nil {
+		t.Error("VerifyNotify() of notify with tampered total_amount should return error")
+	}
+
+	if _, err = gopay.NewProvider(ProviderName, config.Clone().Set("alipay_public_key", "")); err == nil {
+		t.Error("NewProvider() without alipay_public_key should return error")
+	}
+	if _, err = p.CreateOrder(context.Background(), &gopay.OrderRequest{Scene: "UNKNOWN"}); !errors.Is(err, gopay.ErrNotSupported) {
+		t.Errorf("CreateOrder(UNKNOWN) error = %v", err)
+	}
+}
+
+func TestYuan(t *testing.T) {
+	for fen, yuan := range map[int64]string{0: "0.00", 1: "0.01", 101: "1.01", 1000: "10.00", -5: "-0.05"} {
+		if s := formatYuan(fen); s != yuan {
+			t.Errorf("formatYuan(%d) = %s, want %s", fen, s, yuan)
+		}
+		if n := parseYuan(yuan); n != fen {
+			t.Errorf("parseYuan(%s) = %d, want %d", yuan, n, fen)
+		}
+	}
+	if n := parseYuan("9.9"); n != 990 {
+		t.Errorf("parseYuan(9.9) = %d", n)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/sm2"
 	"github.com/cedarwu/gopay/pkg/util"
 	"github.com/cedarwu/gopay/pkg/xlog"
 	"github.com/cedarwu/gopay/pkg/xpem"
@@ -78,12 +79,7 @@ func GetCertSN(certPathOrData interface{}) (sn string, err error) {
 		if err != nil {
 			return util.NULL, err
 		}
-		name := cert.Issuer.String()
-		serialNumber := cert.SerialNumber.String()
-		h := md5.New()
-		h.Write([]byte(name))
-		h.Write([]byte(serialNumber))
-		sn = hex.EncodeToString(h.Sum(nil))
+		sn = certSN(cert)
 	}
 	if sn == util.NULL {
 		return util.NULL, errors.New("failed to get sn,please check your cert")
@@ -91,6 +87,14 @@ func GetCertSN(certPathOrData interface{}) (sn string, err error) {
 	return sn, nil
 }
 
+// certSN 证书序列号SN：md5(签发者 + 证书序列号)
+func certSN(cert *x509.Certificate) (sn string) {
+	h := md5.New()
+	h.Write([]byte(cert.Issuer.String()))
+	h.Write([]byte(cert.SerialNumber.String()))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // GetRootCertSN 获取root证书序列号SN
 //	rootCertPathOrData.509证书文件路径(alipayRootCert.crt) 或文件 buffer
 //	返回 sn：证书序列号(alipay_root_cert_sn)
@@ -172,6 +176,32 @@ func GetRsaSign(bm gopay.BodyMap, signType string, privateKey *rsa.PrivateKey) (
 	return
 }
 
+// 获取支付宝参数签名（国密模式，SM3 摘要、SM2 签名）
+//	bm：签名参数
+//	privateKey：应用 SM2 私钥
+func GetSM2Sign(bm gopay.BodyMap, privateKey *sm2.PrivateKey) (sign string, err error) {
+	signBytes, err := sm2.Sign(privateKey, nil, []byte(bm.EncodeAliPaySignParams()))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signBytes), nil
+}
+
+// getSign 按 bm 中的 sign_type 签名，SM2 使用国密私钥，其余使用 RSA 私钥
+func (a *Client) getSign(bm gopay.BodyMap) (sign string, err error) {
+	signType := bm.GetString("sign_type")
+	if signType == SM2 {
+		if a.smPrivateKey == nil {
+			return "", errors.New("sm2 private key is nil, please use alipay.NewClientSM()")
+		}
+		return GetSM2Sign(bm, a.smPrivateKey)
+	}
+	if a.privateKey == nil {
+		return "", errors.New("rsa private key is nil, please use alipay.NewClient()")
+	}
+	return GetRsaSign(bm, signType, a.privateKey)
+}
+
 // =============================== 获取SignData ===============================
 
 // 需注意的是，公钥签名模式和公钥证书签名模式的不同之处
@@ -182,12 +212,20 @@ func (a *Client) getSignData(bs []byte, alipayCertSN string) (signData string, e
 		indexStart = strings.Index(str, `_response":`)
 		indexEnd   int
 	)
+	// 接口内容加密，验签内容为加密后的响应内容
+	if encryptData, ok := a.encryptSignData(bs); ok {
+		signData = encryptData
+		if alipayCertSN != "" && alipayCertSN != a.AliPayPublicCertSN {
+			return signData, &alipayCertSNError{sn: alipayCertSN}
+		}
+		return
+	}
 	if alipayCertSN != "" {
 		// 公钥证书模式
 		indexEnd = strings.Index(str, `,"alipay_cert_sn":`)
 		signData = str[indexStart+11 : indexEnd]
 		if alipayCertSN != a.AliPayPublicCertSN {
-			return signData, errors.New("当前使用的支付宝公钥证书SN与网关响应报文中的SN不匹配")
+			return signData, &alipayCertSNError{sn: alipayCertSN}
 		}
 		return
 	}
@@ -241,24 +279,46 @@ func VerifySyncSignWithCert(alipayPublicKeyCert interface{}, signData, sign stri
 }
 
 func (a *Client) autoVerifySignByCert(sign, signData string, signDataErr error) (err error) {
+	if a.autoSign && a.smAliPayPublicKey != nil {
+		if a.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("Alipay_SyncSignData: %s, Sign=[%s]", signData, sign)
+		}
+		if signDataErr != nil {
+			return signDataErr
+		}
+		return verifySignSM2(signData, sign, a.smAliPayPublicKey)
+	}
 	if a.autoSign && a.aliPayPublicKey != nil {
 		if a.DebugSwitch == gopay.DebugOn {
 			xlog.Debugf("Alipay_SyncSignData: %s, Sign=[%s]", signData, sign)
 		}
 		// 只有证书验签时，才可能出现此error
 		if signDataErr != nil {
-			return signDataErr
+			// 支付宝公钥证书已更新，开启自动更新时下载对应SN的证书验签
+			snErr, ok := signDataErr.(*alipayCertSNError)
+			if !ok || a.certStore == nil {
+				return signDataErr
+			}
+			pubKey, err := a.alipayCertPublicKey(snErr.sn)
+			if err != nil {
+				return err
+			}
+			return verifySignRSA2(signData, sign, pubKey)
 		}
 
-		signBytes, _ := base64.StdEncoding.DecodeString(sign)
-		hashs := crypto.SHA256
-		h := hashs.New()
-		h.Write([]byte(signData))
-		return rsa.VerifyPKCS1v15(a.aliPayPublicKey, hashs, h.Sum(nil), signBytes)
+		return verifySignRSA2(signData, sign, a.aliPayPublicKey)
 	}
 	return nil
 }
 
+func verifySignRSA2(signData, sign string, publicKey *rsa.PublicKey) (err error) {
+	signBytes, _ := base64.StdEncoding.DecodeString(sign)
+	hashs := crypto.SHA256
+	h := hashs.New()
+	h.Write([]byte(signData))
+	return rsa.VerifyPKCS1v15(publicKey, hashs, h.Sum(nil), signBytes)
+}
+
 // =============================== 异步验签 ===============================
 
 // VerifySign 支付宝异步通知验签（公钥模式）
@@ -280,6 +340,8 @@ func VerifySign(alipayPublicKey string, notifyBean interface{}) (ok bool, err er
 	)
 	if reflect.ValueOf(notifyBean).Kind() == reflect.Map {
 		if bm, ok = notifyBean.(gopay.BodyMap); ok {
+			// 复制一份，避免移除 sign、sign_type 时修改调用方的 BodyMap
+			bm = bm.Clone()
 			bodySign = bm.GetString("sign")
 			bodySignType = bm.GetString("sign_type")
 			bm.Remove("sign")
@@ -335,6 +397,8 @@ func VerifySignWithCert(aliPayPublicKeyCert, notifyBean interface{}) (ok bool, e
 	)
 	if reflect.ValueOf(notifyBean).Kind() == reflect.Map {
 		if bm, ok = notifyBean.(gopay.BodyMap); ok {
+			// 复制一份，避免移除 sign、sign_type 时修改调用方的 BodyMap
+			bm = bm.Clone()
 			bodySign = bm.GetString("sign")
 			bodySignType = bm.GetString("sign_type")
 			bm.Remove("sign")
@@ -368,6 +432,13 @@ func verifySign(signData, sign, signType, alipayPublicKey string) (err error) {
 		h     hash.Hash
 		hashs crypto.Hash
 	)
+	if signType == SM2 {
+		pubKey, _, err := sm2.ParsePublicKey([]byte(alipayPublicKey))
+		if err != nil {
+			return err
+		}
+		return verifySignSM2(signData, sign, pubKey)
+	}
 	publicKey, err := xpem.DecodePublicKey([]byte(alipayPublicKey))
 	if err != nil {
 		return err
@@ -403,6 +474,13 @@ func verifySignCert(signData, sign, signType string, alipayPublicKeyCert interfa
 			return fmt.Errorf("支付宝公钥读取失败: %w", err)
 		}
 	}
+	if signType == SM2 {
+		pubKey, _, err := sm2.ParsePublicKey(bytes)
+		if err != nil {
+			return err
+		}
+		return verifySignSM2(signData, sign, pubKey)
+	}
 	publicKey, err := xpem.DecodePublicKey(bytes)
 	if err != nil {
 		return err
@@ -421,3 +499,11 @@ func verifySignCert(signData, sign, signType string, alipayPublicKeyCert interfa
 	h.Write([]byte(signData))
 	return rsa.VerifyPKCS1v15(publicKey, hashs, h.Sum(nil), signBytes)
 }
+
+func verifySignSM2(signData, sign string, publicKey *sm2.PublicKey) (err error) {
+	signBytes, _ := base64.StdEncoding.DecodeString(sign)
+	if !sm2.Verify(publicKey, nil, []byte(signData), signBytes) {
+		return errors.New("sm2 verify sign failed")
+	}
+	return nil
+}
@@ -1,10 +1,13 @@
 package alipay
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"io/ioutil"
 	"testing"
 
 	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/sm2"
 	"github.com/cedarwu/gopay/pkg/xlog"
 	"github.com/cedarwu/gopay/pkg/xrsa"
 )
@@ -145,3 +148,57 @@ func TestGetCertSN(t *testing.T) {
 	// 687b59193f3f462dd5336e5abf83c5d8_02941eef3187dddf3d3b83462e1dfcf6
 	// 687b59193f3f462dd5336e5abf83c5d8_02941eef3187dddf3d3b83462e1dfcf6
 }
+
+func TestSM2Sign(t *testing.T) {
+	priKey, err := sm2.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPem, err := sm2.MarshalPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pubPem)
+	publicKey := base64.StdEncoding.EncodeToString(block.Bytes)
+
+	a := &Client{AppId: "2015102700040153", SignType: SM2, smPrivateKey: priKey}
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_type", "trade_status_sync").
+		Set("trade_no", "2020010222001401551430614892").
+		Set("out_trade_no", "1086209247658383466").
+		Set("trade_status", TradeStatusSuccess)
+	sign, err := GetSM2Sign(bm, priKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm.Set("sign", sign).Set("sign_type", SM2)
+
+	// 异步通知验签
+	ok, err := VerifySign(publicKey, bm)
+	if err != nil || !ok {
+		t.Fatalf("VerifySign() = %v, %v", ok, err)
+	}
+	bm.Set("trade_status", TradeStatusClosed)
+	if ok, _ = VerifySign(publicKey, bm); ok {
+		t.Error("VerifySign() with modified params should fail")
+	}
+
+	// 同步自动验签
+	a.AutoVerifySign(pubPem)
+	signBytes, err := sm2.Sign(priKey, nil, []byte(`{"code":"10000","msg":"Success"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = a.autoVerifySignByCert(base64.StdEncoding.EncodeToString(signBytes), `{"code":"10000","msg":"Success"}`, nil); err != nil {
+		t.Errorf("autoVerifySignByCert() error: %v", err)
+	}
+
+	// 请求签名按 sign_type 选择私钥
+	if _, err = a.getSign(bm); err != nil {
+		t.Errorf("getSign() error: %v", err)
+	}
+	bm.Set("sign_type", RSA2)
+	if _, err = a.getSign(bm); err == nil {
+		t.Error("getSign() with RSA2 and nil rsa private key should return error")
+	}
+}
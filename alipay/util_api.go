@@ -48,6 +48,8 @@ func (a *Client) SystemOauthToken(bm gopay.BodyMap) (aliRsp *SystemOauthTokenRes
 	if err != nil {
 		return nil, err
 	}
+	// 复制后再设置公共参数及签名，避免调用方复用 bm 时带上旧的 sign 而不再重新签名
+	bm = bm.Clone()
 
 	if a.AppCertSN != util.NULL {
 		bm.Set("app_cert_sn", a.AppCertSN)
@@ -57,7 +59,11 @@ func (a *Client) SystemOauthToken(bm gopay.BodyMap) (aliRsp *SystemOauthTokenRes
 	}
 
 	var bs []byte
-	if bs, err = systemOauthToken(a.AppId, a.privateKey, bm, "alipay.system.oauth.token", a.IsProd, a.SignType); err != nil {
+	if a.SignType == SM2 {
+		if bs, err = a.doAliPaySelf(bm, "alipay.system.oauth.token"); err != nil {
+			return nil, err
+		}
+	} else if bs, err = systemOauthToken(a.AppId, a.privateKey, bm, "alipay.system.oauth.token", a.IsProd, a.SignType); err != nil {
 		return nil, err
 	}
 	aliRsp = new(SystemOauthTokenResponse)
@@ -1,10 +1,13 @@
 package alipay
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/alipay/cert"
+	"github.com/cedarwu/gopay/pkg/sm2"
 	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
@@ -25,6 +28,51 @@ func TestClient_SystemOauthToken(t *testing.T) {
 	xlog.Debug("aliRsp:", aliRsp.SignData)
 }
 
+func TestClient_SystemOauthTokenSM2(t *testing.T) {
+	priKey, err := sm2.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+			return
+		}
+		req := make(gopay.BodyMap)
+		for k := range r.PostForm {
+			req.Set(k, r.PostForm.Get(k))
+		}
+		sign := req.GetString("sign")
+		req.Remove("sign")
+		if err := verifySignSM2(req.EncodeAliPaySignParams(), sign, &priKey.PublicKey); err != nil {
+			t.Errorf("request sign verify failed：%v", err)
+		}
+		codes = append(codes, req.GetString("code"))
+		_, _ = w.Write([]byte(`{"alipay_system_oauth_token_response":{"user_id":"2088102150477652","access_token":"20120823ac6ffaa4d2d84e7384bf983531473993"},"sign":"sign"}`))
+	}))
+	defer ts.Close()
+	a := &Client{AppId: cert.Appid, Charset: UTF8, SignType: SM2, smPrivateKey: priKey, apiUrl: ts.URL}
+
+	// 复用同一个 bm，每次请求都需要重新签名
+	bm := make(gopay.BodyMap)
+	bm.Set("grant_type", "authorization_code").
+		Set("code", "3a06216ac8f84b8c93507bb9774bWX11")
+	if _, err = a.SystemOauthToken(bm); err != nil {
+		t.Fatal(err)
+	}
+	if bm.GetString("sign") != "" || bm.GetString("method") != "" {
+		t.Fatalf("SystemOauthToken() modified caller's bm: %s", bm.JsonBody())
+	}
+	bm.Set("code", "4b17327bd9f95c9da4618cc0885cXY22")
+	if _, err = a.SystemOauthToken(bm); err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 2 || codes[1] != "4b17327bd9f95c9da4618cc0885cXY22" {
+		t.Fatalf("request codes = %v", codes)
+	}
+}
+
 func TestClient_OpenAuthTokenApp(t *testing.T) {
 	// 请求参数
 	bm := make(gopay.BodyMap)
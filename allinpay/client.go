@@ -0,0 +1,134 @@
+package allinpay
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+	"github.com/cedarwu/gopay/pkg/xpem"
+)
+
+// Client 通联收银宝客户端
+type Client struct {
+	CusId       string
+	AppId       string
+	IsProd      bool
+	DebugSwitch gopay.DebugSwitch
+
+	apiUrl     string // 非空时替换正式、测试环境地址
+	signType   string
+	md5Key     string
+	privateKey *rsa.PrivateKey
+	publicKey  *rsa.PublicKey // 通联公钥
+}
+
+// 初始化通联收银宝客户端（MD5 签名）
+//	cusId：商户号
+//	appId：应用ID
+//	md5Key：MD5 交易密钥
+//	isProd：是否是正式环境，false 时请求测试环境 syb-test.allinpay.com
+func NewClient(cusId, appId, md5Key string, isProd bool) (client *Client, err error) {
+	if cusId == util.NULL || appId == util.NULL || md5Key == util.NULL {
+		return nil, errors.New("cusId、appId、md5Key cannot be empty")
+	}
+	return &Client{
+		CusId:       cusId,
+		AppId:       appId,
+		IsProd:      isProd,
+		DebugSwitch: gopay.DebugOff,
+		signType:    MD5,
+		md5Key:      md5Key,
+	}, nil
+}
+
+// 初始化通联收银宝客户端（RSA 签名）
+//	cusId：商户号
+//	appId：应用ID
+//	privateKey：商户 RSA 私钥文件内容（PEM）
+//	allinpayPublicKey：通联 RSA 公钥文件内容（PEM），用于同步响应及异步通知验签
+//	isProd：是否是正式环境
+func NewClientRSA(cusId, appId string, privateKey, allinpayPublicKey []byte, isProd bool) (client *Client, err error) {
+	if cusId == util.NULL || appId == util.NULL {
+		return nil, errors.New("cusId or appId cannot be empty")
+	}
+	priKey, err := xpem.DecodePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := xpem.DecodePublicKey(allinpayPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		CusId:       cusId,
+		AppId:       appId,
+		IsProd:      isProd,
+		DebugSwitch: gopay.DebugOff,
+		signType:    RSA,
+		privateKey:  priKey,
+		publicKey:   pubKey,
+	}, nil
+}
+
+// 向通联发送请求，对于本库未提供的收银宝接口，可自行实现，通过此方法发送请求
+//	bm：请求参数的BodyMap，无需设置 cusid、appid、version、randomstr、signtype、sign
+//	uri：接口地址，例如：/unitorder/pay
+func (c *Client) PostAllinpayAPISelf(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	return c.doAllinpay(bm, uri)
+}
+
+func (c *Client) doAllinpay(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	var url = baseUrlProd + uri
+	if !c.IsProd {
+		url = baseUrlSandbox + uri
+	}
+	if c.apiUrl != util.NULL {
+		url = c.apiUrl + uri
+	}
+	bm.Set("cusid", c.CusId).
+		Set("appid", c.AppId).
+		Set("version", version).
+		Set("signtype", c.signType)
+	if bm.GetString("randomstr") == util.NULL {
+		bm.Set("randomstr", util.GetRandomString(32))
+	}
+	bm.Remove("sign")
+	sign, err := c.getSign(bm)
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("sign", sign)
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Allinpay_Request: %s", bm.JsonBody())
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeForm).Post(url).SendString(bm.EncodeURLParams()).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Allinpay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	// 请求失败（retcode = FAIL）时响应不签名
+	rsp := make(gopay.BodyMap)
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+	decoder.UseNumber()
+	if err = decoder.Decode(&rsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if rsp.GetString("retcode") == RetCodeSuccess {
+		if err = c.VerifySign(rsp); err != nil {
+			return nil, err
+		}
+	}
+	return bs, nil
+}
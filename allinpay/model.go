@@ -0,0 +1,84 @@
+package allinpay
+
+const (
+	// URL
+	baseUrlProd    = "https://vsp.allinpay.com/apiweb"      // 正式 URL
+	baseUrlSandbox = "https://syb-test.allinpay.com/apiweb" // 测试 URL
+	unitOrderPay   = "/unitorder/pay"                       // 统一支付
+	tranxQuery     = "/tranx/query"                         // 交易查询
+	tranxRefund    = "/tranx/refund"                        // 交易退款
+
+	version = "11"
+
+	// 签名方式 signtype
+	MD5 = "MD5"
+	RSA = "RSA"
+
+	RetCodeSuccess   = "SUCCESS" // 请求成功
+	TrxStatusSuccess = "0000"    // 交易成功
+	TrxStatusPending = "2000"    // 交易处理中，请查询交易结果
+
+	// 交易方式 paytype
+	PayTypeWechatScan   = "W01" // 微信扫码支付
+	PayTypeWechatJs     = "W02" // 微信JS支付
+	PayTypeWechatMini   = "W06" // 微信小程序支付
+	PayTypeAlipayScan   = "A01" // 支付宝扫码支付
+	PayTypeAlipayJs     = "A02" // 支付宝JS支付
+	PayTypeUnionPayScan = "U01" // 银联扫码支付
+	PayTypeUnionPayJs   = "U02" // 银联JS支付
+)
+
+// Response 通联收银宝接口公共响应参数
+type Response struct {
+	RetCode   string `json:"retcode"`
+	RetMsg    string `json:"retmsg"`
+	CusId     string `json:"cusid"`
+	AppId     string `json:"appid"`
+	RandomStr string `json:"randomstr"`
+	Sign      string `json:"sign"`
+}
+
+// IsSuccess 请求是否成功（retcode = SUCCESS），交易结果以 trxstatus 为准
+func (r *Response) IsSuccess() bool {
+	return r.RetCode == RetCodeSuccess
+}
+
+type UnitOrderPayRsp struct {
+	Response
+	TrxId     string `json:"trxid"`
+	ChnlTrxId string `json:"chnltrxid"`
+	ReqSn     string `json:"reqsn"`
+	TrxStatus string `json:"trxstatus"` // 0000：成功，2000：处理中，其他：失败
+	FinTime   string `json:"fintime"`
+	ErrMsg    string `json:"errmsg"`
+	PayInfo   string `json:"payinfo"` // 扫码支付为二维码链接，JS支付为调起支付的 JSON 参数
+}
+
+type TranxQueryRsp struct {
+	Response
+	TrxId     string `json:"trxid"`
+	ChnlTrxId string `json:"chnltrxid"`
+	ReqSn     string `json:"reqsn"`
+	TrxCode   string `json:"trxcode"`
+	TrxAmt    string `json:"trxamt"`
+	TrxStatus string `json:"trxstatus"`
+	Acct      string `json:"acct"`
+	FinTime   string `json:"fintime"`
+	Fee       string `json:"fee"`
+	ErrMsg    string `json:"errmsg"`
+}
+
+// IsPaid 交易是否成功（retcode = SUCCESS 且 trxstatus = 0000）
+func (r *TranxQueryRsp) IsPaid() bool {
+	return r.IsSuccess() && r.TrxStatus == TrxStatusSuccess
+}
+
+type TranxRefundRsp struct {
+	Response
+	TrxId     string `json:"trxid"`
+	ReqSn     string `json:"reqsn"`
+	TrxStatus string `json:"trxstatus"`
+	FinTime   string `json:"fintime"`
+	Fee       string `json:"fee"`
+	ErrMsg    string `json:"errmsg"`
+}
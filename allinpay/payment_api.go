@@ -0,0 +1,66 @@
+package allinpay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 统一支付
+//	必填：trxamt（单位：分）、reqsn（商户订单号）、paytype
+//	扫码支付 rsp.PayInfo 为二维码链接，JS支付为调起支付的 JSON 参数
+func (c *Client) UnitOrderPay(bm gopay.BodyMap) (aipRsp *UnitOrderPayRsp, err error) {
+	err = bm.CheckEmptyError("trxamt", "reqsn", "paytype")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doAllinpay(bm, unitOrderPay)
+	if err != nil {
+		return nil, err
+	}
+	aipRsp = new(UnitOrderPayRsp)
+	if err = json.Unmarshal(bs, aipRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return aipRsp, nil
+}
+
+// 交易查询
+//	reqsn（商户订单号）与 trxid（收银宝交易单号）二选一
+func (c *Client) TranxQuery(bm gopay.BodyMap) (aipRsp *TranxQueryRsp, err error) {
+	if bm.GetString("reqsn") == gopay.NULL && bm.GetString("trxid") == gopay.NULL {
+		return nil, errors.New("reqsn and trxid : cannot be empty at the same time")
+	}
+	bs, err := c.doAllinpay(bm, tranxQuery)
+	if err != nil {
+		return nil, err
+	}
+	aipRsp = new(TranxQueryRsp)
+	if err = json.Unmarshal(bs, aipRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return aipRsp, nil
+}
+
+// 交易退款
+//	必填：trxamt（退款金额，单位：分）、reqsn（退款订单号），oldreqsn（原商户订单号）与 oldtrxid（原收银宝交易单号）二选一
+func (c *Client) TranxRefund(bm gopay.BodyMap) (aipRsp *TranxRefundRsp, err error) {
+	err = bm.CheckEmptyError("trxamt", "reqsn")
+	if err != nil {
+		return nil, err
+	}
+	if bm.GetString("oldreqsn") == gopay.NULL && bm.GetString("oldtrxid") == gopay.NULL {
+		return nil, errors.New("oldreqsn and oldtrxid : cannot be empty at the same time")
+	}
+	bs, err := c.doAllinpay(bm, tranxRefund)
+	if err != nil {
+		return nil, err
+	}
+	aipRsp = new(TranxRefundRsp)
+	if err = json.Unmarshal(bs, aipRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return aipRsp, nil
+}
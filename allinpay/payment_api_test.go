@@ -0,0 +1,156 @@
+package allinpay
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+// newTestServer 模拟收银宝接口：校验请求路径及签名，返回签名后的 result
+func newTestServer(t *testing.T, c *Client, path string, result func(req gopay.BodyMap) gopay.BodyMap) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := ParseNotifyToBodyMap(r)
+		if err != nil || r.URL.Path != path || req.GetString("version") != version || req.GetString("signtype") != MD5 || c.VerifySign(req) != nil {
+			t.Errorf("path = %s, req = %v, err = %v", r.URL.Path, req, err)
+			_, _ = w.Write([]byte(`{"retcode":"FAIL","retmsg":"签名验证失败"}`))
+			return
+		}
+		rsp := result(req)
+		rsp.Set("retcode", RetCodeSuccess).
+			Set("cusid", req.GetString("cusid")).
+			Set("appid", req.GetString("appid")).
+			Set("randomstr", "84937582")
+		rsp.Set("sign", GetMd5Sign(rsp, testMd5Key))
+		_ = json.NewEncoder(w).Encode(rsp)
+	}))
+}
+
+func TestClient_UnitOrderPay(t *testing.T) {
+	c, err := NewClient(testCusId, testAppId, testMd5Key, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := newTestServer(t, c, unitOrderPay, func(req gopay.BodyMap) gopay.BodyMap {
+		rsp := make(gopay.BodyMap)
+		rsp.Set("trxid", "221101116012345678").
+			Set("reqsn", req.GetString("reqsn")).
+			Set("trxstatus", TrxStatusSuccess).
+			Set("payinfo", "weixin://wxpay/bizpayurl?pr=8SvyBeZzz")
+		return rsp
+	})
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("trxamt", 1).
+		Set("reqsn", "GOPAY20221101001").
+		Set("paytype", PayTypeWechatScan).
+		Set("body", "测试商品")
+	aipRsp, err := c.UnitOrderPay(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !aipRsp.IsSuccess() || aipRsp.ReqSn != "GOPAY20221101001" || aipRsp.TrxStatus != TrxStatusSuccess || aipRsp.PayInfo == "" {
+		t.Errorf("UnitOrderPay() = %+v", aipRsp)
+	}
+}
+
+func TestClient_TranxQuery(t *testing.T) {
+	c, err := NewClient(testCusId, testAppId, testMd5Key, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := newTestServer(t, c, tranxQuery, func(req gopay.BodyMap) gopay.BodyMap {
+		rsp := make(gopay.BodyMap)
+		rsp.Set("trxid", "221101116012345678").
+			Set("reqsn", req.GetString("reqsn")).
+			Set("trxcode", "VSP501").
+			Set("trxamt", "1").
+			Set("trxstatus", TrxStatusSuccess).
+			Set("fintime", "20221101143522")
+		return rsp
+	})
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	if _, err = c.TranxQuery(make(gopay.BodyMap)); err == nil {
+		t.Error("TranxQuery() without reqsn and trxid should return error")
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("reqsn", "GOPAY20221101001")
+	aipRsp, err := c.TranxQuery(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !aipRsp.IsPaid() || aipRsp.TrxCode != "VSP501" || aipRsp.TrxAmt != "1" {
+		t.Errorf("TranxQuery() = %+v", aipRsp)
+	}
+}
+
+func TestClient_TranxRefund(t *testing.T) {
+	c, err := NewClient(testCusId, testAppId, testMd5Key, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := newTestServer(t, c, tranxRefund, func(req gopay.BodyMap) gopay.BodyMap {
+		if req.GetString("oldreqsn") != "GOPAY20221101001" {
+			t.Errorf("oldreqsn = %s", req.GetString("oldreqsn"))
+		}
+		rsp := make(gopay.BodyMap)
+		rsp.Set("trxid", "221101116012345679").
+			Set("reqsn", req.GetString("reqsn")).
+			Set("trxstatus", TrxStatusSuccess).
+			Set("fee", "0")
+		return rsp
+	})
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("trxamt", 1).
+		Set("reqsn", "GOPAY20221101001R")
+	if _, err = c.TranxRefund(bm); err == nil {
+		t.Error("TranxRefund() without oldreqsn and oldtrxid should return error")
+	}
+	bm.Set("oldreqsn", "GOPAY20221101001")
+	aipRsp, err := c.TranxRefund(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !aipRsp.IsSuccess() || aipRsp.ReqSn != "GOPAY20221101001R" || aipRsp.TrxStatus != TrxStatusSuccess {
+		t.Errorf("TranxRefund() = %+v", aipRsp)
+	}
+}
+
+func TestClient_doAllinpayResponseSign(t *testing.T) {
+	c, err := NewClient(testCusId, testAppId, testMd5Key, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("reqsn", "GOPAY20221101001")
+	// retcode = FAIL 时响应不签名
+	body = `{"retcode":"FAIL","retmsg":"商户不存在"}`
+	aipRsp, err := c.TranxQuery(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aipRsp.IsSuccess() || aipRsp.RetMsg != "商户不存在" {
+		t.Errorf("TranxQuery() = %+v", aipRsp)
+	}
+	// retcode = SUCCESS 时必须验签
+	body = `{"retcode":"SUCCESS","cusid":"990440148166000","appid":"00000003","trxid":"221101116012345678","reqsn":"GOPAY20221101001","trxstatus":"0000","sign":"8B3E8121052688826643E38A5FF6CE66"}`
+	if _, err = c.TranxQuery(bm); err == nil {
+		t.Error("TranxQuery() with bad response sign should return error")
+	}
+}
@@ -0,0 +1,96 @@
+package allinpay
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// GetMd5Sign MD5 签名
+//	参数（含 key，不含 sign）按 key 排序拼接为 key1=value1&key2=value2，取 MD5 大写
+func GetMd5Sign(bm gopay.BodyMap, md5Key string) (sign string) {
+	bm = bm.Clone()
+	bm.Remove("sign")
+	bm.Set("key", md5Key)
+	h := md5.Sum([]byte(bm.EncodeAliPaySignParams()))
+	return strings.ToUpper(hex.EncodeToString(h[:]))
+}
+
+// GetRsaSign RSA 签名
+//	参数（不含 sign）按 key 排序拼接为 key1=value1&key2=value2，SHA1withRSA 签名后 base64 编码
+func GetRsaSign(bm gopay.BodyMap, privateKey *rsa.PrivateKey) (sign string, err error) {
+	bm = bm.Clone()
+	bm.Remove("sign")
+	h := sha1.Sum([]byte(bm.EncodeAliPaySignParams()))
+	signBytes, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA1, h[:])
+	if err != nil {
+		return util.NULL, err
+	}
+	return base64.StdEncoding.EncodeToString(signBytes), nil
+}
+
+func (c *Client) getSign(bm gopay.BodyMap) (sign string, err error) {
+	if c.signType == RSA {
+		if c.privateKey == nil {
+			return util.NULL, errors.New("rsa private key is nil, please use allinpay.NewClientRSA()")
+		}
+		return GetRsaSign(bm, c.privateKey)
+	}
+	return GetMd5Sign(bm, c.md5Key), nil
+}
+
+// 解析通联异步通知的参数到BodyMap
+//	req：*http.Request
+func ParseNotifyToBodyMap(req *http.Request) (bm gopay.BodyMap, err error) {
+	if err = req.ParseForm(); err != nil {
+		return nil, err
+	}
+	bm = make(gopay.BodyMap, len(req.Form))
+	for k, v := range req.Form {
+		if len(v) == 1 {
+			bm.Set(k, v[0])
+		}
+	}
+	return bm, nil
+}
+
+// 通联同步响应、异步通知验签
+//	bm：响应或通知参数，异步通知使用 allinpay.ParseNotifyToBodyMap() 解析，验签通过后响应 success
+//	签名方式与客户端一致，RSA 使用通联公钥验签
+func (c *Client) VerifySign(bm gopay.BodyMap) (err error) {
+	sign := bm.GetString("sign")
+	if sign == util.NULL {
+		return errors.New("sign : cannot be empty")
+	}
+	if c.signType != RSA {
+		if GetMd5Sign(bm, c.md5Key) != sign {
+			return errors.New("allinpay verify sign failed")
+		}
+		return nil
+	}
+	if c.publicKey == nil {
+		return errors.New("allinpay public key is nil, please use allinpay.NewClientRSA()")
+	}
+	signBytes, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	bm = bm.Clone()
+	bm.Remove("sign")
+	h := sha1.Sum([]byte(bm.EncodeAliPaySignParams()))
+	if err = rsa.VerifyPKCS1v15(c.publicKey, crypto.SHA1, h[:], signBytes); err != nil {
+		return fmt.Errorf("allinpay verify sign failed：%w", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package apple
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+const (
+	// App Store Server API 正式环境
+	serverApiUrlProd = "https://api.storekit.itunes.apple.com"
+
+	// App Store Server API 沙箱环境
+	serverApiUrlSandbox = "https://api.storekit-sandbox.itunes.apple.com"
+
+	// JWT 有效期，Apple 要求不超过 60 分钟
+	tokenExpiresIn = 30 * time.Minute
+)
+
+// Client App Store Server API 客户端
+type Client struct {
+	Iss         string // Issuer ID，App Store Connect 密钥页面获取
+	Kid         string // Key ID，App Store Connect 生成的 In-App Purchase 密钥ID
+	Bid         string // Bundle ID
+	IsProd      bool
+	DebugSwitch gopay.DebugSwitch
+
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewClient 初始化 App Store Server API 客户端
+//	iss：Issuer ID
+//	kid：Key ID
+//	bid：App 的 Bundle ID
+//	privateKey：App Store Connect 下载的 In-App Purchase 密钥（SubscriptionKey_xxx.p8）文件内容
+//	isProd：是否是正式环境
+//	文档：https://developer.apple.com/documentation/appstoreserverapi/generating_tokens_for_api_requests
+func NewClient(iss, kid, bid string, privateKey []byte, isProd bool) (client *Client, err error) {
+	if iss == gopay.NULL || kid == gopay.NULL || bid == gopay.NULL {
+		return nil, errors.New("iss、kid、bid cannot be empty")
+	}
+	key, err := decodeECPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Iss:         iss,
+		Kid:         kid,
+		Bid:         bid,
+		IsProd:      isProd,
+		DebugSwitch: gopay.DebugOff,
+		privateKey:  key,
+	}, nil
+}
+
+// decodeECPrivateKey 解析 PKCS#8 格式的 ES256 私钥，支持 PEM 格式或去掉首尾的 base64 内容
+func decodeECPrivateKey(privateKey []byte) (key *ecdsa.PrivateKey, err error) {
+	der := privateKey
+	if block, _ := pem.Decode(privateKey); block != nil {
+		der = block.Bytes
+	} else if bs, err := base64.StdEncoding.DecodeString(string(privateKey)); err == nil {
+		der = bs
+	}
+	pk, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("x509.ParsePKCS8PrivateKey：%w", err)
+	}
+	key, ok := pk.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not ecdsa private key")
+	}
+	return key, nil
+}
+
+// generateToken 生成请求 App Store Server API 的 ES256 JWT
+func (c *Client) generateToken() (token string, err error) {
+	now := time.Now()
+	header, err := json.Marshal(map[string]string{"alg": "ES256", "kid": c.Kid, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"iss": c.Iss,
+		"iat": now.Unix(),
+		"exp": now.Add(tokenExpiresIn).Unix(),
+		"aud": "appstoreconnect-v1",
+		"bid": c.Bid,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	h := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, c.privateKey, h[:])
+	if err != nil {
+		return "", fmt.Errorf("ecdsa.Sign：%w", err)
+	}
+	// JWS 的 ES256 签名为定长 R||S
+	sign := make([]byte, 64)
+	r.FillBytes(sign[:32])
+	s.FillBytes(sign[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign), nil
+}
+
+func (c *Client) doAppleGet(ctx context.Context, uri string, v interface{}) (err error) {
+	var url = serverApiUrlProd + uri
+	if !c.IsProd {
+		url = serverApiUrlSandbox + uri
+	}
+	token, err := c.generateToken()
+	if err != nil {
+		return err
+	}
+	httpClient := xhttp.NewClient()
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Apple_Url: %s", url)
+	}
+	httpClient.Header.Add("Authorization", "Bearer "+token)
+	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Get(url).EndBytes()
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Apple_Response: %d > %s", res.StatusCode, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		errRsp := &ErrorResponse{HttpCode: res.StatusCode}
+		if len(bs) > 0 {
+			_ = json.Unmarshal(bs, errRsp)
+		}
+		return errRsp
+	}
+	if err = json.Unmarshal(bs, v); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package apple
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestClient_GenerateToken(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient("57246542-96fe-1a63-e053-0824d011072a", "2X9R4HXF34", "com.example", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := client.generateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token = %s", token)
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	claims := make(map[string]interface{})
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims["iss"] != client.Iss || claims["aud"] != "appstoreconnect-v1" || claims["bid"] != client.Bid {
+		t.Errorf("claims = %v", claims)
+	}
+	sign, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	h := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if len(sign) != 64 || !ecdsa.Verify(&key.PublicKey, h[:], new(big.Int).SetBytes(sign[:32]), new(big.Int).SetBytes(sign[32:])) {
+		t.Error("token signature verify failed")
+	}
+}
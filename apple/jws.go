@@ -0,0 +1,185 @@
+package apple
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// Apple 签发的 App Store 收据签名证书（叶子证书）扩展 OID
+	oidAppStoreReceiptSigning = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 11, 1}
+	// Apple Worldwide Developer Relations 中间证书扩展 OID
+	oidAppleWWDRIntermediate = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 2, 1}
+)
+
+// JWSVerifier App Store 签名数据（JWS）验签器
+//	用于验签并解析 App Store Server Notifications V2 的 signedPayload，
+//	以及 App Store Server API 返回的 signedTransactionInfo、signedRenewalInfo
+type JWSVerifier struct {
+	roots *x509.CertPool
+}
+
+type jwsHeader struct {
+	Alg string   `json:"alg"`
+	X5c []string `json:"x5c"`
+}
+
+// NewJWSVerifier 初始化 JWS 验签器
+//	appleRootCert：Apple 根证书文件内容，支持 PEM 或 DER 格式，
+//	下载地址：https://www.apple.com/certificateauthority/AppleRootCA-G3.cer
+func NewJWSVerifier(appleRootCert ...[]byte) (verifier *JWSVerifier, err error) {
+	if len(appleRootCert) == 0 {
+		return nil, errors.New("apple root cert cannot be empty")
+	}
+	roots := x509.NewCertPool()
+	for _, content := range appleRootCert {
+		der := content
+		if block, _ := pem.Decode(content); block != nil {
+			der = block.Bytes
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("x509.ParseCertificate：%w", err)
+		}
+		roots.AddCert(cert)
+	}
+	return &JWSVerifier{roots: roots}, nil
+}
+
+// Verify 校验 JWS 的 x5c 证书链及 ES256 签名，验签通过后将 payload 解析到 ptr
+func (v *JWSVerifier) Verify(jws string, ptr interface{}) (err error) {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 {
+		return errors.New("invalid jws format")
+	}
+	headerBs, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("decode jws header：%w", err)
+	}
+	header := new(jwsHeader)
+	if err = json.Unmarshal(headerBs, header); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(headerBs), err)
+	}
+	if header.Alg != "ES256" {
+		return fmt.Errorf("unsupported jws alg: %s", header.Alg)
+	}
+	pubKey, err := v.verifyCertChain(header.X5c)
+	if err != nil {
+		return err
+	}
+	sign, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("decode jws signature：%w", err)
+	}
+	if len(sign) != 64 {
+		return errors.New("invalid jws signature length")
+	}
+	h := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !ecdsa.Verify(pubKey, h[:], new(big.Int).SetBytes(sign[:32]), new(big.Int).SetBytes(sign[32:])) {
+		return errors.New("jws signature verify failed")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("decode jws payload：%w", err)
+	}
+	if err = json.Unmarshal(payload, ptr); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(payload), err)
+	}
+	return nil
+}
+
+// verifyCertChain 校验 x5c 证书链（叶子证书、中间证书）由 Apple 根证书签发
+func (v *JWSVerifier) verifyCertChain(x5c []string) (pubKey *ecdsa.PublicKey, err error) {
+	if len(x5c) < 2 {
+		return nil, errors.New("invalid jws x5c certificate chain")
+	}
+	certs := make([]*x509.Certificate, len(x5c))
+	for i, c := range x5c {
+		der, err := base64.StdEncoding.DecodeString(c)
+		if err != nil {
+			return nil, fmt.Errorf("decode x5c[%d]：%w", i, err)
+		}
+		if certs[i], err = x509.ParseCertificate(der); err != nil {
+			return nil, fmt.Errorf("x509.ParseCertificate(x5c[%d])：%w", i, err)
+		}
+	}
+	if !hasExtension(certs[0], oidAppStoreReceiptSigning) || !hasExtension(certs[1], oidAppleWWDRIntermediate) {
+		return nil, errors.New("jws x5c certificate is not issued for app store")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("verify jws x5c certificate chain：%w", err)
+	}
+	pubKey, ok := certs[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("jws x5c certificate is not ecdsa public key")
+	}
+	return pubKey, nil
+}
+
+func hasExtension(cert *x509.Certificate, oid asn1.ObjectIdentifier) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeTransaction 验签并解析 signedTransactionInfo
+func (v *JWSVerifier) DecodeTransaction(signedTransactionInfo string) (transaction *JWSTransaction, err error) {
+	transaction = new(JWSTransaction)
+	if err = v.Verify(signedTransactionInfo, transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// DecodeRenewalInfo 验签并解析 signedRenewalInfo
+func (v *JWSVerifier) DecodeRenewalInfo(signedRenewalInfo string) (renewalInfo *JWSRenewalInfo, err error) {
+	renewalInfo = new(JWSRenewalInfo)
+	if err = v.Verify(signedRenewalInfo, renewalInfo); err != nil {
+		return nil, err
+	}
+	return renewalInfo, nil
+}
+
+// DecodeNotification 验签并解析 App Store Server Notifications V2 通知
+//	signedPayload：通知请求体 {"signedPayload":"..."} 中的 signedPayload
+//	data 中的 signedTransactionInfo、signedRenewalInfo 同时验签并解析到 notification.Data.TransactionInfo、notification.Data.RenewalInfo
+//	文档：https://developer.apple.com/documentation/appstoreservernotifications/responsebodyv2decodedpayload
+func (v *JWSVerifier) DecodeNotification(signedPayload string) (notification *NotificationV2, err error) {
+	notification = new(NotificationV2)
+	if err = v.Verify(signedPayload, notification); err != nil {
+		return nil, err
+	}
+	if data := notification.Data; data != nil {
+		if data.SignedTransactionInfo != "" {
+			if data.TransactionInfo, err = v.DecodeTransaction(data.SignedTransactionInfo); err != nil {
+				return nil, err
+			}
+		}
+		if data.SignedRenewalInfo != "" {
+			if data.RenewalInfo, err = v.DecodeRenewalInfo(data.SignedRenewalInfo); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return notification, nil
+}
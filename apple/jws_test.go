@@ -0,0 +1,130 @@
+package apple
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCert(t *testing.T, cn string, serial int64, isCA bool, oid []int, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"Apple Inc."}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		tpl.KeyUsage = x509.KeyUsageCertSign
+	}
+	if oid != nil {
+		tpl.ExtraExtensions = []pkix.Extension{{Id: oid, Value: []byte{0x05, 0x00}}}
+	}
+	if parent == nil {
+		parent, parentKey = tpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func signJWS(t *testing.T, key *ecdsa.PrivateKey, chain []*x509.Certificate, payload interface{}) string {
+	x5c := make([]string, len(chain))
+	for i, c := range chain {
+		x5c[i] = base64.StdEncoding.EncodeToString(c.Raw)
+	}
+	header, _ := json.Marshal(map[string]interface{}{"alg": "ES256", "x5c": x5c})
+	body, _ := json.Marshal(payload)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	h := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := make([]byte, 64)
+	r.FillBytes(sign[:32])
+	s.FillBytes(sign[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign)
+}
+
+func TestJWSVerifier_DecodeNotification(t *testing.T) {
+	root, rootKey := newTestCert(t, "Apple Root CA - G3", 1, true, nil, nil, nil)
+	wwdr, wwdrKey := newTestCert(t, "Apple Worldwide Developer Relations Certification Authority", 2, true, oidAppleWWDRIntermediate, root, rootKey)
+	leaf, leafKey := newTestCert(t, "Prod ECC Mac App Store and iTunes Store Receipt Signing", 3, false, oidAppStoreReceiptSigning, wwdr, wwdrKey)
+	chain := []*x509.Certificate{leaf, wwdr, root}
+
+	verifier, err := NewJWSVerifier(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTransactionInfo := signJWS(t, leafKey, chain, map[string]interface{}{
+		"transactionId":         "2000000000000002",
+		"originalTransactionId": "2000000000000001",
+		"productId":             "com.example.monthly",
+		"type":                  "Auto-Renewable Subscription",
+		"expiresDate":           1672531200000,
+	})
+	signedRenewalInfo := signJWS(t, leafKey, chain, map[string]interface{}{
+		"originalTransactionId": "2000000000000001",
+		"autoRenewStatus":       1,
+	})
+	signedPayload := signJWS(t, leafKey, chain, map[string]interface{}{
+		"notificationType": NotificationTypeDidRenew,
+		"notificationUUID": "002e14d5-51f5-4503-b5a8-c3a1af68eb20",
+		"version":          "2.0",
+		"data": map[string]interface{}{
+			"bundleId":              "com.example",
+			"environment":           "Sandbox",
+			"signedTransactionInfo": signedTransactionInfo,
+			"signedRenewalInfo":     signedRenewalInfo,
+		},
+	})
+
+	notification, err := verifier.DecodeNotification(signedPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notification.NotificationType != NotificationTypeDidRenew || notification.Data == nil {
+		t.Fatalf("notification = %+v", notification)
+	}
+	if ti := notification.Data.TransactionInfo; ti == nil || ti.TransactionId != "2000000000000002" || ti.ExpiresDate != 1672531200000 {
+		t.Errorf("TransactionInfo = %+v", ti)
+	}
+	if ri := notification.Data.RenewalInfo; ri == nil || ri.AutoRenewStatus != 1 {
+		t.Errorf("RenewalInfo = %+v", ri)
+	}
+
+	// 签名被篡改
+	parts := strings.Split(signedTransactionInfo, ".")
+	fake := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"transactionId":"1"}`)) + "." + parts[2]
+	if _, err = verifier.DecodeTransaction(fake); err == nil {
+		t.Error("DecodeTransaction() with modified payload should return error")
+	}
+	// 非 Apple 根证书签发
+	otherRoot, otherKey := newTestCert(t, "Other Root", 4, true, nil, nil, nil)
+	otherLeaf, otherLeafKey := newTestCert(t, "Other Leaf", 5, false, oidAppStoreReceiptSigning, otherRoot, otherKey)
+	if _, err = verifier.DecodeTransaction(signJWS(t, otherLeafKey, []*x509.Certificate{otherLeaf, wwdr}, map[string]string{})); err == nil {
+		t.Error("DecodeTransaction() with untrusted chain should return error")
+	}
+}
@@ -1,5 +1,7 @@
 package apple
 
+import "fmt"
+
 // VerifyRequest 校验请求体
 // 	https://developer.apple.com/documentation/appstorereceipts/requestbody
 type VerifyRequest struct {
@@ -29,7 +31,7 @@ type VerifyResponse struct {
 	LatestReceiptInfo []*LatestReceiptInfo `json:"latest_receipt_info,omitempty"`
 
 	// PendingRenewalInfo ,in the JSON file, an array where each element contains the pending renewal information for each auto-renewable subscription identified by the product_id. Only returned for app receipts that contain auto-renewable subscriptions.
-	PendingRenewalInfo *PendingRenewalInfo `json:"pending_renewal_info,omitempty"`
+	PendingRenewalInfo []*PendingRenewalInfo `json:"pending_renewal_info,omitempty"`
 
 	// Receipt is a JSON representation of the receipt that was sent for verification.
 	Receipt *Receipt `json:"receipt,omitempty"`
@@ -330,3 +332,128 @@ type InApp struct {
 	// A unique identifier for purchase events across devices, including subscription-renewal events. This value is the primary key for identifying subscription purchases.
 	WebOrderLineItemId string `json:"web_order_line_item_id"`
 }
+
+// ErrorResponse App Store Server API 错误响应，实现 error 接口
+// 	https://developer.apple.com/documentation/appstoreserverapi/error_codes
+type ErrorResponse struct {
+	HttpCode     int    `json:"-"`
+	ErrorCode    int    `json:"errorCode"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("apple: http code %d, errorCode %d, errorMessage %s", e.HttpCode, e.ErrorCode, e.ErrorMessage)
+}
+
+// TransactionInfoResponse 查询交易信息响应
+// 	https://developer.apple.com/documentation/appstoreserverapi/transactioninforesponse
+type TransactionInfoResponse struct {
+	SignedTransactionInfo string `json:"signedTransactionInfo"`
+}
+
+// HistoryResponse 查询交易历史响应
+// 	https://developer.apple.com/documentation/appstoreserverapi/historyresponse
+type HistoryResponse struct {
+	AppAppleId         int64    `json:"appAppleId"`
+	BundleId           string   `json:"bundleId"`
+	Environment        string   `json:"environment"`
+	HasMore            bool     `json:"hasMore"`
+	Revision           string   `json:"revision"`
+	SignedTransactions []string `json:"signedTransactions"`
+}
+
+// StatusResponse 查询所有订阅状态响应
+// 	https://developer.apple.com/documentation/appstoreserverapi/statusresponse
+type StatusResponse struct {
+	AppAppleId  int64                              `json:"appAppleId"`
+	BundleId    string                             `json:"bundleId"`
+	Environment string                             `json:"environment"`
+	Data        []*SubscriptionGroupIdentifierItem `json:"data"`
+}
+
+type SubscriptionGroupIdentifierItem struct {
+	SubscriptionGroupIdentifier string                  `json:"subscriptionGroupIdentifier"`
+	LastTransactions            []*LastTransactionsItem `json:"lastTransactions"`
+}
+
+type LastTransactionsItem struct {
+	OriginalTransactionId string `json:"originalTransactionId"`
+	// Status 订阅状态，取值见 SubscriptionStatus* 常量
+	Status                int    `json:"status"`
+	SignedTransactionInfo string `json:"signedTransactionInfo"`
+	SignedRenewalInfo     string `json:"signedRenewalInfo"`
+}
+
+// JWSTransaction 验签解析后的交易信息
+// 	https://developer.apple.com/documentation/appstoreserverapi/jwstransactiondecodedpayload
+type JWSTransaction struct {
+	AppAccountToken             string `json:"appAccountToken"`
+	BundleId                    string `json:"bundleId"`
+	Currency                    string `json:"currency"`
+	Environment                 string `json:"environment"`
+	ExpiresDate                 int64  `json:"expiresDate"` // 毫秒时间戳
+	InAppOwnershipType          string `json:"inAppOwnershipType"`
+	IsUpgraded                  bool   `json:"isUpgraded"`
+	OfferIdentifier             string `json:"offerIdentifier"`
+	OfferType                   int    `json:"offerType"`
+	OriginalPurchaseDate        int64  `json:"originalPurchaseDate"`
+	OriginalTransactionId       string `json:"originalTransactionId"`
+	Price                       int64  `json:"price"` // 价格 * 1000
+	ProductId                   string `json:"productId"`
+	PurchaseDate                int64  `json:"purchaseDate"`
+	Quantity                    int    `json:"quantity"`
+	RevocationDate              int64  `json:"revocationDate"`
+	RevocationReason            *int   `json:"revocationReason"` // 0：其他原因退款，1：App 问题退款，未退款时为 nil
+	SignedDate                  int64  `json:"signedDate"`
+	Storefront                  string `json:"storefront"`
+	StorefrontId                string `json:"storefrontId"`
+	SubscriptionGroupIdentifier string `json:"subscriptionGroupIdentifier"`
+	TransactionId               string `json:"transactionId"`
+	TransactionReason           string `json:"transactionReason"` // PURCHASE、RENEWAL
+	Type                        string `json:"type"`              // Auto-Renewable Subscription、Non-Consumable、Consumable、Non-Renewing Subscription
+	WebOrderLineItemId          string `json:"webOrderLineItemId"`
+}
+
+// JWSRenewalInfo 验签解析后的自动续期订阅续期信息
+// 	https://developer.apple.com/documentation/appstoreserverapi/jwsrenewalinfodecodedpayload
+type JWSRenewalInfo struct {
+	AutoRenewProductId          string `json:"autoRenewProductId"`
+	AutoRenewStatus             int    `json:"autoRenewStatus"` // 0：关闭自动续期，1：开启自动续期
+	Environment                 string `json:"environment"`
+	ExpirationIntent            int    `json:"expirationIntent"`
+	GracePeriodExpiresDate      int64  `json:"gracePeriodExpiresDate"`
+	IsInBillingRetryPeriod      bool   `json:"isInBillingRetryPeriod"`
+	OfferIdentifier             string `json:"offerIdentifier"`
+	OfferType                   int    `json:"offerType"`
+	OriginalTransactionId       string `json:"originalTransactionId"`
+	PriceIncreaseStatus         int    `json:"priceIncreaseStatus"`
+	ProductId                   string `json:"productId"`
+	RecentSubscriptionStartDate int64  `json:"recentSubscriptionStartDate"`
+	RenewalDate                 int64  `json:"renewalDate"`
+	SignedDate                  int64  `json:"signedDate"`
+}
+
+// NotificationV2 验签解析后的 App Store Server Notifications V2 通知
+// 	https://developer.apple.com/documentation/appstoreservernotifications/responsebodyv2decodedpayload
+type NotificationV2 struct {
+	NotificationType string            `json:"notificationType"` // 取值见 NotificationType* 常量
+	Subtype          string            `json:"subtype"`
+	NotificationUUID string            `json:"notificationUUID"`
+	Version          string            `json:"version"`
+	SignedDate       int64             `json:"signedDate"`
+	Data             *NotificationData `json:"data"`
+}
+
+type NotificationData struct {
+	AppAppleId            int64  `json:"appAppleId"`
+	BundleId              string `json:"bundleId"`
+	BundleVersion         string `json:"bundleVersion"`
+	Environment           string `json:"environment"`
+	Status                int    `json:"status"`
+	SignedTransactionInfo string `json:"signedTransactionInfo"`
+	SignedRenewalInfo     string `json:"signedRenewalInfo"`
+
+	// 由 verifier.DecodeNotification() 验签解析
+	TransactionInfo *JWSTransaction `json:"-"`
+	RenewalInfo     *JWSRenewalInfo `json:"-"`
+}
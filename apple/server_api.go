@@ -0,0 +1,66 @@
+package apple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/cedarwu/gopay"
+)
+
+const (
+	getTransactionInfo         = "/inApps/v1/transactions/%s"  // transactionId 查询交易信息 GET
+	getTransactionHistory      = "/inApps/v1/history/%s"       // originalTransactionId 查询交易历史 GET
+	getAllSubscriptionStatuses = "/inApps/v1/subscriptions/%s" // originalTransactionId 查询订阅状态 GET
+)
+
+// 查询交易信息（Get Transaction Info）
+//	transactionId：交易ID
+//	rsp.SignedTransactionInfo 可通过 verifier.DecodeTransaction() 验签并解析
+//	文档：https://developer.apple.com/documentation/appstoreserverapi/get_transaction_info
+func (c *Client) GetTransactionInfo(ctx context.Context, transactionId string) (rsp *TransactionInfoResponse, err error) {
+	if transactionId == gopay.NULL {
+		return nil, errors.New("transactionId is empty")
+	}
+	rsp = new(TransactionInfoResponse)
+	if err = c.doAppleGet(ctx, fmt.Sprintf(getTransactionInfo, url.PathEscape(transactionId)), rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// 查询交易历史（Get Transaction History）
+//	originalTransactionId：原始交易ID
+//	revision：分页标识，首次请求传空，之后传上次响应的 rsp.Revision，rsp.HasMore 为 false 时结束
+//	rsp.SignedTransactions 可通过 verifier.DecodeTransaction() 验签并解析
+//	文档：https://developer.apple.com/documentation/appstoreserverapi/get_transaction_history
+func (c *Client) GetTransactionHistory(ctx context.Context, originalTransactionId, revision string) (rsp *HistoryResponse, err error) {
+	if originalTransactionId == gopay.NULL {
+		return nil, errors.New("originalTransactionId is empty")
+	}
+	uri := fmt.Sprintf(getTransactionHistory, url.PathEscape(originalTransactionId))
+	if revision != gopay.NULL {
+		uri += "?revision=" + url.QueryEscape(revision)
+	}
+	rsp = new(HistoryResponse)
+	if err = c.doAppleGet(ctx, uri, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// 查询所有订阅状态（Get All Subscription Statuses）
+//	originalTransactionId：原始交易ID
+//	LastTransactions 中的 SignedTransactionInfo、SignedRenewalInfo 可通过 verifier.DecodeTransaction()、verifier.DecodeRenewalInfo() 验签并解析
+//	文档：https://developer.apple.com/documentation/appstoreserverapi/get_all_subscription_statuses
+func (c *Client) GetAllSubscriptionStatuses(ctx context.Context, originalTransactionId string) (rsp *StatusResponse, err error) {
+	if originalTransactionId == gopay.NULL {
+		return nil, errors.New("originalTransactionId is empty")
+	}
+	rsp = new(StatusResponse)
+	if err = c.doAppleGet(ctx, fmt.Sprintf(getAllSubscriptionStatuses, url.PathEscape(originalTransactionId)), rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
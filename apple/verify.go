@@ -12,12 +12,51 @@ const (
 	UrlProd = "https://buy.itunes.apple.com/verifyReceipt"
 )
 
+// 校验响应状态码
+// 	https://developer.apple.com/documentation/appstorereceipts/status
+const (
+	StatusValid              = 0     // 收据校验成功
+	StatusBadJson            = 21000 // 请求未使用 HTTP POST 或 JSON 格式错误
+	StatusMalformedReceipt   = 21002 // receipt-data 格式错误或服务暂时不可用
+	StatusNotAuthenticated   = 21003 // 收据无法通过认证
+	StatusSharedSecretError  = 21004 // 秘钥与账户的秘钥不匹配
+	StatusServerUnavailable  = 21005 // 收据服务器暂时不可用
+	StatusSubscriptionExpire = 21006 // 收据有效，但订阅已过期
+	StatusSandboxReceipt     = 21007 // 沙箱环境的收据发送到了正式环境
+	StatusProductionReceipt  = 21008 // 正式环境的收据发送到了沙箱环境
+	StatusInternalError      = 21009 // 内部数据访问错误
+	StatusAccountNotFound    = 21010 // 用户账户不存在或已被删除
+)
+
 // VerifyReceipt 请求APP Store 校验支付请求,实际测试时发现这个文档介绍的返回信息只有那个status==0表示成功可以用，其他的返回信息跟文档对不上
 //	url：取 UrlProd 或 UrlSandbox
 //	pwd：苹果APP秘钥，https://help.apple.com/app-store-connect/#/devf341c0f01
 // 	文档：https://developer.apple.com/documentation/appstorereceipts/verifyreceipt
 func VerifyReceipt(url, pwd, receipt string) (*VerifyResponse, error) {
-	req := &VerifyRequest{Receipt: receipt, Password: pwd}
+	return verifyReceipt(url, &VerifyRequest{Receipt: receipt, Password: pwd})
+}
+
+// AutoVerifyReceipt 先请求正式环境校验收据，返回 21007（沙箱环境收据）时自动改为请求沙箱环境
+//	Apple 推荐的校验方式，App 审核期间使用沙箱环境收据时也可以正常校验
+//	pwd：苹果APP秘钥，https://help.apple.com/app-store-connect/#/devf341c0f01
+//	excludeOldTransactions：是否仅返回自动续期订阅的最新续期交易
+// 	文档：https://developer.apple.com/documentation/storekit/original_api_for_in-app_purchase/validating_receipts_with_the_app_store
+func AutoVerifyReceipt(pwd, receipt string, excludeOldTransactions bool) (*VerifyResponse, error) {
+	return autoVerifyReceipt(UrlProd, UrlSandbox, &VerifyRequest{Receipt: receipt, Password: pwd, ExcludeOldTranscations: excludeOldTransactions})
+}
+
+func autoVerifyReceipt(prodUrl, sandboxUrl string, req *VerifyRequest) (*VerifyResponse, error) {
+	vr, err := verifyReceipt(prodUrl, req)
+	if err != nil {
+		return nil, err
+	}
+	if vr.Status == StatusSandboxReceipt {
+		return verifyReceipt(sandboxUrl, req)
+	}
+	return vr, nil
+}
+
+func verifyReceipt(url string, req *VerifyRequest) (*VerifyResponse, error) {
 	vr := new(VerifyResponse)
 	_, errs := xhttp.NewClient().Type(xhttp.TypeJSON).Post(url).SendStruct(req).EndStruct(vr)
 	if len(errs) > 0 {
@@ -25,3 +64,33 @@ func VerifyReceipt(url, pwd, receipt string) (*VerifyResponse, error) {
 	}
 	return vr, nil
 }
+
+// App Store Server Notifications V2 通知类型
+// 	https://developer.apple.com/documentation/appstoreservernotifications/notificationtype
+const (
+	NotificationTypeConsumptionRequest     = "CONSUMPTION_REQUEST"
+	NotificationTypeDidChangeRenewalPref   = "DID_CHANGE_RENEWAL_PREF"
+	NotificationTypeDidChangeRenewalStatus = "DID_CHANGE_RENEWAL_STATUS"
+	NotificationTypeDidFailToRenew         = "DID_FAIL_TO_RENEW"
+	NotificationTypeDidRenew               = "DID_RENEW"
+	NotificationTypeExpired                = "EXPIRED"
+	NotificationTypeGracePeriodExpired     = "GRACE_PERIOD_EXPIRED"
+	NotificationTypeOfferRedeemed          = "OFFER_REDEEMED"
+	NotificationTypePriceIncrease          = "PRICE_INCREASE"
+	NotificationTypeRefund                 = "REFUND"
+	NotificationTypeRefundDeclined         = "REFUND_DECLINED"
+	NotificationTypeRenewalExtended        = "RENEWAL_EXTENDED"
+	NotificationTypeRevoke                 = "REVOKE"
+	NotificationTypeSubscribed             = "SUBSCRIBED"
+	NotificationTypeTest                   = "TEST"
+)
+
+// 自动续期订阅状态
+// 	https://developer.apple.com/documentation/appstoreserverapi/status
+const (
+	SubscriptionStatusActive       = 1 // 有效
+	SubscriptionStatusExpired      = 2 // 已过期
+	SubscriptionStatusBillingRetry = 3 // 扣款重试期
+	SubscriptionStatusBillingGrace = 4 // 扣款宽限期
+	SubscriptionStatusRevoked      = 5 // 已撤销
+)
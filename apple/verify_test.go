@@ -1,6 +1,9 @@
 package apple
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/cedarwu/gopay/pkg/xlog"
@@ -23,3 +26,38 @@ func TestVerify(t *testing.T) {
 		xlog.Debugf("receipt:%+v", rsp.Receipt)
 	}
 }
+
+func TestAutoVerifyReceipt(t *testing.T) {
+	var prodCalls, sandboxCalls int
+	prod := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prodCalls++
+		_, _ = w.Write([]byte(`{"status":21007}`))
+	}))
+	defer prod.Close()
+	sandbox := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sandboxCalls++
+		req := new(VerifyRequest)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.Receipt != "receipt" || req.Password != "pwd" {
+			t.Errorf("sandbox request = %+v, %v", req, err)
+		}
+		_, _ = w.Write([]byte(`{"status":0,"environment":"Sandbox","latest_receipt_info":[{"product_id":"10002","original_transaction_id":"1000000859439868","expires_date_ms":"1628947697000"}],"pending_renewal_info":[{"auto_renew_product_id":"10002","auto_renew_status":"1","original_transaction_id":"1000000859439868","product_id":"10002"}]}`))
+	}))
+	defer sandbox.Close()
+
+	rsp, err := autoVerifyReceipt(prod.URL, sandbox.URL, &VerifyRequest{Receipt: "receipt", Password: "pwd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prodCalls != 1 || sandboxCalls != 1 {
+		t.Errorf("prodCalls = %d, sandboxCalls = %d", prodCalls, sandboxCalls)
+	}
+	if rsp.Status != StatusValid || rsp.Environment != "Sandbox" {
+		t.Errorf("rsp = %+v", rsp)
+	}
+	if len(rsp.LatestReceiptInfo) != 1 || rsp.LatestReceiptInfo[0].ExpiresDateTimestamp != "1628947697000" {
+		t.Errorf("rsp.LatestReceiptInfo = %+v", rsp.LatestReceiptInfo)
+	}
+	if len(rsp.PendingRenewalInfo) != 1 || rsp.PendingRenewalInfo[0].AutoRenewStatus != "1" {
+		t.Errorf("rsp.PendingRenewalInfo = %+v", rsp.PendingRenewalInfo)
+	}
+}
@@ -0,0 +1,120 @@
+package bestpay
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+	"github.com/cedarwu/gopay/pkg/xpem"
+)
+
+// Client 翼支付客户端
+type Client struct {
+	MerchantNo  string
+	DebugSwitch gopay.DebugSwitch
+
+	apiUrl     string
+	signType   string
+	md5Key     string
+	privateKey *rsa.PrivateKey
+	publicKey  *rsa.PublicKey // 翼支付公钥
+}
+
+// 初始化翼支付客户端（MD5 签名）
+//	merchantNo：商户号
+//	md5Key：MD5 数据密钥
+func NewClient(merchantNo, md5Key string) (client *Client, err error) {
+	if merchantNo == util.NULL || md5Key == util.NULL {
+		return nil, errors.New("merchantNo or md5Key cannot be empty")
+	}
+	return &Client{
+		MerchantNo:  merchantNo,
+		DebugSwitch: gopay.DebugOff,
+		apiUrl:      baseUrl,
+		signType:    MD5,
+		md5Key:      md5Key,
+	}, nil
+}
+
+// 初始化翼支付客户端（RSA 签名）
+//	merchantNo：商户号
+//	privateKey：商户 RSA 私钥文件内容（PEM）
+//	bestpayPublicKey：翼支付 RSA 公钥文件内容（PEM），用于同步响应及异步通知验签
+func NewClientRSA(merchantNo string, privateKey, bestpayPublicKey []byte) (client *Client, err error) {
+	if merchantNo == util.NULL {
+		return nil, errors.New("merchantNo cannot be empty")
+	}
+	priKey, err := xpem.DecodePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := xpem.DecodePublicKey(bestpayPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		MerchantNo:  merchantNo,
+		DebugSwitch: gopay.DebugOff,
+		apiUrl:      baseUrl,
+		signType:    RSA,
+		privateKey:  priKey,
+		publicKey:   pubKey,
+	}, nil
+}
+
+// 向翼支付发送请求，对于本库未提供的翼支付接口，可自行实现，通过此方法发送请求
+//	bm：请求参数的BodyMap，无需设置 merchantNo、signType、sign
+//	uri：接口地址，例如：/tradeQuery
+func (c *Client) PostBestpayAPISelf(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	return c.doBestpay(bm, uri)
+}
+
+func (c *Client) doBestpay(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	if bm.GetString("merchantNo") == util.NULL {
+		bm.Set("merchantNo", c.MerchantNo)
+	}
+	bm.Set("signType", c.signType)
+	bm.Remove("sign")
+	sign, err := c.getSign(bm)
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("sign", sign)
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Bestpay_Request: %s", bm.JsonBody())
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeJSON).Post(c.apiUrl + uri).SendBodyMap(bm).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Bestpay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	// 请求失败（success = false）时不返回 result，不签名
+	rsp, err := decodeBodyMap(bs)
+	if err != nil {
+		return nil, err
+	}
+	if result, ok := rsp["result"].(map[string]interface{}); ok && rsp.GetString("success") == "true" {
+		if err = c.VerifySign(result); err != nil {
+			return nil, err
+		}
+	}
+	return bs, nil
+}
+
+func unmarshalRsp(bs []byte, ptr interface{}) (err error) {
+	if err = json.Unmarshal(bs, ptr); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
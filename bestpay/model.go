@@ -0,0 +1,91 @@
+package bestpay
+
+const (
+	// URL
+	baseUrl        = "https://mapi.bestpay.com.cn/mapi/uniformReceipt"
+	proCreateOrder = "/proCreateOrder" // 下单
+	tradeQuery     = "/tradeQuery"     // 交易查询
+	tradeRefund    = "/tradeRefund"    // 退款
+
+	// 签名方式 signType
+	MD5 = "MD5"
+	RSA = "RSA"
+
+	// 交易状态 tradeStatus
+	TradeStatusWaitForPay = "WAITFORPAY" // 待支付
+	TradeStatusSuccess    = "SUCCESS"    // 支付成功
+	TradeStatusFail       = "FAIL"       // 支付失败
+	TradeStatusClosed     = "CLOSED"     // 已关闭
+
+	// 退款状态 refundStatus
+	RefundStatusProcessing = "PROCESSING" // 退款中
+	RefundStatusSuccess    = "SUCCESS"    // 退款成功
+	RefundStatusFail       = "FAIL"       // 退款失败
+
+	// 交易渠道 tradeChannel
+	TradeChannelApp = "APP"
+	TradeChannelH5  = "H5"
+)
+
+// Response 翼支付接口公共响应参数
+type Response struct {
+	Success   bool   `json:"success"`
+	ErrorCode string `json:"errorCode,omitempty"`
+	ErrorMsg  string `json:"errorMsg,omitempty"`
+}
+
+// IsSuccess 请求是否成功，交易结果以 result 中的状态为准
+func (r *Response) IsSuccess() bool {
+	return r.Success
+}
+
+type CreateOrderRsp struct {
+	Response
+	Result *CreateOrderResult `json:"result,omitempty"`
+}
+
+type CreateOrderResult struct {
+	MerchantNo  string `json:"merchantNo"`
+	OutTradeNo  string `json:"outTradeNo"`
+	TradeNo     string `json:"tradeNo"`
+	TradeAmt    string `json:"tradeAmt"`
+	TradeStatus string `json:"tradeStatus"`
+	Execute     string `json:"execute,omitempty"` // 调起翼支付收银台的参数
+	Sign        string `json:"sign,omitempty"`
+}
+
+type TradeQueryRsp struct {
+	Response
+	Result *TradeQueryResult `json:"result,omitempty"`
+}
+
+type TradeQueryResult struct {
+	MerchantNo        string `json:"merchantNo"`
+	OutTradeNo        string `json:"outTradeNo"`
+	TradeNo           string `json:"tradeNo"`
+	TradeAmt          string `json:"tradeAmt"`
+	PayerAmt          string `json:"payerAmt,omitempty"`
+	TradeStatus       string `json:"tradeStatus"` // 取值见 TradeStatus* 常量
+	TradeFinishedDate string `json:"tradeFinishedDate,omitempty"`
+	RefundFlag        string `json:"refundFlag,omitempty"`
+	Sign              string `json:"sign,omitempty"`
+}
+
+// IsPaid 交易是否支付成功
+func (r *TradeQueryRsp) IsPaid() bool {
+	return r.Success && r.Result != nil && r.Result.TradeStatus == TradeStatusSuccess
+}
+
+type RefundRsp struct {
+	Response
+	Result *RefundResult `json:"result,omitempty"`
+}
+
+type RefundResult struct {
+	MerchantNo   string `json:"merchantNo"`
+	OutRequestNo string `json:"outRequestNo"`
+	RefundNo     string `json:"refundNo"`
+	RefundAmt    string `json:"refundAmt"`
+	RefundStatus string `json:"refundStatus"` // 取值见 RefundStatus* 常量
+	Sign         string `json:"sign,omitempty"`
+}
@@ -0,0 +1,79 @@
+package bestpay
+
+import (
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 下单
+//	必填：outTradeNo、tradeAmt（单位：分）、subject、notifyUrl、tradeChannel
+//	requestDate 默认当前时间，ccy 默认 156
+//	bpRsp.Result.Execute 用于调起翼支付收银台
+func (c *Client) CreateOrder(bm gopay.BodyMap) (bpRsp *CreateOrderRsp, err error) {
+	err = bm.CheckEmptyError("outTradeNo", "tradeAmt", "subject", "notifyUrl", "tradeChannel")
+	if err != nil {
+		return nil, err
+	}
+	if bm.GetString("requestDate") == util.NULL {
+		bm.Set("requestDate", time.Now().Format(util.TimeLayout))
+	}
+	if bm.GetString("ccy") == util.NULL {
+		bm.Set("ccy", "156")
+	}
+	bs, err := c.doBestpay(bm, proCreateOrder)
+	if err != nil {
+		return nil, err
+	}
+	bpRsp = new(CreateOrderRsp)
+	if err = unmarshalRsp(bs, bpRsp); err != nil {
+		return nil, err
+	}
+	return bpRsp, nil
+}
+
+// 交易查询
+//	outTradeNo：商户订单号
+//	bpRsp.IsPaid() 为 true 时支付成功
+func (c *Client) TradeQuery(outTradeNo string) (bpRsp *TradeQueryRsp, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("outTradeNo", outTradeNo)
+	if err = bm.CheckEmptyError("outTradeNo"); err != nil {
+		return nil, err
+	}
+	bs, err := c.doBestpay(bm, tradeQuery)
+	if err != nil {
+		return nil, err
+	}
+	bpRsp = new(TradeQueryRsp)
+	if err = unmarshalRsp(bs, bpRsp); err != nil {
+		return nil, err
+	}
+	return bpRsp, nil
+}
+
+// 退款
+//	必填：outTradeNo（原商户订单号）、outRequestNo（退款流水号）、refundAmt（单位：分）
+//	requestDate 默认当前时间，ccy 默认 156
+func (c *Client) Refund(bm gopay.BodyMap) (bpRsp *RefundRsp, err error) {
+	err = bm.CheckEmptyError("outTradeNo", "outRequestNo", "refundAmt")
+	if err != nil {
+		return nil, err
+	}
+	if bm.GetString("requestDate") == util.NULL {
+		bm.Set("requestDate", time.Now().Format(util.TimeLayout))
+	}
+	if bm.GetString("ccy") == util.NULL {
+		bm.Set("ccy", "156")
+	}
+	bs, err := c.doBestpay(bm, tradeRefund)
+	if err != nil {
+		return nil, err
+	}
+	bpRsp = new(RefundRsp)
+	if err = unmarshalRsp(bs, bpRsp); err != nil {
+		return nil, err
+	}
+	return bpRsp, nil
+}
@@ -0,0 +1,106 @@
+package bestpay
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// getSignContent 签名原文：除 sign、signType 外的非空参数按 key 排序拼接为 key1=value1&key2=value2
+func getSignContent(bm gopay.BodyMap) (content string) {
+	bm = bm.Clone()
+	bm.Remove("sign")
+	bm.Remove("signType")
+	return bm.EncodeAliPaySignParams()
+}
+
+// GetMd5Sign MD5 签名
+//	签名原文后拼接 &KEY=md5Key，取 MD5 大写
+func GetMd5Sign(bm gopay.BodyMap, md5Key string) (sign string) {
+	h := md5.Sum([]byte(getSignContent(bm) + "&KEY=" + md5Key))
+	return strings.ToUpper(hex.EncodeToString(h[:]))
+}
+
+// GetRsaSign RSA 签名
+//	签名原文 SHA256withRSA 签名后 base64 编码
+func GetRsaSign(bm gopay.BodyMap, privateKey *rsa.PrivateKey) (sign string, err error) {
+	h := sha256.Sum256([]byte(getSignContent(bm)))
+	signBytes, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, h[:])
+	if err != nil {
+		return util.NULL, err
+	}
+	return base64.StdEncoding.EncodeToString(signBytes), nil
+}
+
+func (c *Client) getSign(bm gopay.BodyMap) (sign string, err error) {
+	if c.signType == RSA {
+		if c.privateKey == nil {
+			return util.NULL, errors.New("rsa private key is nil, please use bestpay.NewClientRSA()")
+		}
+		return GetRsaSign(bm, c.privateKey)
+	}
+	return GetMd5Sign(bm, c.md5Key), nil
+}
+
+// 解析翼支付异步通知的参数到BodyMap
+//	req：*http.Request，通知请求体为 JSON
+func ParseNotifyToBodyMap(req *http.Request) (bm gopay.BodyMap, err error) {
+	bs, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	return decodeBodyMap(bs)
+}
+
+// decodeBodyMap JSON 解析到 BodyMap，数字保留原始文本，避免验签原文与翼支付不一致
+func decodeBodyMap(bs []byte) (bm gopay.BodyMap, err error) {
+	bm = make(gopay.BodyMap)
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+	decoder.UseNumber()
+	if err = decoder.Decode(&bm); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return bm, nil
+}
+
+// 翼支付同步响应 result、异步通知验签
+//	bm：响应 result 或通知参数，异步通知使用 bestpay.ParseNotifyToBodyMap() 解析，验签通过后响应 SUCCESS
+//	签名方式与客户端一致，RSA 使用翼支付公钥验签
+func (c *Client) VerifySign(bm gopay.BodyMap) (err error) {
+	sign := bm.GetString("sign")
+	if sign == util.NULL {
+		return errors.New("sign : cannot be empty")
+	}
+	if c.signType != RSA {
+		if GetMd5Sign(bm, c.md5Key) != sign {
+			return errors.New("bestpay verify sign failed")
+		}
+		return nil
+	}
+	if c.publicKey == nil {
+		return errors.New("bestpay public key is nil, please use bestpay.NewClientRSA()")
+	}
+	signBytes, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	h := sha256.Sum256([]byte(getSignContent(bm)))
+	if err = rsa.VerifyPKCS1v15(c.publicKey, crypto.SHA256, h[:], signBytes); err != nil {
+		return fmt.Errorf("bestpay verify sign failed：%w", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"net/url"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -30,6 +31,9 @@ func (bm BodyMap) Set(key string, value interface{}) BodyMap {
 	return bm
 }
 
+// 设置嵌套的 BodyMap 参数，value 中构造子参数，JSON 请求时序列化为嵌套对象而非字符串
+//
+//	如微信V3 的 amount、payer、scene_info.h5_info 等，对象数组（如 receivers）可直接 Set []BodyMap
 func (bm BodyMap) SetBodyMap(key string, value func(bm BodyMap)) BodyMap {
 	_bm := make(BodyMap)
 	value(_bm)
@@ -77,6 +81,18 @@ func (bm BodyMap) Remove(key string) {
 	delete(bm, key)
 }
 
+// 浅拷贝BodyMap，嵌套的值（如子 BodyMap）与原 BodyMap 共享
+func (bm BodyMap) Clone() BodyMap {
+	if bm == nil {
+		return nil
+	}
+	cbm := make(BodyMap, len(bm))
+	for k, v := range bm {
+		cbm[k] = v
+	}
+	return cbm
+}
+
 // 置空BodyMap
 func (bm BodyMap) Reset() {
 	for k := range bm {
@@ -97,7 +113,7 @@ func (bm BodyMap) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error)
 	if len(bm) == 0 {
 		return nil
 	}
-	start.Name = xml.Name{NULL, "xml"}
+	start.Name = xml.Name{Space: NULL, Local: "xml"}
 	if err = e.EncodeToken(start); err != nil {
 		return
 	}
@@ -223,10 +239,22 @@ func (bm BodyMap) CheckEmptyError(keys ...string) error {
 	return nil
 }
 
+// JSONStringer 可自行序列化为JSON字符串的参数，Set 到 BodyMap 后 GetString() 时优先调用 ToJSONString()
+type JSONStringer interface {
+	ToJSONString() string
+}
+
 func convertToString(v interface{}) (str string) {
 	if v == nil {
 		return NULL
 	}
+	if js, ok := v.(JSONStringer); ok {
+		return js.ToJSONString()
+	}
+	// 自定义的 string 类型（如枚举类型），直接取其字符串值
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+		return rv.String()
+	}
 	var (
 		bs  []byte
 		err error
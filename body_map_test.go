@@ -53,6 +53,24 @@ func TestBodyMapSetBodyMap(t *testing.T) {
 		Set("8key", "8value")
 	xlog.Debug("高级用法：", bm) // map[scene_info:map[h5_info:map[type:Wap wap_name:H5测试支付 wap_url:https://www.fmm.ink]]]
 	xlog.Debug("高级用法 JsonBody：", bm.JsonBody())
+
+	// 5、嵌套对象及对象数组
+	bm.Reset()
+	bm.Set("out_order_no", "P20150806125346").
+		SetBodyMap("amount", func(bm BodyMap) {
+			bm.Set("total", 100).
+				Set("currency", "CNY")
+		}).
+		Set("receivers", []BodyMap{
+			make(BodyMap).Set("type", "MERCHANT_ID").Set("account", "190001001").Set("amount", 10),
+		})
+	want := `{"amount":{"currency":"CNY","total":100},"out_order_no":"P20150806125346","receivers":[{"account":"190001001","amount":10,"type":"MERCHANT_ID"}]}`
+	if got := bm.JsonBody(); got != want {
+		t.Fatalf("JsonBody() = %s, want %s", got, want)
+	}
+	if got := bm.GetString("amount"); got != `{"currency":"CNY","total":100}` {
+		t.Fatalf("GetString(amount) = %s", got)
+	}
 }
 
 func TestBodyMapMarshal(t *testing.T) {
@@ -131,3 +149,24 @@ func TestBodyMapMarshalSlice(t *testing.T) {
 	bss, _ := xml.Marshal(bm)
 	xlog.Debug("body:", string(bss))
 }
+
+func TestBodyMapClone(t *testing.T) {
+	bm := make(BodyMap)
+	bm.Set("appid", "wx123456").
+		Set("total_fee", 101)
+	cbm := bm.Clone()
+	cbm.Set("sign", "SIGN").Set("total_fee", 1)
+	xlog.Debug("bm:", bm, " cbm:", cbm)
+	if bm.GetString("sign") != NULL || bm.GetString("total_fee") != "101" {
+		t.Fatalf("Clone() should not affect the original BodyMap: %v", bm)
+	}
+}
+
+func TestBodyMapNamedString(t *testing.T) {
+	type tradeType string
+	bm := make(BodyMap)
+	bm.Set("trade_type", tradeType("APP"))
+	if v := bm.GetString("trade_type"); v != "APP" {
+		t.Fatalf("GetString() = %s, want APP", v)
+	}
+}
@@ -0,0 +1,66 @@
+package douyin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+// Client 抖音担保支付客户端
+type Client struct {
+	AppId       string
+	Salt        string // 担保交易支付设置中的 SALT，用于请求签名
+	Token       string // 担保交易支付设置中的 Token，用于回调验签
+	DebugSwitch gopay.DebugSwitch
+
+	apiUrl string
+}
+
+// 初始化抖音担保支付客户端
+//	appId：小程序 AppID
+//	salt：开发者后台「支付-担保交易设置」中的 SALT
+//	token：开发者后台「支付-担保交易设置」中的 Token
+func NewClient(appId, salt, token string) (client *Client, err error) {
+	if appId == util.NULL || salt == util.NULL {
+		return nil, errors.New("appId or salt cannot be empty")
+	}
+	return &Client{
+		AppId:       appId,
+		Salt:        salt,
+		Token:       token,
+		DebugSwitch: gopay.DebugOff,
+		apiUrl:      baseUrl,
+	}, nil
+}
+
+// 向抖音发送请求，对于本库未提供的担保支付接口，可自行实现，通过此方法发送请求
+//	bm：请求参数的BodyMap，无需设置 app_id、sign
+//	uri：接口地址，例如：/create_order
+func (c *Client) PostDouyinAPISelf(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	return c.doDouyin(bm, uri)
+}
+
+func (c *Client) doDouyin(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	bm.Set("app_id", c.AppId)
+	bm.Remove("sign")
+	bm.Set("sign", GetSign(bm, c.Salt))
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Douyin_Request: %s", bm.JsonBody())
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeJSON).Post(c.apiUrl + uri).SendBodyMap(bm).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Douyin_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	return bs, nil
+}
@@ -0,0 +1,165 @@
+package douyin
+
+const (
+	// URL
+	baseUrl      = "https://developer.toutiao.com/api/apps/ecpay/v1" // 担保支付 URL
+	createOrder  = "/create_order"                                   // 预下单
+	queryOrder   = "/query_order"                                    // 支付结果查询
+	createRefund = "/create_refund"                                  // 退款
+	queryRefund  = "/query_refund"                                   // 退款结果查询
+	settle       = "/settle"                                         // 分账请求（结算）
+	querySettle  = "/query_settle"                                   // 分账结果查询
+
+	Success = 0 // err_no 成功
+
+	// 回调类型 type
+	CallbackTypePayment = "payment" // 支付成功回调
+	CallbackTypeRefund  = "refund"  // 退款回调
+	CallbackTypeSettle  = "settle"  // 分账回调
+)
+
+// ErrorInfo 担保支付接口公共响应参数
+type ErrorInfo struct {
+	ErrNo   int    `json:"err_no"`
+	ErrTips string `json:"err_tips"`
+}
+
+// IsSuccess err_no = 0 时请求成功
+func (e *ErrorInfo) IsSuccess() bool {
+	return e.ErrNo == Success
+}
+
+type CreateOrderRsp struct {
+	ErrorInfo
+	Data *CreateOrderData `json:"data,omitempty"`
+}
+
+// CreateOrderData 预下单结果，order_id、order_token 传给小程序 tt.pay 的 orderInfo
+type CreateOrderData struct {
+	OrderId    string `json:"order_id"`
+	OrderToken string `json:"order_token"`
+}
+
+type QueryOrderRsp struct {
+	ErrorInfo
+	OutOrderNo  string       `json:"out_order_no"`
+	OrderId     string       `json:"order_id"`
+	PaymentInfo *PaymentInfo `json:"payment_info,omitempty"`
+}
+
+type PaymentInfo struct {
+	TotalFee         int    `json:"total_fee"`
+	OrderStatus      string `json:"order_status"` // PROCESSING、SUCCESS、FAIL、TIMEOUT
+	PayTime          string `json:"pay_time"`
+	Way              int    `json:"way"` // 1：微信，2：支付宝，10：抖音支付
+	ChannelNo        string `json:"channel_no"`
+	ChannelGatewayNo string `json:"channel_gateway_no"`
+	SellerUid        string `json:"seller_uid"`
+	ItemId           string `json:"item_id"`
+	CpExtra          string `json:"cp_extra"`
+}
+
+type CreateRefundRsp struct {
+	ErrorInfo
+	RefundNo string `json:"refund_no"`
+}
+
+type QueryRefundRsp struct {
+	ErrorInfo
+	RefundInfo *RefundInfo `json:"refundInfo,omitempty"`
+}
+
+type RefundInfo struct {
+	RefundNo     string `json:"refund_no"`
+	RefundAmount int    `json:"refund_amount"`
+	RefundStatus string `json:"refund_status"` // SUCCESS、FAIL、PROCESSING
+	RefundedAt   int64  `json:"refunded_at"`
+	IsAllSettled bool   `json:"is_all_settled"`
+	CpExtra      string `json:"cp_extra"`
+}
+
+type SettleRsp struct {
+	ErrorInfo
+	SettleNo string `json:"settle_no"`
+}
+
+type QuerySettleRsp struct {
+	ErrorInfo
+	SettleInfo *SettleInfo `json:"settle_info,omitempty"`
+}
+
+type SettleInfo struct {
+	SettleNo     string `json:"settle_no"`
+	SettleAmount int    `json:"settle_amount"`
+	SettleStatus string `json:"settle_status"` // SUCCESS、FAIL、PROCESSING
+	SettleDetail string `json:"settle_detail"`
+	SettledAt    int64  `json:"settled_at"`
+	Rake         int    `json:"rake"`
+	Commission   int    `json:"commission"`
+	CpExtra      string `json:"cp_extra"`
+}
+
+// Callback 担保支付回调请求体
+type Callback struct {
+	Timestamp    string `json:"timestamp"`
+	Nonce        string `json:"nonce"`
+	Msg          string `json:"msg"`
+	Type         string `json:"type"` // payment、refund、settle
+	MsgSignature string `json:"msg_signature"`
+}
+
+// PaymentCallbackMsg 支付成功回调 msg
+type PaymentCallbackMsg struct {
+	Appid             string `json:"appid"`
+	CpOrderno         string `json:"cp_orderno"`
+	CpExtra           string `json:"cp_extra"`
+	Way               string `json:"way"`
+	ChannelNo         string `json:"channel_no"`
+	ChannelGatewayNo  string `json:"channel_gateway_no"`
+	PaymentOrderNo    string `json:"payment_order_no"`
+	OutChannelOrderNo string `json:"out_channel_order_no"`
+	TotalAmount       int    `json:"total_amount"`
+	Status            string `json:"status"`
+	SellerUid         string `json:"seller_uid"`
+	Extra             string `json:"extra"`
+	ItemId            string `json:"item_id"`
+	PaidAt            int64  `json:"paid_at"`
+	Message           string `json:"message"`
+	OrderId           string `json:"order_id"`
+}
+
+// RefundCallbackMsg 退款回调 msg
+type RefundCallbackMsg struct {
+	Appid        string `json:"appid"`
+	CpRefundno   string `json:"cp_refundno"`
+	CpExtra      string `json:"cp_extra"`
+	Status       string `json:"status"`
+	RefundAmount int    `json:"refund_amount"`
+	IsAllSettled bool   `json:"is_all_settled"`
+	RefundedAt   int64  `json:"refunded_at"`
+	Message      string `json:"message"`
+	OrderId      string `json:"order_id"`
+	RefundNo     string `json:"refund_no"`
+}
+
+// SettleCallbackMsg 分账回调 msg
+type SettleCallbackMsg struct {
+	Appid        string `json:"appid"`
+	CpSettleNo   string `json:"cp_settle_no"`
+	CpExtra      string `json:"cp_extra"`
+	Status       string `json:"status"`
+	Rake         int    `json:"rake"`
+	Commission   int    `json:"commission"`
+	SettleDetail string `json:"settle_detail"`
+	SettledAt    int64  `json:"settled_at"`
+	Message      string `json:"message"`
+	OrderId      string `json:"order_id"`
+	SettleAmount int    `json:"settle_amount"`
+	SettleNo     string `json:"settle_no"`
+}
+
+// CallbackResponse 回调响应
+type CallbackResponse struct {
+	ErrNo   int    `json:"err_no"`
+	ErrTips string `json:"err_tips"`
+}
@@ -0,0 +1,118 @@
+package douyin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 预下单
+//	必填：out_order_no、total_amount（单位：分）、subject、body、valid_time（秒）
+//	返回的 order_id、order_token 作为小程序 tt.pay 的 orderInfo 发起支付
+func (c *Client) CreateOrder(bm gopay.BodyMap) (dyRsp *CreateOrderRsp, err error) {
+	err = bm.CheckEmptyError("out_order_no", "total_amount", "subject", "body", "valid_time")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doDouyin(bm, createOrder)
+	if err != nil {
+		return nil, err
+	}
+	dyRsp = new(CreateOrderRsp)
+	if err = json.Unmarshal(bs, dyRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return dyRsp, nil
+}
+
+// 支付结果查询
+//	必填：out_order_no
+func (c *Client) QueryOrder(bm gopay.BodyMap) (dyRsp *QueryOrderRsp, err error) {
+	err = bm.CheckEmptyError("out_order_no")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doDouyin(bm, queryOrder)
+	if err != nil {
+		return nil, err
+	}
+	dyRsp = new(QueryOrderRsp)
+	if err = json.Unmarshal(bs, dyRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return dyRsp, nil
+}
+
+// 退款
+//	必填：out_order_no、out_refund_no、reason、refund_amount（单位：分）
+func (c *Client) CreateRefund(bm gopay.BodyMap) (dyRsp *CreateRefundRsp, err error) {
+	err = bm.CheckEmptyError("out_order_no", "out_refund_no", "reason", "refund_amount")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doDouyin(bm, createRefund)
+	if err != nil {
+		return nil, err
+	}
+	dyRsp = new(CreateRefundRsp)
+	if err = json.Unmarshal(bs, dyRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return dyRsp, nil
+}
+
+// 退款结果查询
+//	必填：out_refund_no
+func (c *Client) QueryRefund(bm gopay.BodyMap) (dyRsp *QueryRefundRsp, err error) {
+	err = bm.CheckEmptyError("out_refund_no")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doDouyin(bm, queryRefund)
+	if err != nil {
+		return nil, err
+	}
+	dyRsp = new(QueryRefundRsp)
+	if err = json.Unmarshal(bs, dyRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return dyRsp, nil
+}
+
+// 分账请求（结算）
+//	必填：out_settle_no、out_order_no、settle_desc
+//	担保交易订单需在支付成功 7 天后（可退款期结束）发起结算，资金才会进入商户账户
+func (c *Client) Settle(bm gopay.BodyMap) (dyRsp *SettleRsp, err error) {
+	err = bm.CheckEmptyError("out_settle_no", "out_order_no", "settle_desc")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doDouyin(bm, settle)
+	if err != nil {
+		return nil, err
+	}
+	dyRsp = new(SettleRsp)
+	if err = json.Unmarshal(bs, dyRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return dyRsp, nil
+}
+
+// 分账结果查询
+//	必填：out_settle_no
+func (c *Client) QuerySettle(bm gopay.BodyMap) (dyRsp *QuerySettleRsp, err error) {
+	err = bm.CheckEmptyError("out_settle_no")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doDouyin(bm, querySettle)
+	if err != nil {
+		return nil, err
+	}
+	dyRsp = new(QuerySettleRsp)
+	if err = json.Unmarshal(bs, dyRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return dyRsp, nil
+}
@@ -0,0 +1,127 @@
+package douyin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestClient_PaymentAPI(t *testing.T) {
+	c, err := NewClient("tt07e3715e98c9aac0", "salt_xxx", "token_xxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 担保支付接口响应示例
+	responses := map[string]string{
+		createOrder:  `{"err_no":0,"err_tips":"","data":{"order_id":"6819903302604491021","order_token":"CgsIARDiGhgCIAEoARJOCkzLEiCiZAB3ZuB7cOgoyMmhiMSaB4M54TQ6J2jzHWIQHNXVuGfUW6Q7QEHrq3uvy2mIN8IvR0VpDZl9oFOHaXdBlGDXjt9lKBoA"}}`,
+		queryOrder:   `{"err_no":0,"err_tips":"","out_order_no":"out_order_no_1","order_id":"N6819903302604491021","payment_info":{"total_fee":1200,"order_status":"SUCCESS","pay_time":"2021-07-08 12:12:22","way":2,"channel_no":"2021070722001450071438803941","channel_gateway_no":"","seller_uid":"69631798443938962290","item_id":"","cp_extra":""}}`,
+		createRefund: `{"err_no":0,"err_tips":"","refund_no":"N6980958136891050272"}`,
+		queryRefund:  `{"err_no":0,"err_tips":"","refundInfo":{"refund_no":"N6980958136891050272","refund_amount":1200,"refund_status":"SUCCESS","refunded_at":1625717742,"is_all_settled":false,"cp_extra":""}}`,
+		settle:       `{"err_no":0,"err_tips":"","settle_no":"N6980961547264264461"}`,
+		querySettle:  `{"err_no":0,"err_tips":"","settle_info":{"settle_no":"N6980961547264264461","settle_amount":1188,"settle_status":"SUCCESS","settle_detail":"商户号69631798443938962290-分账金额(分)1188","settled_at":1626321630,"rake":12,"commission":0,"cp_extra":""}}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := make(gopay.BodyMap)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GetString("app_id") != c.AppId {
+			_, _ = w.Write([]byte(`{"err_no":1,"err_tips":"参数错误"}`))
+			return
+		}
+		sign := req.GetString("sign")
+		req.Remove("sign")
+		if sign != GetSign(req, "salt_xxx") {
+			_, _ = w.Write([]byte(`{"err_no":2008,"err_tips":"签名校验失败"}`))
+			return
+		}
+		rsp, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(rsp))
+	}))
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("out_order_no", "out_order_no_1").
+		Set("total_amount", 1200).
+		Set("subject", "测试商品").
+		Set("body", "测试商品").
+		Set("valid_time", 900)
+	orderRsp, err := c.CreateOrder(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !orderRsp.IsSuccess() || orderRsp.Data == nil || orderRsp.Data.OrderId != "6819903302604491021" || orderRsp.Data.OrderToken == "" {
+		t.Errorf("CreateOrder() = %+v", orderRsp)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("out_order_no", "out_order_no_1")
+	queryRsp, err := c.QueryOrder(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !queryRsp.IsSuccess() || queryRsp.PaymentInfo == nil || queryRsp.PaymentInfo.OrderStatus != "SUCCESS" || queryRsp.PaymentInfo.TotalFee != 1200 {
+		t.Errorf("QueryOrder() = %+v", queryRsp)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("out_order_no", "out_order_no_1").
+		Set("out_refund_no", "out_refund_no_1").
+		Set("reason", "协商退款").
+		Set("refund_amount", 1200)
+	refundRsp, err := c.CreateRefund(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refundRsp.IsSuccess() || refundRsp.RefundNo != "N6980958136891050272" {
+		t.Errorf("CreateRefund() = %+v", refundRsp)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("out_refund_no", "out_refund_no_1")
+	queryRefundRsp, err := c.QueryRefund(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !queryRefundRsp.IsSuccess() || queryRefundRsp.RefundInfo == nil || queryRefundRsp.RefundInfo.RefundStatus != "SUCCESS" || queryRefundRsp.RefundInfo.RefundAmount != 1200 {
+		t.Errorf("QueryRefund() = %+v", queryRefundRsp)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("out_settle_no", "out_settle_no_1").
+		Set("out_order_no", "out_order_no_1").
+		Set("settle_desc", "主动结算")
+	settleRsp, err := c.Settle(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !settleRsp.IsSuccess() || settleRsp.SettleNo != "N6980961547264264461" {
+		t.Errorf("Settle() = %+v", settleRsp)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("out_settle_no", "out_settle_no_1")
+	querySettleRsp, err := c.QuerySettle(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !querySettleRsp.IsSuccess() || querySettleRsp.SettleInfo == nil || querySettleRsp.SettleInfo.SettleAmount != 1188 || querySettleRsp.SettleInfo.Rake != 12 {
+		t.Errorf("QuerySettle() = %+v", querySettleRsp)
+	}
+
+	// 签名错误
+	c.Salt = "other_salt"
+	bm = make(gopay.BodyMap)
+	bm.Set("out_order_no", "out_order_no_1")
+	if queryRsp, err = c.QueryOrder(bm); err != nil {
+		t.Fatal(err)
+	}
+	if queryRsp.IsSuccess() || queryRsp.ErrNo != 2008 {
+		t.Errorf("QueryOrder() with wrong salt = %+v", queryRsp)
+	}
+}
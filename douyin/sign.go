@@ -0,0 +1,121 @@
+package douyin
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 不参与签名的参数
+var signSkipKeys = map[string]bool{
+	"app_id":              true,
+	"thirdparty_id":       true,
+	"sign":                true,
+	"other_settle_params": true,
+}
+
+// GetSign 担保支付请求签名
+//	除 app_id、thirdparty_id、sign、other_settle_params 外的非空参数值与 salt 按字典序排序后以 & 拼接，再取 MD5
+func GetSign(bm gopay.BodyMap, salt string) (sign string) {
+	values := make([]string, 0, len(bm)+1)
+	for k := range bm {
+		if signSkipKeys[k] {
+			continue
+		}
+		v := strings.TrimSpace(bm.GetString(k))
+		if len(v) > 1 && strings.HasPrefix(v, `"`) && strings.HasSuffix(v, `"`) {
+			v = strings.TrimSpace(v[1 : len(v)-1])
+		}
+		if v == util.NULL || v == "null" {
+			continue
+		}
+		values = append(values, v)
+	}
+	values = append(values, salt)
+	sort.Strings(values)
+	h := md5.Sum([]byte(strings.Join(values, "&")))
+	return hex.EncodeToString(h[:])
+}
+
+// 解析担保支付回调请求体
+//	req：*http.Request
+func ParseCallback(req *http.Request) (callback *Callback, err error) {
+	bs, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	defer req.Body.Close()
+	callback = new(Callback)
+	if err = json.Unmarshal(bs, callback); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return callback, nil
+}
+
+// 担保支付回调验签
+//	token：开发者后台「支付-担保交易设置」中的 Token
+//	timestamp、nonce、msg、token 按字典序排序后拼接，取 SHA1 与 msg_signature 比较
+func VerifyCallbackSign(token string, callback *Callback) (err error) {
+	if callback == nil || callback.MsgSignature == util.NULL {
+		return errors.New("msg_signature : cannot be empty")
+	}
+	strs := []string{token, callback.Timestamp, callback.Nonce, callback.Msg}
+	sort.Strings(strs)
+	h := sha1.Sum([]byte(strings.Join(strs, "")))
+	if hex.EncodeToString(h[:]) != callback.MsgSignature {
+		return errors.New("douyin callback verify sign failed")
+	}
+	return nil
+}
+
+// 担保支付回调验签，使用 client.Token
+func (c *Client) VerifyCallbackSign(callback *Callback) (err error) {
+	return VerifyCallbackSign(c.Token, callback)
+}
+
+// PaymentMsg 解析支付成功回调的 msg
+func (c *Callback) PaymentMsg() (msg *PaymentCallbackMsg, err error) {
+	msg = new(PaymentCallbackMsg)
+	if err = c.unmarshalMsg(CallbackTypePayment, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// RefundMsg 解析退款回调的 msg
+func (c *Callback) RefundMsg() (msg *RefundCallbackMsg, err error) {
+	msg = new(RefundCallbackMsg)
+	if err = c.unmarshalMsg(CallbackTypeRefund, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// SettleMsg 解析分账回调的 msg
+func (c *Callback) SettleMsg() (msg *SettleCallbackMsg, err error) {
+	msg = new(SettleCallbackMsg)
+	if err = c.unmarshalMsg(CallbackTypeSettle, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (c *Callback) unmarshalMsg(typ string, ptr interface{}) (err error) {
+	if c.Type != typ {
+		return fmt.Errorf("callback type is %s, not %s", c.Type, typ)
+	}
+	if err = json.Unmarshal([]byte(c.Msg), ptr); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", c.Msg, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package douyin
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestGetSign(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("app_id", "tt07e3715e98c9aac0").
+		Set("thirdparty_id", "").
+		Set("out_order_no", "out_order_no_1").
+		Set("total_amount", 100).
+		Set("subject", `"测试商品"`).
+		Set("body", " 测试商品 ").
+		Set("valid_time", 900).
+		Set("cp_extra", "null").
+		Set("notify_url", "")
+	h := md5.Sum([]byte("100&900&out_order_no_1&salt_xxx&测试商品&测试商品"))
+	if sign := GetSign(bm, "salt_xxx"); sign != hex.EncodeToString(h[:]) {
+		t.Errorf("GetSign() = %s, want %s", sign, hex.EncodeToString(h[:]))
+	}
+}
+
+func TestVerifyCallbackSign(t *testing.T) {
+	body := `{"timestamp":"1602507471","nonce":"797","msg":"{\"appid\":\"tt07e3715e98c9aac0\",\"cp_orderno\":\"out_order_no_1\",\"cp_extra\":\"\",\"way\":\"2\",\"payment_order_no\":\"2021070722001450071438803941\",\"total_amount\":9980,\"status\":\"SUCCESS\",\"seller_uid\":\"69631798443938962290\",\"paid_at\":1625717542,\"order_id\":\"6980958136891050271\"}","type":"payment","msg_signature":"%s"}`
+	req, _ := http.NewRequest(http.MethodPost, "/douyin/notify", strings.NewReader(body))
+	callback, err := ParseCallback(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strs := []string{"token_xxx", callback.Timestamp, callback.Nonce, callback.Msg}
+	sort.Strings(strs)
+	h := sha1.Sum([]byte(strings.Join(strs, "")))
+	callback.MsgSignature = hex.EncodeToString(h[:])
+
+	c := &Client{AppId: "tt07e3715e98c9aac0", Salt: "salt_xxx", Token: "token_xxx"}
+	if err = c.VerifyCallbackSign(callback); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := callback.PaymentMsg()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.CpOrderno != "out_order_no_1" || msg.TotalAmount != 9980 || msg.Status != "SUCCESS" {
+		t.Errorf("msg = %+v", msg)
+	}
+	if _, err = callback.RefundMsg(); err == nil {
+		t.Error("RefundMsg() of payment callback should return error")
+	}
+	if err = VerifyCallbackSign("other_token", callback); err == nil {
+		t.Error("VerifyCallbackSign() with wrong token should return error")
+	}
+}
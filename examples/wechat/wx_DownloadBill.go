@@ -21,7 +21,7 @@ func DownloadBill() {
 	bm.Set("nonce_str", util.GetRandomString(32)).
 		Set("sign_type", wechat.SignType_MD5).
 		Set("bill_date", "20190722").
-		Set("bill_type", "ALL")
+		Set("bill_type", wechat.BillTypeAll)
 
 	//请求下载对账单，成功后得到结果（string类型字符串）
 	wxRsp, _, err := client.DownloadBill(bm)
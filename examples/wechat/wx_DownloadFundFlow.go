@@ -22,7 +22,7 @@ func DownloadFundFlow() {
 	bm.Set("nonce_str", util.GetRandomString(32)).
 		Set("sign_type", wechat.SignType_HMAC_SHA256).
 		Set("bill_date", "20190122").
-		Set("account_type", "Basic")
+		Set("account_type", wechat.AccountTypeBasic)
 
 	// 请求下载资金账单，成功后得到结果，沙箱环境下，证书路径参数可传空
 	wxRsp, _, err := client.DownloadFundFlow(bm)
@@ -2,4 +2,7 @@ module github.com/cedarwu/gopay
 
 go 1.16
 
-require golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
+require (
+	github.com/emmansun/gmsm v0.15.5
+	golang.org/x/crypto v0.4.0
+)
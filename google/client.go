@@ -0,0 +1,159 @@
+package google
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+	"github.com/cedarwu/gopay/pkg/xpem"
+)
+
+// Client Google Play Developer API 客户端
+type Client struct {
+	ClientEmail string // 服务帐号邮箱
+	DebugSwitch gopay.DebugSwitch
+
+	privateKeyId string
+	privateKey   *rsa.PrivateKey
+	tokenUri     string
+	apiUrl       string
+
+	mu          sync.Mutex
+	accessToken string
+	expireAt    time.Time
+}
+
+// 初始化 Google Play Developer API 客户端
+//	serviceAccountJson：Google Cloud 服务帐号密钥 JSON 文件内容，服务帐号需在 Play 管理中心授予财务数据查看权限
+func NewClient(serviceAccountJson []byte) (client *Client, err error) {
+	sa := new(serviceAccount)
+	if err = json.Unmarshal(serviceAccountJson, sa); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(service account)：%w", err)
+	}
+	if sa.ClientEmail == util.NULL || sa.PrivateKey == util.NULL {
+		return nil, errors.New("service account client_email or private_key cannot be empty")
+	}
+	key, err := xpem.DecodePrivateKey([]byte(sa.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	if sa.TokenUri == util.NULL {
+		sa.TokenUri = defaultTokenUri
+	}
+	return &Client{
+		ClientEmail:  sa.ClientEmail,
+		DebugSwitch:  gopay.DebugOff,
+		privateKeyId: sa.PrivateKeyId,
+		privateKey:   key,
+		tokenUri:     sa.TokenUri,
+		apiUrl:       androidPublisherUrl,
+	}, nil
+}
+
+// generateAssertion 生成服务帐号 RS256 JWT，用于换取 Access Token
+func (c *Client) generateAssertion() (assertion string, err error) {
+	now := time.Now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": c.privateKeyId})
+	if err != nil {
+		return util.NULL, err
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"iss":   c.ClientEmail,
+		"scope": androidPublisherScope,
+		"aud":   c.tokenUri,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return util.NULL, err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	h := sha256.Sum256([]byte(signingInput))
+	sign, err := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, h[:])
+	if err != nil {
+		return util.NULL, fmt.Errorf("rsa.SignPKCS1v15：%w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign), nil
+}
+
+// 获取 Access Token，有效期内复用，过期前 5 分钟刷新
+func (c *Client) GetAccessToken() (accessToken string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.accessToken != util.NULL && time.Now().Before(c.expireAt) {
+		return c.accessToken, nil
+	}
+	assertion, err := c.generateAssertion()
+	if err != nil {
+		return util.NULL, err
+	}
+	form := make(url.Values)
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeForm).Post(c.tokenUri).SendString(form.Encode()).EndBytes()
+	if len(errs) > 0 {
+		return util.NULL, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Google_Token_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	rsp := new(accessTokenRsp)
+	if err = json.Unmarshal(bs, rsp); err != nil {
+		return util.NULL, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK || rsp.AccessToken == util.NULL {
+		return util.NULL, fmt.Errorf("get google access token error, StatusCode = %d, %s: %s", res.StatusCode, rsp.Error, rsp.ErrorDescription)
+	}
+	c.accessToken = rsp.AccessToken
+	c.expireAt = time.Now().Add(time.Duration(rsp.ExpiresIn)*time.Second - 5*time.Minute)
+	return c.accessToken, nil
+}
+
+func (c *Client) doGoogleGet(uri string, v interface{}) (err error) {
+	accessToken, err := c.GetAccessToken()
+	if err != nil {
+		return err
+	}
+	url := c.apiUrl + uri
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Google_Url: %s", url)
+	}
+	httpClient := xhttp.NewClient()
+	httpClient.Header.Add("Authorization", "Bearer "+accessToken)
+	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Get(url).EndBytes()
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Google_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		if res.StatusCode == http.StatusUnauthorized {
+			c.mu.Lock()
+			c.accessToken = util.NULL
+			c.mu.Unlock()
+		}
+		errRsp := &ErrorResponse{HttpCode: res.StatusCode}
+		if len(bs) > 0 {
+			_ = json.Unmarshal(bs, errRsp)
+		}
+		return errRsp
+	}
+	if err = json.Unmarshal(bs, v); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package google
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_GetProductPurchase(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tokenCount int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			tokenCount++
+			parts := strings.Split(r.FormValue("assertion"), ".")
+			if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || len(parts) != 3 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			sign, _ := base64.RawURLEncoding.DecodeString(parts[2])
+			h := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, h[:], sign); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid JWT Signature."}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"ya29.test","expires_in":3599,"token_type":"Bearer"}`))
+		case r.Header.Get("Authorization") != "Bearer ya29.test":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/com.example.app/purchases/products/coin100/tokens/token001":
+			_, _ = w.Write([]byte(`{"kind":"androidpublisher#productPurchase","purchaseTimeMillis":"1672531200000","purchaseState":0,"consumptionState":0,"orderId":"GPA.1234-5678-9012-34567","purchaseType":0,"acknowledgementState":1,"regionCode":"US"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"The purchase token was not found.","status":"NOT_FOUND"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	sa, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "gopay@example.iam.gserviceaccount.com",
+		"private_key_id": "kid",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":      ts.URL + "/token",
+	})
+	c, err := NewClient(sa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.apiUrl = ts.URL
+
+	for i := 0; i < 2; i++ {
+		rsp, err := c.GetProductPurchase("com.example.app", "coin100", "token001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rsp.PurchaseTimeMillis != 1672531200000 || rsp.PurchaseState != PurchaseStatePurchased || !rsp.IsTest() || rsp.OrderId != "GPA.1234-5678-9012-34567" {
+			t.Fatalf("GetProductPurchase() = %+v", rsp)
+		}
+	}
+	if tokenCount != 1 {
+		t.Errorf("access token requested %d times, want 1", tokenCount)
+	}
+
+	_, err = c.GetProductPurchase("com.example.app", "coin100", "token002")
+	errRsp, ok := err.(*ErrorResponse)
+	if !ok || errRsp.HttpCode != http.StatusNotFound || errRsp.Err.Status != "NOT_FOUND" {
+		t.Fatalf("GetProductPurchase() error = %v", err)
+	}
+}
+
+func TestDecodeNotification(t *testing.T) {
+	data := base64.StdEncoding.EncodeToString([]byte(`{"version":"1.0","packageName":"com.example.app","eventTimeMillis":"1672531200000","subscriptionNotification":{"version":"1.0","notificationType":4,"purchaseToken":"token001","subscriptionId":"monthly"}}`))
+	body := `{"message":{"data":"` + data + `","messageId":"136969346945","publishTime":"2023-01-01T00:00:00.000Z"},"subscription":"projects/example/subscriptions/play-rtdn"}`
+	notification, err := ParseNotification(httptest.NewRequest(http.MethodPost, "/rtdn", strings.NewReader(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sn := notification.SubscriptionNotification
+	if notification.EventTimeMillis != 1672531200000 || sn == nil || sn.NotificationType != SubscriptionPurchased || sn.SubscriptionId != "monthly" {
+		t.Fatalf("ParseNotification() = %+v", notification)
+	}
+	if notification.OneTimeProductNotification != nil || notification.TestNotification != nil {
+		t.Fatalf("ParseNotification() = %+v", notification)
+	}
+}
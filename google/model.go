@@ -0,0 +1,227 @@
+package google
+
+import (
+	"fmt"
+)
+
+const (
+	// Android Publisher API
+	androidPublisherUrl   = "https://androidpublisher.googleapis.com/androidpublisher/v3/applications"
+	androidPublisherScope = "https://www.googleapis.com/auth/androidpublisher"
+
+	// 服务帐号未指定 token_uri 时使用
+	defaultTokenUri = "https://oauth2.googleapis.com/token"
+
+	getProductPurchase        = "/%s/purchases/products/%s/tokens/%s"      // packageName、productId、token 查询一次性商品购买 GET
+	getSubscriptionPurchase   = "/%s/purchases/subscriptions/%s/tokens/%s" // packageName、subscriptionId、token 查询订阅购买 GET
+	getSubscriptionPurchaseV2 = "/%s/purchases/subscriptionsv2/tokens/%s"  // packageName、token 查询订阅购买（v2） GET
+
+	// 一次性商品购买状态 purchaseState
+	PurchaseStatePurchased = 0 // 已购买
+	PurchaseStateCanceled  = 1 // 已取消
+	PurchaseStatePending   = 2 // 待处理
+
+	// 一次性商品消耗状态 consumptionState
+	ConsumptionStateNotConsumed = 0 // 未消耗
+	ConsumptionStateConsumed    = 1 // 已消耗
+
+	// 确认状态 acknowledgementState
+	AcknowledgementStateNotAcknowledged = 0 // 未确认
+	AcknowledgementStateAcknowledged    = 1 // 已确认
+
+	// 购买类型 purchaseType，正常购买时不返回
+	PurchaseTypeTest     = 0 // 测试购买（许可测试帐号）
+	PurchaseTypePromo    = 1 // 促销码购买
+	PurchaseTypeRewarded = 2 // 激励广告
+
+	// 订阅状态 subscriptionState（v2）
+	SubscriptionStateActive        = "SUBSCRIPTION_STATE_ACTIVE"
+	SubscriptionStateCanceled      = "SUBSCRIPTION_STATE_CANCELED"
+	SubscriptionStateInGracePeriod = "SUBSCRIPTION_STATE_IN_GRACE_PERIOD"
+	SubscriptionStateOnHold        = "SUBSCRIPTION_STATE_ON_HOLD"
+	SubscriptionStatePaused        = "SUBSCRIPTION_STATE_PAUSED"
+	SubscriptionStateExpired       = "SUBSCRIPTION_STATE_EXPIRED"
+	SubscriptionStatePending       = "SUBSCRIPTION_STATE_PENDING"
+
+	// 实时开发者通知 订阅通知类型 subscriptionNotification.notificationType
+	SubscriptionRecovered               = 1  // 从账号保留状态恢复
+	SubscriptionRenewed                 = 2  // 续订成功
+	SubscriptionCanceled                = 3  // 自愿或非自愿取消
+	SubscriptionPurchased               = 4  // 新购买
+	SubscriptionOnHold                  = 5  // 进入账号保留状态
+	SubscriptionInGracePeriod           = 6  // 进入宽限期
+	SubscriptionRestarted               = 7  // 用户恢复订阅
+	SubscriptionPriceChangeConfirmed    = 8  // 用户确认价格变动
+	SubscriptionDeferred                = 9  // 续订时间延期
+	SubscriptionPaused                  = 10 // 订阅暂停
+	SubscriptionPauseScheduleChanged    = 11 // 暂停计划变更
+	SubscriptionRevoked                 = 12 // 到期前被撤销
+	SubscriptionExpired                 = 13 // 订阅到期
+	SubscriptionPendingPurchaseCanceled = 20 // 待处理交易被取消
+
+	// 实时开发者通知 一次性商品通知类型 oneTimeProductNotification.notificationType
+	OneTimeProductPurchased = 1 // 购买成功
+	OneTimeProductCanceled  = 2 // 待处理购买被取消
+)
+
+// ErrorResponse Google API 错误响应
+type ErrorResponse struct {
+	HttpCode int `json:"-"`
+	Err      struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("google api error, StatusCode = %d, status = %s, message = %s", e.HttpCode, e.Err.Status, e.Err.Message)
+}
+
+type serviceAccount struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyId string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenUri     string `json:"token_uri"`
+}
+
+type accessTokenRsp struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// ProductPurchase 一次性商品购买详情
+type ProductPurchase struct {
+	Kind                        string `json:"kind,omitempty"`
+	PurchaseTimeMillis          int64  `json:"purchaseTimeMillis,string,omitempty"`
+	PurchaseState               int    `json:"purchaseState"`
+	ConsumptionState            int    `json:"consumptionState"`
+	DeveloperPayload            string `json:"developerPayload,omitempty"`
+	OrderId                     string `json:"orderId,omitempty"`
+	PurchaseType                *int   `json:"purchaseType,omitempty"` // 正常购买时为空
+	AcknowledgementState        int    `json:"acknowledgementState"`
+	PurchaseToken               string `json:"purchaseToken,omitempty"`
+	ProductId                   string `json:"productId,omitempty"`
+	Quantity                    int    `json:"quantity,omitempty"`
+	ObfuscatedExternalAccountId string `json:"obfuscatedExternalAccountId,omitempty"`
+	ObfuscatedExternalProfileId string `json:"obfuscatedExternalProfileId,omitempty"`
+	RegionCode                  string `json:"regionCode,omitempty"`
+	RefundableQuantity          int    `json:"refundableQuantity,omitempty"`
+}
+
+// IsTest 是否是测试购买
+func (p *ProductPurchase) IsTest() bool {
+	return p.PurchaseType != nil && *p.PurchaseType == PurchaseTypeTest
+}
+
+// SubscriptionPurchase 订阅购买详情
+type SubscriptionPurchase struct {
+	Kind                        string `json:"kind,omitempty"`
+	StartTimeMillis             int64  `json:"startTimeMillis,string,omitempty"`
+	ExpiryTimeMillis            int64  `json:"expiryTimeMillis,string,omitempty"`
+	AutoResumeTimeMillis        int64  `json:"autoResumeTimeMillis,string,omitempty"`
+	AutoRenewing                bool   `json:"autoRenewing"`
+	PriceCurrencyCode           string `json:"priceCurrencyCode,omitempty"`
+	PriceAmountMicros           int64  `json:"priceAmountMicros,string,omitempty"`
+	CountryCode                 string `json:"countryCode,omitempty"`
+	DeveloperPayload            string `json:"developerPayload,omitempty"`
+	PaymentState                *int   `json:"paymentState,omitempty"` // 0：待付款，1：已付款，2：免费试用，3：待处理的延期升级/降级
+	CancelReason                *int   `json:"cancelReason,omitempty"` // 0：用户取消，1：系统取消，2：被新订阅替换，3：开发者取消
+	UserCancellationTimeMillis  int64  `json:"userCancellationTimeMillis,string,omitempty"`
+	OrderId                     string `json:"orderId,omitempty"`
+	LinkedPurchaseToken         string `json:"linkedPurchaseToken,omitempty"`
+	PurchaseType                *int   `json:"purchaseType,omitempty"` // 正常购买时为空
+	AcknowledgementState        int    `json:"acknowledgementState"`
+	ObfuscatedExternalAccountId string `json:"obfuscatedExternalAccountId,omitempty"`
+	ObfuscatedExternalProfileId string `json:"obfuscatedExternalProfileId,omitempty"`
+}
+
+// IsTest 是否是测试购买
+func (p *SubscriptionPurchase) IsTest() bool {
+	return p.PurchaseType != nil && *p.PurchaseType == PurchaseTypeTest
+}
+
+// SubscriptionPurchaseV2 订阅购买详情（v2）
+type SubscriptionPurchaseV2 struct {
+	Kind                       string                      `json:"kind,omitempty"`
+	RegionCode                 string                      `json:"regionCode,omitempty"`
+	StartTime                  string                      `json:"startTime,omitempty"` // RFC3339
+	SubscriptionState          string                      `json:"subscriptionState,omitempty"`
+	LatestOrderId              string                      `json:"latestOrderId,omitempty"`
+	LinkedPurchaseToken        string                      `json:"linkedPurchaseToken,omitempty"`
+	AcknowledgementState       string                      `json:"acknowledgementState,omitempty"`
+	ExternalAccountIdentifiers *ExternalAccountIdentifiers `json:"externalAccountIdentifiers,omitempty"`
+	TestPurchase               *struct{}                   `json:"testPurchase,omitempty"` // 不为空时为测试购买
+	LineItems                  []*SubscriptionLineItem     `json:"lineItems,omitempty"`
+}
+
+type ExternalAccountIdentifiers struct {
+	ExternalAccountId           string `json:"externalAccountId,omitempty"`
+	ObfuscatedExternalAccountId string `json:"obfuscatedExternalAccountId,omitempty"`
+	ObfuscatedExternalProfileId string `json:"obfuscatedExternalProfileId,omitempty"`
+}
+
+type SubscriptionLineItem struct {
+	ProductId        string `json:"productId,omitempty"`
+	ExpiryTime       string `json:"expiryTime,omitempty"` // RFC3339
+	AutoRenewingPlan *struct {
+		AutoRenewEnabled bool `json:"autoRenewEnabled"`
+	} `json:"autoRenewingPlan,omitempty"`
+	OfferDetails *struct {
+		BasePlanId string   `json:"basePlanId,omitempty"`
+		OfferId    string   `json:"offerId,omitempty"`
+		OfferTags  []string `json:"offerTags,omitempty"`
+	} `json:"offerDetails,omitempty"`
+}
+
+// PubSubMessage Cloud Pub/Sub 推送订阅的请求体
+type PubSubMessage struct {
+	Message struct {
+		Data        string            `json:"data"` // base64 编码的 DeveloperNotification
+		Attributes  map[string]string `json:"attributes,omitempty"`
+		MessageId   string            `json:"messageId"`
+		PublishTime string            `json:"publishTime"`
+	} `json:"message"`
+	Subscription string `json:"subscription"`
+}
+
+// DeveloperNotification 实时开发者通知（RTDN）
+type DeveloperNotification struct {
+	Version                    string                      `json:"version"`
+	PackageName                string                      `json:"packageName"`
+	EventTimeMillis            int64                       `json:"eventTimeMillis,string"`
+	SubscriptionNotification   *SubscriptionNotification   `json:"subscriptionNotification,omitempty"`
+	OneTimeProductNotification *OneTimeProductNotification `json:"oneTimeProductNotification,omitempty"`
+	VoidedPurchaseNotification *VoidedPurchaseNotification `json:"voidedPurchaseNotification,omitempty"`
+	TestNotification           *TestNotification           `json:"testNotification,omitempty"`
+}
+
+type SubscriptionNotification struct {
+	Version          string `json:"version"`
+	NotificationType int    `json:"notificationType"`
+	PurchaseToken    string `json:"purchaseToken"`
+	SubscriptionId   string `json:"subscriptionId"`
+}
+
+type OneTimeProductNotification struct {
+	Version          string `json:"version"`
+	NotificationType int    `json:"notificationType"`
+	PurchaseToken    string `json:"purchaseToken"`
+	Sku              string `json:"sku"`
+}
+
+type VoidedPurchaseNotification struct {
+	PurchaseToken string `json:"purchaseToken"`
+	OrderId       string `json:"orderId"`
+	ProductType   int    `json:"productType"` // 1：订阅，2：一次性商品
+	RefundType    int    `json:"refundType"`  // 1：全额退款，2：部分退款
+}
+
+type TestNotification struct {
+	Version string `json:"version"`
+}
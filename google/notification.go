@@ -0,0 +1,39 @@
+package google
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// 解析 Cloud Pub/Sub 推送的实时开发者通知（RTDN）
+//	req：Pub/Sub 推送订阅的 *http.Request
+//	注意：Pub/Sub 推送请求本身不带签名，请在推送端点开启身份验证或使用不可猜测的地址，
+//	并通过 client.GetProductPurchase()、client.GetSubscriptionPurchase() 查询确认购买状态
+func ParseNotification(req *http.Request) (notification *DeveloperNotification, err error) {
+	bs, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	return DecodeNotification(bs)
+}
+
+// 解码实时开发者通知（RTDN）
+//	body：Pub/Sub 推送请求体 {"message":{"data":"..."},"subscription":"..."}
+func DecodeNotification(body []byte) (notification *DeveloperNotification, err error) {
+	msg := new(PubSubMessage)
+	if err = json.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(body), err)
+	}
+	data, err := base64.StdEncoding.DecodeString(msg.Message.Data)
+	if err != nil {
+		return nil, fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	notification = new(DeveloperNotification)
+	if err = json.Unmarshal(data, notification); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(data), err)
+	}
+	return notification, nil
+}
@@ -0,0 +1,58 @@
+package google
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 查询一次性商品购买（purchases.products.get）
+//	packageName：应用包名
+//	productId：商品ID
+//	purchaseToken：购买 Token
+//	rsp.PurchaseState 为 google.PurchaseStatePurchased 时购买成功
+func (c *Client) GetProductPurchase(packageName, productId, purchaseToken string) (rsp *ProductPurchase, err error) {
+	if packageName == util.NULL || productId == util.NULL || purchaseToken == util.NULL {
+		return nil, errors.New("packageName、productId、purchaseToken cannot be empty")
+	}
+	uri := fmt.Sprintf(getProductPurchase, url.PathEscape(packageName), url.PathEscape(productId), url.PathEscape(purchaseToken))
+	rsp = new(ProductPurchase)
+	if err = c.doGoogleGet(uri, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// 查询订阅购买（purchases.subscriptions.get）
+//	packageName：应用包名
+//	subscriptionId：订阅商品ID
+//	purchaseToken：购买 Token
+func (c *Client) GetSubscriptionPurchase(packageName, subscriptionId, purchaseToken string) (rsp *SubscriptionPurchase, err error) {
+	if packageName == util.NULL || subscriptionId == util.NULL || purchaseToken == util.NULL {
+		return nil, errors.New("packageName、subscriptionId、purchaseToken cannot be empty")
+	}
+	uri := fmt.Sprintf(getSubscriptionPurchase, url.PathEscape(packageName), url.PathEscape(subscriptionId), url.PathEscape(purchaseToken))
+	rsp = new(SubscriptionPurchase)
+	if err = c.doGoogleGet(uri, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// 查询订阅购买 v2（purchases.subscriptionsv2.get）
+//	packageName：应用包名
+//	purchaseToken：购买 Token
+//	rsp.SubscriptionState 为 google.SubscriptionStateActive 时订阅有效
+func (c *Client) GetSubscriptionPurchaseV2(packageName, purchaseToken string) (rsp *SubscriptionPurchaseV2, err error) {
+	if packageName == util.NULL || purchaseToken == util.NULL {
+		return nil, errors.New("packageName、purchaseToken cannot be empty")
+	}
+	uri := fmt.Sprintf(getSubscriptionPurchaseV2, url.PathEscape(packageName), url.PathEscape(purchaseToken))
+	rsp = new(SubscriptionPurchaseV2)
+	if err = c.doGoogleGet(uri, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
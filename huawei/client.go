@@ -0,0 +1,171 @@
+package huawei
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+// Client 华为应用内支付（IAP）服务端客户端
+type Client struct {
+	ClientId     string // 应用 App ID（OAuth 2.0 客户端ID）
+	ClientSecret string // 应用密钥（OAuth 2.0 客户端密钥）
+	DebugSwitch  gopay.DebugSwitch
+
+	tokenUrl        string
+	orderUrl        string
+	subscriptionUrl string
+	publicKey       *rsa.PublicKey // IAP 公钥
+
+	mu          sync.Mutex
+	accessToken string
+	expireAt    time.Time
+}
+
+// 初始化华为 IAP 客户端
+//	clientId：应用 App ID
+//	clientSecret：应用密钥
+//	site：站点，与应用所在华为帐号服务地相同，例如：huawei.SiteChina
+func NewClient(clientId, clientSecret, site string) (client *Client, err error) {
+	if clientId == util.NULL || clientSecret == util.NULL {
+		return nil, errors.New("clientId or clientSecret cannot be empty")
+	}
+	if site == util.NULL {
+		site = SiteChina
+	}
+	return &Client{
+		ClientId:        clientId,
+		ClientSecret:    clientSecret,
+		DebugSwitch:     gopay.DebugOff,
+		tokenUrl:        tokenUrl,
+		orderUrl:        fmt.Sprintf(orderUrlFormat, site),
+		subscriptionUrl: fmt.Sprintf(subscriptionUrlFormat, site),
+	}, nil
+}
+
+// 设置 IAP 公钥，用于 client.VerifySignature() 校验购买数据签名
+//	publicKey：AppGallery Connect 中获取的 IAP 公钥（base64 编码）
+func (c *Client) SetPublicKey(publicKey string) (err error) {
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return fmt.Errorf("x509.ParsePKIXPublicKey：%w", err)
+	}
+	pubKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("huawei iap public key is not rsa public key")
+	}
+	c.publicKey = pubKey
+	return nil
+}
+
+// 校验购买数据签名
+//	content：purchaseTokenData 或 inappPurchaseData
+//	sign：dataSignature
+//	signatureAlgorithm：签名算法，为空时默认 SHA256WithRSA
+func (c *Client) VerifySignature(content, sign, signatureAlgorithm string) (err error) {
+	if c.publicKey == nil {
+		return errors.New("huawei iap public key is not set, please call client.SetPublicKey() first")
+	}
+	signBytes, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	h := sha256.Sum256([]byte(content))
+	switch signatureAlgorithm {
+	case util.NULL, "SHA256WithRSA":
+		err = rsa.VerifyPKCS1v15(c.publicKey, crypto.SHA256, h[:], signBytes)
+	case "SHA256WithRSA/PSS":
+		err = rsa.VerifyPSS(c.publicKey, crypto.SHA256, h[:], signBytes, nil)
+	default:
+		return fmt.Errorf("unsupported signatureAlgorithm: %s", signatureAlgorithm)
+	}
+	if err != nil {
+		return fmt.Errorf("huawei iap verify sign failed：%w", err)
+	}
+	return nil
+}
+
+// 获取应用级 Access Token，有效期内复用，过期前 5 分钟刷新
+func (c *Client) GetAccessToken() (accessToken string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.accessToken != util.NULL && time.Now().Before(c.expireAt) {
+		return c.accessToken, nil
+	}
+	form := make(url.Values)
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", c.ClientId)
+	form.Set("client_secret", c.ClientSecret)
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeForm).Post(c.tokenUrl).SendString(form.Encode()).EndBytes()
+	if len(errs) > 0 {
+		return util.NULL, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Huawei_Token_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	rsp := new(accessTokenRsp)
+	if err = json.Unmarshal(bs, rsp); err != nil {
+		return util.NULL, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK || rsp.AccessToken == util.NULL {
+		return util.NULL, fmt.Errorf("get huawei access token error, StatusCode = %d, %s", res.StatusCode, string(bs))
+	}
+	c.accessToken = rsp.AccessToken
+	c.expireAt = time.Now().Add(time.Duration(rsp.ExpiresIn)*time.Second - 5*time.Minute)
+	return c.accessToken, nil
+}
+
+// 清除缓存的 Access Token，下次请求时重新获取
+func (c *Client) resetAccessToken() {
+	c.mu.Lock()
+	c.accessToken = util.NULL
+	c.mu.Unlock()
+}
+
+func (c *Client) doHuawei(bm gopay.BodyMap, url string, v interface{}) (err error) {
+	accessToken, err := c.GetAccessToken()
+	if err != nil {
+		return err
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Huawei_Url: %s", url)
+		xlog.Debugf("Huawei_Request: %s", bm.JsonBody())
+	}
+	httpClient := xhttp.NewClient()
+	httpClient.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("APPAT:"+accessToken)))
+	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes()
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Huawei_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		c.resetAccessToken()
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	if err = json.Unmarshal(bs, v); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package huawei
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_VerifyToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	purchaseData := `{"autoRenewing":false,"orderId":"202006231230178931e7b2b0b3.102174339","packageName":"com.example.demo","applicationId":102174339,"kind":0,"productId":"coin100","productName":"100 Coins","purchaseTime":1592886617000,"purchaseState":0,"developerPayload":"test","purchaseToken":"00000173...","consumptionState":0,"confirmed":0,"purchaseType":0,"currency":"CNY","price":100,"country":"CN","payOrderId":"sandbox2020062312301768","payType":"4"}`
+	h := sha256.Sum256([]byte(purchaseData))
+	sign, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tokenCount int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/v3/token":
+			tokenCount++
+			if r.FormValue("grant_type") != "client_credentials" || r.FormValue("client_id") != "102174339" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"AT","expires_in":3600,"token_type":"Bearer"}`))
+		case verifyToken:
+			if r.Header.Get("Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte("APPAT:AT")) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&VerifyTokenRsp{
+				ResponseCode:       ResponseCodeSuccess,
+				PurchaseTokenData:  purchaseData,
+				DataSignature:      base64.StdEncoding.EncodeToString(sign),
+				SignatureAlgorithm: "SHA256WithRSA",
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("102174339", "secret", SiteChina)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.tokenUrl, c.orderUrl = ts.URL+"/oauth2/v3/token", ts.URL
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetPublicKey(base64.StdEncoding.EncodeToString(der)); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		hwRsp, err := c.VerifyToken("00000173...", "coin100")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hwRsp.IsSuccess() {
+			t.Fatalf("VerifyToken() = %+v", hwRsp)
+		}
+		if err = c.VerifySignature(hwRsp.PurchaseTokenData, hwRsp.DataSignature, hwRsp.SignatureAlgorithm); err != nil {
+			t.Fatal(err)
+		}
+		data, err := hwRsp.PurchaseData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.ProductId != "coin100" || data.Price != 100 || data.PurchaseState != PurchaseStatePurchased || !data.IsSandbox() {
+			t.Fatalf("PurchaseData() = %+v", data)
+		}
+	}
+	if tokenCount != 1 {
+		t.Errorf("access token requested %d times, want 1", tokenCount)
+	}
+	if err = c.VerifySignature(purchaseData+" ", base64.StdEncoding.EncodeToString(sign), ""); err == nil {
+		t.Error("VerifySignature() with modified content should return error")
+	}
+}
@@ -0,0 +1,42 @@
+package huawei
+
+import (
+	"github.com/cedarwu/gopay"
+)
+
+// 校验购买 Token（Order 服务），适用于消耗型、非消耗型商品
+//	purchaseToken：购买 Token
+//	productId：商品ID
+//	hwRsp.IsSuccess() 为 true 时，hwRsp.PurchaseData() 解析购买详情，
+//	设置 IAP 公钥后可通过 client.VerifySignature(hwRsp.PurchaseTokenData, hwRsp.DataSignature, hwRsp.SignatureAlgorithm) 验签
+func (c *Client) VerifyToken(purchaseToken, productId string) (hwRsp *VerifyTokenRsp, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("purchaseToken", purchaseToken).
+		Set("productId", productId)
+	if err = bm.CheckEmptyError("purchaseToken", "productId"); err != nil {
+		return nil, err
+	}
+	hwRsp = new(VerifyTokenRsp)
+	if err = c.doHuawei(bm, c.orderUrl+verifyToken, hwRsp); err != nil {
+		return nil, err
+	}
+	return hwRsp, nil
+}
+
+// 查询订阅状态（Subscription 服务），适用于自动续期订阅商品
+//	subscriptionId：订阅ID
+//	purchaseToken：购买 Token
+//	hwRsp.IsSuccess() 为 true 时，hwRsp.PurchaseData() 解析购买详情，SubIsvalid 为 true 表示订阅有效
+func (c *Client) GetSubscription(subscriptionId, purchaseToken string) (hwRsp *SubscriptionRsp, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("subscriptionId", subscriptionId).
+		Set("purchaseToken", purchaseToken)
+	if err = bm.CheckEmptyError("subscriptionId", "purchaseToken"); err != nil {
+		return nil, err
+	}
+	hwRsp = new(SubscriptionRsp)
+	if err = c.doHuawei(bm, c.subscriptionUrl+getSubscription, hwRsp); err != nil {
+		return nil, err
+	}
+	return hwRsp, nil
+}
@@ -0,0 +1,161 @@
+package huawei
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// 获取应用级 Access Token
+	tokenUrl = "https://oauth-login.cloud.huawei.com/oauth2/v3/token"
+
+	// 订单服务、订阅服务地址，%s 为站点
+	orderUrlFormat        = "https://orders-%s.iap.hicloud.com"
+	subscriptionUrlFormat = "https://subscr-%s.iap.hicloud.com"
+
+	verifyToken     = "/applications/purchases/tokens/verify" // 校验购买 Token（消耗型、非消耗型商品）
+	getSubscription = "/sub/applications/v2/purchases/get"    // 查询订阅状态
+
+	// 站点，与应用所在华为帐号服务地相同
+	SiteChina     = "drcn" // 中国
+	SiteGermany   = "dre"  // 德国（欧洲）
+	SiteSingapore = "dra"  // 新加坡（亚非拉）
+	SiteRussia    = "drru" // 俄罗斯
+
+	ResponseCodeSuccess = "0" // 成功
+
+	// 商品类型 kind
+	KindConsumable    = 0 // 消耗型商品
+	KindNonConsumable = 1 // 非消耗型商品
+	KindSubscription  = 2 // 自动续期订阅商品
+
+	// 订单状态 purchaseState
+	PurchaseStateInit      = -1 // 初始化
+	PurchaseStatePurchased = 0  // 已购买
+	PurchaseStateCanceled  = 1  // 已取消
+	PurchaseStateRefunded  = 2  // 已撤销或已退款
+	PurchaseStatePending   = 3  // 待处理
+
+	// 消耗状态 consumptionState
+	ConsumptionStateNotConsumed = 0 // 未消耗
+	ConsumptionStateConsumed    = 1 // 已消耗
+
+	// 购买类型 purchaseType，正式购买时不返回
+	PurchaseTypeSandbox = 0 // 沙盒环境
+	PurchaseTypeTrial   = 1 // 试用
+)
+
+type accessTokenRsp struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+
+	Error            interface{} `json:"error,omitempty"`
+	SubError         interface{} `json:"sub_error,omitempty"`
+	ErrorDescription string      `json:"error_description,omitempty"`
+}
+
+// VerifyTokenRsp 校验购买 Token 响应
+type VerifyTokenRsp struct {
+	ResponseCode       string `json:"responseCode"`
+	ResponseMessage    string `json:"responseMessage,omitempty"`
+	PurchaseTokenData  string `json:"purchaseTokenData,omitempty"` // InappPurchaseData 的 JSON 字符串
+	DataSignature      string `json:"dataSignature,omitempty"`
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+}
+
+// IsSuccess responseCode = 0 时请求成功
+func (r *VerifyTokenRsp) IsSuccess() bool {
+	return r.ResponseCode == ResponseCodeSuccess
+}
+
+// PurchaseData 解析 purchaseTokenData
+func (r *VerifyTokenRsp) PurchaseData() (data *InappPurchaseData, err error) {
+	return ParsePurchaseData(r.PurchaseTokenData)
+}
+
+// SubscriptionRsp 查询订阅状态响应
+type SubscriptionRsp struct {
+	ResponseCode       string `json:"responseCode"`
+	ResponseMessage    string `json:"responseMessage,omitempty"`
+	InappPurchaseData  string `json:"inappPurchaseData,omitempty"` // InappPurchaseData 的 JSON 字符串
+	DataSignature      string `json:"dataSignature,omitempty"`
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+}
+
+// IsSuccess responseCode = 0 时请求成功
+func (r *SubscriptionRsp) IsSuccess() bool {
+	return r.ResponseCode == ResponseCodeSuccess
+}
+
+// PurchaseData 解析 inappPurchaseData
+func (r *SubscriptionRsp) PurchaseData() (data *InappPurchaseData, err error) {
+	return ParsePurchaseData(r.InappPurchaseData)
+}
+
+// InappPurchaseData 购买详情
+type InappPurchaseData struct {
+	ApplicationId        int64  `json:"applicationId,omitempty"`
+	AutoRenewing         bool   `json:"autoRenewing"`
+	OrderId              string `json:"orderId,omitempty"`
+	Kind                 int    `json:"kind"`
+	PackageName          string `json:"packageName,omitempty"`
+	ProductId            string `json:"productId,omitempty"`
+	ProductName          string `json:"productName,omitempty"`
+	PurchaseTime         int64  `json:"purchaseTime,omitempty"`
+	PurchaseState        int    `json:"purchaseState"`
+	DeveloperPayload     string `json:"developerPayload,omitempty"`
+	DeveloperChallenge   string `json:"developerChallenge,omitempty"`
+	ConsumptionState     int    `json:"consumptionState"`
+	Confirmed            int    `json:"confirmed"`
+	PurchaseToken        string `json:"purchaseToken,omitempty"`
+	PurchaseType         *int   `json:"purchaseType,omitempty"` // 正式购买时为空
+	Currency             string `json:"currency,omitempty"`
+	Price                int64  `json:"price,omitempty"` // 实际价格 * 100
+	Country              string `json:"country,omitempty"`
+	PayOrderId           string `json:"payOrderId,omitempty"`
+	PayType              string `json:"payType,omitempty"`
+	SdkChannel           string `json:"sdkChannel,omitempty"`
+	Quantity             int    `json:"quantity,omitempty"`
+	AccountFlag          int    `json:"accountFlag,omitempty"`
+	LastOrderId          string `json:"lastOrderId,omitempty"`
+	ProductGroup         string `json:"productGroup,omitempty"`
+	OriPurchaseTime      int64  `json:"oriPurchaseTime,omitempty"`
+	SubscriptionId       string `json:"subscriptionId,omitempty"`
+	OriSubscriptionId    string `json:"oriSubscriptionId,omitempty"`
+	DaysLasted           int64  `json:"daysLasted,omitempty"`
+	NumOfPeriods         int64  `json:"numOfPeriods,omitempty"`
+	NumOfDiscount        int64  `json:"numOfDiscount,omitempty"`
+	ExpirationDate       int64  `json:"expirationDate,omitempty"`
+	ExpirationIntent     int    `json:"expirationIntent,omitempty"`
+	RetryFlag            int    `json:"retryFlag,omitempty"`
+	IntroductoryFlag     int    `json:"introductoryFlag,omitempty"`
+	TrialFlag            int    `json:"trialFlag,omitempty"`
+	CancelTime           int64  `json:"cancelTime,omitempty"`
+	CancelReason         int    `json:"cancelReason,omitempty"`
+	CancelWay            int    `json:"cancelWay,omitempty"`
+	CancellationTime     int64  `json:"cancellationTime,omitempty"`
+	CancelledSubKeepDays int    `json:"cancelledSubKeepDays,omitempty"`
+	ResumeTime           int64  `json:"resumeTime,omitempty"`
+	GraceExpirationTime  int64  `json:"graceExpirationTime,omitempty"`
+	RenewStatus          int    `json:"renewStatus,omitempty"`
+	RenewPrice           int64  `json:"renewPrice,omitempty"`
+	PriceConsentStatus   int    `json:"priceConsentStatus,omitempty"`
+	SubIsvalid           bool   `json:"subIsvalid"`
+	DeferFlag            int    `json:"deferFlag,omitempty"`
+	NotifyClosed         int    `json:"notifyClosed,omitempty"`
+}
+
+// IsSandbox 是否是沙盒环境购买
+func (d *InappPurchaseData) IsSandbox() bool {
+	return d.PurchaseType != nil && *d.PurchaseType == PurchaseTypeSandbox
+}
+
+// ParsePurchaseData 解析 InappPurchaseData 的 JSON 字符串
+func ParsePurchaseData(purchaseData string) (data *InappPurchaseData, err error) {
+	data = new(InappPurchaseData)
+	if err = json.Unmarshal([]byte(purchaseData), data); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", purchaseData, err)
+	}
+	return data, nil
+}
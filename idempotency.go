@@ -0,0 +1,44 @@
+package gopay
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// IdGenerator 商户单号生成器，生成规则：前缀 + 时间（yyyyMMddHHmmss） + 4位自增序号 + 随机数字
+//	可用于生成 out_trade_no、out_refund_no、out_batch_no 等商户侧唯一单号
+//	注意：单号长度 = len(Prefix) + 18 + RandomLen，微信单号最长32位，支付宝最长64位
+//	注意：多实例部署时，请为每个实例设置不同的 Prefix，或适当增加 RandomLen
+type IdGenerator struct {
+	Prefix    string // 单号前缀，可为空
+	RandomLen int    // 随机数字位数，小于0时不添加随机数
+
+	seq uint32
+}
+
+// NewIdGenerator 初始化商户单号生成器，默认4位随机数字
+func NewIdGenerator(prefix string) (g *IdGenerator) {
+	return &IdGenerator{Prefix: prefix, RandomLen: 4}
+}
+
+// Next 生成下一个商户单号，并发安全
+func (g *IdGenerator) Next() (id string) {
+	seq := atomic.AddUint32(&g.seq, 1) % 10000
+	id = fmt.Sprintf("%s%s%04d", g.Prefix, time.Now().Format("20060102150405"), seq)
+	if g.RandomLen > 0 {
+		id += util.GetRandomNumber(g.RandomLen)
+	}
+	return id
+}
+
+// IdempotencyHook 幂等钩子，创建类请求发送前调用
+//	key：幂等键字段名，如 out_trade_no、out_refund_no、out_batch_no
+//	value：幂等键的值
+//	bm：本次请求参数
+//	调用方可在钩子中持久化幂等键及请求参数，服务崩溃重启后使用同一幂等键重试，避免重复下单、重复扣款
+//	返回 error 时中止请求，不会发送到支付平台
+type IdempotencyHook func(ctx context.Context, key, value string, bm BodyMap) (err error)
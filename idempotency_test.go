@@ -0,0 +1,35 @@
+package gopay
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestIdGenerator_Next(t *testing.T) {
+	g := NewIdGenerator("GOPAY")
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids = make(map[string]bool)
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := g.Next()
+			mu.Lock()
+			ids[id] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if len(ids) != 100 {
+		t.Fatalf("generated %d unique ids, want 100", len(ids))
+	}
+	for id := range ids {
+		if !strings.HasPrefix(id, "GOPAY") || len(id) != len("GOPAY")+18+4 {
+			t.Fatalf("unexpected id: %s", id)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package jdpay
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+	"github.com/cedarwu/gopay/pkg/xpem"
+)
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8"?>`
+
+// Client 京东支付客户端
+type Client struct {
+	Merchant    string
+	DebugSwitch gopay.DebugSwitch
+
+	desKey     []byte          // 3DES 密钥
+	privateKey *rsa.PrivateKey // 商户 RSA 私钥
+	publicKey  *rsa.PublicKey  // 京东 RSA 公钥
+}
+
+// 初始化京东支付客户端
+//	merchant：商户号
+//	desKey：商户 DES 密钥（base64 编码，解码后 24 字节）
+//	privateKey：商户 RSA 私钥文件内容（PEM）
+//	jdPublicKey：京东 RSA 公钥文件内容（PEM）
+func NewClient(merchant, desKey string, privateKey, jdPublicKey []byte) (client *Client, err error) {
+	if merchant == util.NULL {
+		return nil, errors.New("merchant cannot be empty")
+	}
+	key, err := base64.StdEncoding.DecodeString(desKey)
+	if err != nil {
+		return nil, fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	if _, err = newTripleDesCipher(key); err != nil {
+		return nil, err
+	}
+	priKey, err := xpem.DecodePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := xpem.DecodePublicKey(jdPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Merchant:    merchant,
+		DebugSwitch: gopay.DebugOff,
+		desKey:      key,
+		privateKey:  priKey,
+		publicKey:   pubKey,
+	}, nil
+}
+
+// 向京东支付发送请求，对于本库未提供的京东支付接口，可自行实现，通过此方法发送请求
+//	bm：业务参数，version、merchant 自动设置
+//	uri：接口地址，例如：/uniorder
+//	返回解密并验签后的响应报文，result.code 非 000000 且未加密时返回 *jdpay.ResultError
+func (c *Client) PostJdPayAPISelf(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	return c.doJdPay(bm, uri)
+}
+
+func (c *Client) doJdPay(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	bm.Set("version", version).Set("merchant", c.Merchant)
+	body, err := c.encryptRequest(bm)
+	if err != nil {
+		return nil, err
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("JdPay_Request: %s", bm.JsonBody())
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeXML).Post(baseUrl + uri).SendString(body).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("JdPay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	env, err := parseEnvelope(bs)
+	if err != nil {
+		return nil, err
+	}
+	// result.code 非 000000 时京东不返回 encrypt，报文未签名，仅作为错误返回
+	if env.Encrypt == util.NULL && env.Result != nil && !env.Result.IsSuccess() {
+		return nil, &ResultError{Code: env.Result.Code, Desc: env.Result.Desc}
+	}
+	return c.decryptResponse(env)
+}
+
+// encryptRequest 生成请求报文：业务报文签名后 3DES 加密，放入 <encrypt> 节点
+func (c *Client) encryptRequest(bm gopay.BodyMap) (body string, err error) {
+	plain := buildXml(bm)
+	sign, err := signXml(plain, c.privateKey)
+	if err != nil {
+		return util.NULL, fmt.Errorf("jdpay sign error: %w", err)
+	}
+	plain = plain[:len(plain)-len("</jdpay>")] + "<sign>" + sign + "</sign></jdpay>"
+	hexStr, err := tripleDesEncrypt(c.desKey, []byte(plain))
+	if err != nil {
+		return util.NULL, err
+	}
+	bs, err := xml.Marshal(&Envelope{
+		Version:  version,
+		Merchant: c.Merchant,
+		Encrypt:  base64.StdEncoding.EncodeToString([]byte(hexStr)),
+	})
+	if err != nil {
+		return util.NULL, fmt.Errorf("xml.Marshal：%w", err)
+	}
+	return xmlHeader + string(bs), nil
+}
+
+// decryptResponse 解密响应或通知报文的 <encrypt> 节点并验签，返回业务报文
+//
+//	报文必须包含 <encrypt> 且验签通过，否则返回错误
+func (c *Client) decryptResponse(env *Envelope) (plain []byte, err error) {
+	if env.Encrypt == util.NULL {
+		return nil, errors.New("jdpay encrypt : cannot be empty")
+	}
+	hexStr, err := base64.StdEncoding.DecodeString(env.Encrypt)
+	if err != nil {
+		return nil, fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	if plain, err = tripleDesDecrypt(c.desKey, string(hexStr)); err != nil {
+		return nil, err
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("JdPay_Decrypted: %s", string(plain))
+	}
+	if err = verifyXml(plain, c.publicKey); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+// parseEnvelope 解析报文外层
+func parseEnvelope(bs []byte) (env *Envelope, err error) {
+	env = new(Envelope)
+	if err = xml.Unmarshal(bs, env); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return env, nil
+}
+
+// 解析京东支付异步通知，解密并验签
+//	req：*http.Request
+//	通知必须包含 <encrypt> 且验签通过，未加密或验签失败的报文一律返回错误
+//	返回参数notify：通知内容，notify.Status 为 jdpay.StatusSuccess 时支付成功
+//	返回参数err：错误信息
+func (c *Client) ParseNotify(req *http.Request) (notify *NotifyRequest, err error) {
+	bs, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	env, err := parseEnvelope(bs)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := c.decryptResponse(env)
+	if err != nil {
+		return nil, err
+	}
+	notify = new(NotifyRequest)
+	if err = xml.Unmarshal(plain, notify); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(plain), err)
+	}
+	return notify, nil
+}
+
+// buildXml 业务参数按 key 排序生成 <jdpay> 报文，空值不参与
+func buildXml(bm gopay.BodyMap) (xmlStr string) {
+	keys := make([]string, 0, len(bm))
+	for k := range bm {
+		if bm.GetString(k) != util.NULL {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	buf.WriteString(xmlHeader)
+	buf.WriteString("<jdpay>")
+	for _, k := range keys {
+		buf.WriteString("<" + k + ">")
+		_ = xml.EscapeText(&buf, []byte(bm.GetString(k)))
+		buf.WriteString("</" + k + ">")
+	}
+	buf.WriteString("</jdpay>")
+	return buf.String()
+}
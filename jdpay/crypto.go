@@ -0,0 +1,95 @@
+package jdpay
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var signElmRegexp = regexp.MustCompile(`<sign>[^<]*</sign>`)
+
+// tripleDesEncrypt 3DES/ECB 加密，明文前加 4 字节大端长度并补 0 至 8 字节整数倍，返回十六进制字符串
+func tripleDesEncrypt(key, plain []byte) (hexStr string, err error) {
+	block, err := newTripleDesCipher(key)
+	if err != nil {
+		return "", err
+	}
+	size := 4 + len(plain)
+	if size%des.BlockSize != 0 {
+		size += des.BlockSize - size%des.BlockSize
+	}
+	data := make([]byte, size)
+	binary.BigEndian.PutUint32(data, uint32(len(plain)))
+	copy(data[4:], plain)
+	for i := 0; i < len(data); i += des.BlockSize {
+		block.Encrypt(data[i:i+des.BlockSize], data[i:i+des.BlockSize])
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// tripleDesDecrypt 解密 tripleDesEncrypt 加密的十六进制字符串
+func tripleDesDecrypt(key []byte, hexStr string) (plain []byte, err error) {
+	block, err := newTripleDesCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, fmt.Errorf("hex.DecodeString：%w", err)
+	}
+	if len(data) < des.BlockSize || len(data)%des.BlockSize != 0 {
+		return nil, errors.New("jdpay encrypt data length error")
+	}
+	for i := 0; i < len(data); i += des.BlockSize {
+		block.Decrypt(data[i:i+des.BlockSize], data[i:i+des.BlockSize])
+	}
+	n := int(binary.BigEndian.Uint32(data))
+	if n > len(data)-4 {
+		return nil, errors.New("jdpay decrypt data length error")
+	}
+	return data[4 : 4+n], nil
+}
+
+func newTripleDesCipher(key []byte) (block cipher.Block, err error) {
+	if len(key) != 24 {
+		return nil, errors.New("jdpay des key length must be 24 bytes")
+	}
+	return des.NewTripleDESCipher(key)
+}
+
+// signXml 签名：去掉 <sign> 节点后取 SHA-256 十六进制，再用商户私钥 RSA 加密，base64 编码
+func signXml(xmlStr string, privateKey *rsa.PrivateKey) (sign string, err error) {
+	digest := sha256.Sum256([]byte(signElmRegexp.ReplaceAllString(xmlStr, "")))
+	signBytes, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.Hash(0), []byte(hex.EncodeToString(digest[:])))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signBytes), nil
+}
+
+// verifyXml 验签：使用京东公钥 RSA 解密 <sign> 节点，与去掉 <sign> 节点后报文的 SHA-256 十六进制比较
+func verifyXml(xmlStr []byte, publicKey *rsa.PublicKey) (err error) {
+	m := signElmRegexp.Find(xmlStr)
+	if m == nil {
+		return errors.New("jdpay sign : cannot be empty")
+	}
+	sign, err := base64.StdEncoding.DecodeString(string(bytes.TrimSuffix(bytes.TrimPrefix(m, []byte("<sign>")), []byte("</sign>"))))
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	digest := sha256.Sum256(signElmRegexp.ReplaceAll(xmlStr, nil))
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.Hash(0), []byte(hex.EncodeToString(digest[:])), sign); err != nil {
+		return fmt.Errorf("jdpay verify sign failed：%w", err)
+	}
+	return nil
+}
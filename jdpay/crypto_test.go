@@ -0,0 +1,161 @@
+package jdpay
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestTripleDes(t *testing.T) {
+	key := []byte("ta4E/aspLA3lgFGKmNDNRYU92RkZ4w2t")[:24]
+	for _, plain := range []string{"", "jdpay", "12345678", `<?xml version="1.0" encoding="UTF-8"?><jdpay><tradeNum>京东</tradeNum></jdpay>`} {
+		hexStr, err := tripleDesEncrypt(key, []byte(plain))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hexStr)%16 != 0 {
+			t.Fatalf("tripleDesEncrypt(%q) length = %d", plain, len(hexStr))
+		}
+		got, err := tripleDesDecrypt(key, hexStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != plain {
+			t.Fatalf("tripleDesDecrypt() = %q, want %q", got, plain)
+		}
+	}
+	if _, err := tripleDesEncrypt(key[:16], []byte("jdpay")); err == nil {
+		t.Error("tripleDesEncrypt() with 16 bytes key should return error")
+	}
+}
+
+func TestClient_ParseNotify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 商户与京东使用同一对密钥，模拟京东加密签名的通知报文
+	c := &Client{
+		Merchant:   "22294531",
+		desKey:     []byte("ta4E/aspLA3lgFGKmNDNRYU9"),
+		privateKey: key,
+		publicKey:  &key.PublicKey,
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("version", version).
+		Set("merchant", c.Merchant).
+		Set("tradeNum", "TN20230101001").
+		Set("tradeType", "0").
+		Set("amount", "100").
+		Set("status", StatusSuccess)
+	body, err := c.encryptRequest(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, "<merchant>22294531</merchant><encrypt>") {
+		t.Fatalf("encryptRequest() = %s", body)
+	}
+	notify, err := c.ParseNotify(httptest.NewRequest("POST", "/notify", strings.NewReader(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notify.TradeNum != "TN20230101001" || notify.Amount != "100" || notify.Status != StatusSuccess {
+		t.Fatalf("ParseNotify() = %+v", notify)
+	}
+
+	// 篡改业务报文后验签失败
+	plain := buildXml(bm)
+	sign, err := signXml(plain, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := strings.Replace(plain, "</jdpay>", "<sign>"+sign+"</sign></jdpay>", 1)
+	if err = verifyXml([]byte(signed), &key.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	if err = verifyXml(bytes.Replace([]byte(signed), []byte("<amount>100</amount>"), []byte("<amount>1</amount>"), 1), &key.PublicKey); err == nil {
+		t.Error("verifyXml() with modified amount should return error")
+	}
+}
+
+func TestClient_ParseNotifyForged(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{
+		Merchant:   "22294531",
+		desKey:     []byte("ta4E/aspLA3lgFGKmNDNRYU9"),
+		privateKey: key,
+		publicKey:  &key.PublicKey,
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("version", version).
+		Set("merchant", c.Merchant).
+		Set("tradeNum", "TN20230101001").
+		Set("tradeType", "0").
+		Set("amount", "100").
+		Set("status", StatusSuccess)
+	// encryptBody 将业务报文 3DES 加密后放入 <encrypt>
+	encryptBody := func(plain string) string {
+		hexStr, err := tripleDesEncrypt(c.desKey, []byte(plain))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return `<jdpay><version>V2.0</version><merchant>22294531</merchant><encrypt>` + base64.StdEncoding.EncodeToString([]byte(hexStr)) + `</encrypt></jdpay>`
+	}
+	plain := buildXml(bm)
+	sign, err := signXml(plain, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSign, err := signXml(plain, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := strings.Replace(plain, "</jdpay>", "<sign>"+sign+"</sign></jdpay>", 1)
+	// 支付失败的通知，篡改为成功
+	failPlain := strings.Replace(plain, "<status>2</status>", "<status>3</status>", 1)
+	failSign, err := signXml(failPlain, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failSigned := strings.Replace(failPlain, "</jdpay>", "<sign>"+failSign+"</sign></jdpay>", 1)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"unsigned error result", `<jdpay><result><code>X</code></result><tradeNum>TN20230101001</tradeNum><amount>100</amount><status>2</status></jdpay>`},
+		{"unsigned success result", `<jdpay><result><code>000000</code></result><tradeNum>TN20230101001</tradeNum><amount>100</amount><status>2</status></jdpay>`},
+		{"encrypted without sign", encryptBody(plain)},
+		{"signed by other key", encryptBody(strings.Replace(plain, "</jdpay>", "<sign>"+otherSign+"</sign></jdpay>", 1))},
+		{"tampered amount", encryptBody(strings.Replace(signed, "<amount>100</amount>", "<amount>1</amount>", 1))},
+		{"tampered status", encryptBody(strings.Replace(failSigned, "<status>3</status>", "<status>2</status>", 1))},
+	}
+	for _, tt := range tests {
+		notify, err := c.ParseNotify(httptest.NewRequest("POST", "/notify", strings.NewReader(tt.body)))
+		if err == nil {
+			t.Errorf("%s: ParseNotify() = %+v, want error", tt.name, notify)
+		}
+	}
+
+	// 合法通知
+	notify, err := c.ParseNotify(httptest.NewRequest("POST", "/notify", strings.NewReader(encryptBody(signed))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notify.Status != StatusSuccess {
+		t.Errorf("ParseNotify() = %+v", notify)
+	}
+}
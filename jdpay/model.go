@@ -0,0 +1,121 @@
+package jdpay
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+const (
+	// URL
+	baseUrl  = "https://paygate.jd.com/service"
+	uniOrder = "/uniorder" // 统一下单
+	query    = "/query"    // 交易查询
+	refund   = "/refund"   // 退款申请
+
+	version = "V2.0"
+
+	CodeSuccess = "000000" // 成功
+
+	// 交易类型 tradeType
+	TradeTypeGen = "GEN" // 普通支付
+	TradeTypeQR  = "QR"  // 付款码支付
+
+	// 交易状态 status
+	StatusCreate     = "0" // 创建
+	StatusProcessing = "1" // 处理中
+	StatusSuccess    = "2" // 成功
+	StatusFail       = "3" // 失败
+	StatusClose      = "4" // 关闭
+)
+
+// Envelope 京东支付请求及响应报文外层
+type Envelope struct {
+	XMLName  xml.Name `xml:"jdpay"`
+	Version  string   `xml:"version"`
+	Merchant string   `xml:"merchant"`
+	Result   *Result  `xml:"result,omitempty"`
+	Encrypt  string   `xml:"encrypt"`
+}
+
+type Result struct {
+	Code string `xml:"code"`
+	Desc string `xml:"desc"`
+}
+
+// IsSuccess result.code = 000000 时请求成功
+func (r *Result) IsSuccess() bool {
+	return r != nil && r.Code == CodeSuccess
+}
+
+// ResultError 京东支付未加密的错误应答（result.code 非 000000），报文未签名
+type ResultError struct {
+	Code string
+	Desc string
+}
+
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("jdpay result error: code = %s, desc = %s", e.Code, e.Desc)
+}
+
+type UniOrderRsp struct {
+	XMLName      xml.Name `xml:"jdpay"`
+	Version      string   `xml:"version"`
+	Merchant     string   `xml:"merchant"`
+	Result       *Result  `xml:"result"`
+	OrderId      string   `xml:"orderId"`
+	MerchantName string   `xml:"merchantName"`
+	Amount       string   `xml:"amount"`
+	ExpireTime   string   `xml:"expireTime"`
+	TradeNum     string   `xml:"tradeNum"`
+	QrCode       string   `xml:"qrCode"`
+}
+
+type QueryRsp struct {
+	XMLName  xml.Name  `xml:"jdpay"`
+	Version  string    `xml:"version"`
+	Merchant string    `xml:"merchant"`
+	Result   *Result   `xml:"result"`
+	TradeNum string    `xml:"tradeNum"`
+	OrderId  string    `xml:"orderId"`
+	Amount   string    `xml:"amount"`
+	Status   string    `xml:"status"` // 取值见 Status* 常量
+	PayList  []*PayMsg `xml:"payList>pay"`
+}
+
+// IsPaid 交易是否成功
+func (r *QueryRsp) IsPaid() bool {
+	return r.Result.IsSuccess() && r.Status == StatusSuccess
+}
+
+type PayMsg struct {
+	PayType   string `xml:"payType"`
+	Amount    string `xml:"amount"`
+	Currency  string `xml:"currency"`
+	TradeTime string `xml:"tradeTime"`
+}
+
+type RefundRsp struct {
+	XMLName   xml.Name `xml:"jdpay"`
+	Version   string   `xml:"version"`
+	Merchant  string   `xml:"merchant"`
+	Result    *Result  `xml:"result"`
+	TradeNum  string   `xml:"tradeNum"`
+	OTradeNum string   `xml:"oTradeNum"`
+	Amount    string   `xml:"amount"`
+	Currency  string   `xml:"currency"`
+	TradeTime string   `xml:"tradeTime"`
+	Status    string   `xml:"status"`
+}
+
+// NotifyRequest 异步通知解密后的内容
+type NotifyRequest struct {
+	XMLName   xml.Name  `xml:"jdpay"`
+	Version   string    `xml:"version"`
+	Merchant  string    `xml:"merchant"`
+	Result    *Result   `xml:"result"`
+	TradeNum  string    `xml:"tradeNum"`
+	TradeType string    `xml:"tradeType"`
+	Amount    string    `xml:"amount"`
+	Status    string    `xml:"status"` // 取值见 Status* 常量
+	PayList   []*PayMsg `xml:"payList>pay"`
+}
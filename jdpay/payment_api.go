@@ -0,0 +1,79 @@
+package jdpay
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 统一下单
+//	必填：tradeNum、tradeName、amount（单位：分）、tradeTime（yyyyMMddHHmmss）、notifyUrl、userId
+//	currency 默认 CNY，tradeType 默认 jdpay.TradeTypeGen
+//	jdRsp.Result.IsSuccess() 为 true 时，jdRsp.OrderId 用于调起京东收银台
+func (c *Client) UniOrder(bm gopay.BodyMap) (jdRsp *UniOrderRsp, err error) {
+	err = bm.CheckEmptyError("tradeNum", "tradeName", "amount", "tradeTime", "notifyUrl", "userId")
+	if err != nil {
+		return nil, err
+	}
+	if bm.GetString("currency") == util.NULL {
+		bm.Set("currency", "CNY")
+	}
+	if bm.GetString("tradeType") == util.NULL {
+		bm.Set("tradeType", TradeTypeGen)
+	}
+	bs, err := c.doJdPay(bm, uniOrder)
+	if err != nil {
+		return nil, err
+	}
+	jdRsp = new(UniOrderRsp)
+	if err = xml.Unmarshal(bs, jdRsp); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return jdRsp, nil
+}
+
+// 交易查询
+//	tradeNum：商户订单号，退款查询时传退款单号
+//	tradeType：交易类型，0：支付，1：退款
+//	jdRsp.IsPaid() 为 true 时支付成功
+func (c *Client) Query(tradeNum, tradeType string) (jdRsp *QueryRsp, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("tradeNum", tradeNum).
+		Set("tradeType", tradeType)
+	if err = bm.CheckEmptyError("tradeNum", "tradeType"); err != nil {
+		return nil, err
+	}
+	bs, err := c.doJdPay(bm, query)
+	if err != nil {
+		return nil, err
+	}
+	jdRsp = new(QueryRsp)
+	if err = xml.Unmarshal(bs, jdRsp); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return jdRsp, nil
+}
+
+// 退款申请
+//	必填：tradeNum（退款单号）、oTradeNum（原交易订单号）、amount（单位：分）、tradeTime（yyyyMMddHHmmss）
+//	currency 默认 CNY，退款结果可通过 client.Query(tradeNum, "1") 查询
+func (c *Client) Refund(bm gopay.BodyMap) (jdRsp *RefundRsp, err error) {
+	err = bm.CheckEmptyError("tradeNum", "oTradeNum", "amount", "tradeTime")
+	if err != nil {
+		return nil, err
+	}
+	if bm.GetString("currency") == util.NULL {
+		bm.Set("currency", "CNY")
+	}
+	bs, err := c.doJdPay(bm, refund)
+	if err != nil {
+		return nil, err
+	}
+	jdRsp = new(RefundRsp)
+	if err = xml.Unmarshal(bs, jdRsp); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return jdRsp, nil
+}
@@ -0,0 +1,140 @@
+package lakala
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/sm2"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+	"github.com/cedarwu/gopay/pkg/xpem"
+)
+
+// Client 拉卡拉开放平台客户端
+type Client struct {
+	Appid       string
+	SerialNo    string // 商户证书序列号
+	IsProd      bool
+	DebugSwitch gopay.DebugSwitch
+
+	signType     string
+	privateKey   *rsa.PrivateKey
+	smPrivateKey *sm2.PrivateKey
+	publicKey    *rsa.PublicKey // 拉卡拉平台证书公钥
+	smPublicKey  *sm2.PublicKey
+	apiUrl       string // 非空时替换正式、测试环境地址
+}
+
+// 初始化拉卡拉开放平台客户端（RSA 签名）
+//	appid：开放平台分配的 appid
+//	serialNo：商户证书序列号
+//	privateKey：商户 RSA 私钥文件内容（PEM）
+//	isProd：是否是正式环境，false 时请求测试环境 test.wsmsd.cn
+func NewClient(appid, serialNo string, privateKey []byte, isProd bool) (client *Client, err error) {
+	if appid == util.NULL || serialNo == util.NULL {
+		return nil, errors.New("appid or serialNo cannot be empty")
+	}
+	key, err := xpem.DecodePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Appid:       appid,
+		SerialNo:    serialNo,
+		IsProd:      isProd,
+		DebugSwitch: gopay.DebugOff,
+		signType:    RSA,
+		privateKey:  key,
+	}, nil
+}
+
+// 初始化拉卡拉开放平台客户端（国密 SM2 签名）
+//	appid：开放平台分配的 appid
+//	serialNo：商户证书序列号
+//	privateKey：商户 SM2 私钥文件内容（PEM）
+//	isProd：是否是正式环境
+func NewClientSM(appid, serialNo string, privateKey []byte, isProd bool) (client *Client, err error) {
+	if appid == util.NULL || serialNo == util.NULL {
+		return nil, errors.New("appid or serialNo cannot be empty")
+	}
+	key, err := sm2.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Appid:        appid,
+		SerialNo:     serialNo,
+		IsProd:       isProd,
+		DebugSwitch:  gopay.DebugOff,
+		signType:     SM2,
+		smPrivateKey: key,
+	}, nil
+}
+
+// 设置拉卡拉平台证书，设置后自动验签同步响应，client.VerifyNotify() 验签异步通知
+//	certContent：拉卡拉平台证书文件内容（PEM），签名方式与客户端一致
+func (c *Client) SetPlatformCert(certContent []byte) (err error) {
+	if c.signType == SM2 {
+		c.smPublicKey, _, err = sm2.ParsePublicKey(certContent)
+		return err
+	}
+	c.publicKey, err = xpem.DecodePublicKey(certContent)
+	return err
+}
+
+// 向拉卡拉发送请求，对于本库未提供的拉卡拉接口，可自行实现，通过此方法发送请求
+//	bm：请求参数 req_data 的BodyMap
+//	uri：接口地址，例如：/api/v3/labs/trans/preorder
+func (c *Client) PostLakalaAPISelf(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	return c.doLakala(bm, uri)
+}
+
+func (c *Client) doLakala(bm gopay.BodyMap, uri string) (bs []byte, err error) {
+	var url = baseUrlProd + uri
+	if !c.IsProd {
+		url = baseUrlSandbox + uri
+	}
+	if c.apiUrl != util.NULL {
+		url = c.apiUrl + uri
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"req_time": time.Now().Format("20060102150405"),
+		"version":  version,
+		"req_data": bm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal：%w", err)
+	}
+	authorization, err := c.authorization(body)
+	if err != nil {
+		return nil, err
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Lakala_Request: %s", string(body))
+		xlog.Debugf("Lakala_Authorization: %s", authorization)
+	}
+	httpClient := xhttp.NewClient()
+	httpClient.Header.Add("Authorization", authorization)
+	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendString(string(body)).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Lakala_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	if c.publicKey != nil || c.smPublicKey != nil {
+		if err = c.verifyResponse(res.Header, bs); err != nil {
+			return nil, err
+		}
+	}
+	return bs, nil
+}
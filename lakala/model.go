@@ -0,0 +1,124 @@
+package lakala
+
+import "encoding/json"
+
+const (
+	// URL
+	baseUrlProd    = "https://s2.lakala.com"       // 正式 URL
+	baseUrlSandbox = "https://test.wsmsd.cn/sit"   // 测试 URL
+	preOrder       = "/api/v3/labs/trans/preorder" // 聚合主扫（预下单）
+	tradeQuery     = "/api/v3/labs/query/tradequery"
+	refund         = "/api/v3/labs/relation/refund"
+
+	version = "3.0"
+
+	// 签名方式
+	RSA = "RSA" // SHA256withRSA
+	SM2 = "SM2" // SM3withSM2
+
+	authSchemaRSA = "LKLAPI-SHA256withRSA"
+	authSchemaSM2 = "LKLAPI-SM3withSM2"
+
+	CodeSuccess = "BBS00000" // 成功
+
+	// 钱包类型 account_type
+	AccountTypeWechat   = "WECHAT"
+	AccountTypeAlipay   = "ALIPAY"
+	AccountTypeUnionPay = "UQRCODEPAY"
+
+	// 接入方式 trans_type
+	TransTypeNative = "41" // NATIVE（扫码）
+	TransTypeJSAPI  = "51" // JSAPI（公众号、服务窗）
+	TransTypeMini   = "71" // 微信小程序
+
+	// 交易状态 trade_state
+	TradeStateInit       = "INIT"        // 初始化
+	TradeStateCreate     = "CREATE"      // 下单成功
+	TradeStateSuccess    = "SUCCESS"     // 交易成功
+	TradeStateFail       = "FAIL"        // 交易失败
+	TradeStateDeal       = "DEAL"        // 交易处理中
+	TradeStateUnknown    = "UNKNOWN"     // 未知状态
+	TradeStateClose      = "CLOSE"       // 订单关闭
+	TradeStatePartRefund = "PART_REFUND" // 部分退款
+	TradeStateRefund     = "REFUND"      // 全部退款
+)
+
+// ErrorInfo 拉卡拉接口公共响应参数
+type ErrorInfo struct {
+	Code     string `json:"code"`
+	Msg      string `json:"msg"`
+	RespTime string `json:"resp_time"`
+}
+
+// IsSuccess code = BBS00000 时请求成功
+func (e *ErrorInfo) IsSuccess() bool {
+	return e.Code == CodeSuccess
+}
+
+type PreOrderRsp struct {
+	ErrorInfo
+	RespData *PreOrder `json:"resp_data,omitempty"`
+}
+
+type PreOrder struct {
+	MerchantNo    string          `json:"merchant_no"`
+	OutTradeNo    string          `json:"out_trade_no"`
+	TradeNo       string          `json:"trade_no"`
+	LogNo         string          `json:"log_no"`
+	AccRespFields json.RawMessage `json:"acc_resp_fields,omitempty"` // 钱包返回字段，NATIVE 为 code（二维码链接），JSAPI、小程序为调起支付参数
+}
+
+type TradeQueryRsp struct {
+	ErrorInfo
+	RespData *TradeQuery `json:"resp_data,omitempty"`
+}
+
+type TradeQuery struct {
+	MerchantNo      string `json:"merchant_no"`
+	OutTradeNo      string `json:"out_trade_no"`
+	TradeNo         string `json:"trade_no"`
+	LogNo           string `json:"log_no"`
+	AccTradeNo      string `json:"acc_trade_no"`
+	AccountType     string `json:"account_type"`
+	TradeState      string `json:"trade_state"` // 取值见 TradeState* 常量
+	TradeStateDesc  string `json:"trade_state_desc"`
+	TotalAmount     string `json:"total_amount"`
+	PayerAmount     string `json:"payer_amount"`
+	AccSettleAmount string `json:"acc_settle_amount"`
+	TradeTime       string `json:"trade_time"`
+	UserId1         string `json:"user_id1"`
+	UserId2         string `json:"user_id2"`
+	BankType        string `json:"bank_type"`
+	CardType        string `json:"card_type"`
+	Remark          string `json:"remark"`
+}
+
+// IsPaid 交易是否成功
+func (t *TradeQuery) IsPaid() bool {
+	return t.TradeState == TradeStateSuccess
+}
+
+type RefundRsp struct {
+	ErrorInfo
+	RespData *Refund `json:"resp_data,omitempty"`
+}
+
+type Refund struct {
+	MerchantNo   string `json:"merchant_no"`
+	OutTradeNo   string `json:"out_trade_no"`
+	TradeNo      string `json:"trade_no"`
+	LogNo        string `json:"log_no"`
+	AccTradeNo   string `json:"acc_trade_no"`
+	AccountType  string `json:"account_type"`
+	TotalAmount  string `json:"total_amount"`
+	RefundAmount string `json:"refund_amount"`
+	PayerAmount  string `json:"payer_amount"`
+	TradeTime    string `json:"trade_time"`
+	TradeState   string `json:"trade_state"`
+}
+
+// NotifyResponse 异步通知响应
+type NotifyResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
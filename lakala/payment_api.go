@@ -0,0 +1,68 @@
+package lakala
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 聚合主扫（预下单）
+//	必填：merchant_no、term_no、out_trade_no、account_type、trans_type、total_amount（单位：分）、location_info
+//	NATIVE 返回 acc_resp_fields.code 二维码链接，JSAPI、小程序返回调起支付参数
+func (c *Client) PreOrder(bm gopay.BodyMap) (lklRsp *PreOrderRsp, err error) {
+	err = bm.CheckEmptyError("merchant_no", "term_no", "out_trade_no", "account_type", "trans_type", "total_amount", "location_info")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doLakala(bm, preOrder)
+	if err != nil {
+		return nil, err
+	}
+	lklRsp = new(PreOrderRsp)
+	if err = json.Unmarshal(bs, lklRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return lklRsp, nil
+}
+
+// 聚合扫码交易查询
+//	必填：merchant_no、term_no，out_trade_no 与 trade_no 二选一
+func (c *Client) TradeQuery(bm gopay.BodyMap) (lklRsp *TradeQueryRsp, err error) {
+	err = bm.CheckEmptyError("merchant_no", "term_no")
+	if err != nil {
+		return nil, err
+	}
+	if bm.GetString("out_trade_no") == gopay.NULL && bm.GetString("trade_no") == gopay.NULL {
+		return nil, errors.New("out_trade_no and trade_no : cannot be empty at the same time")
+	}
+	bs, err := c.doLakala(bm, tradeQuery)
+	if err != nil {
+		return nil, err
+	}
+	lklRsp = new(TradeQueryRsp)
+	if err = json.Unmarshal(bs, lklRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return lklRsp, nil
+}
+
+// 聚合扫码退款
+//	必填：merchant_no、term_no、out_trade_no（退款订单号）、refund_amount（单位：分）、location_info
+//	原交易通过 origin_out_trade_no、origin_trade_no 等指定
+func (c *Client) Refund(bm gopay.BodyMap) (lklRsp *RefundRsp, err error) {
+	err = bm.CheckEmptyError("merchant_no", "term_no", "out_trade_no", "refund_amount", "location_info")
+	if err != nil {
+		return nil, err
+	}
+	bs, err := c.doLakala(bm, refund)
+	if err != nil {
+		return nil, err
+	}
+	lklRsp = new(RefundRsp)
+	if err = json.Unmarshal(bs, lklRsp); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return lklRsp, nil
+}
@@ -0,0 +1,181 @@
+package lakala
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+// newTestServer 模拟拉卡拉开放平台：校验请求路径及 Authorization 签名，返回 platform 签名后的 respData
+func newTestServer(t *testing.T, c *Client, platform *rsa.PrivateKey, path string, respData func(req gopay.BodyMap) gopay.BodyMap) *httptest.Server {
+	var (
+		merchant = &Client{signType: RSA, publicKey: &c.privateKey.PublicKey}
+		signer   = &Client{signType: RSA, privateKey: platform}
+	)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		params := parseAuthorization(r.Header.Get("Authorization"))
+		signStr := params["appid"] + "\n" + params["serial_no"] + "\n" + params["timestamp"] + "\n" + params["nonce_str"] + "\n" + string(body) + "\n"
+		if err := merchant.verify(signStr, params["signature"]); err != nil || r.URL.Path != path || params["appid"] != c.Appid || params["serial_no"] != c.SerialNo {
+			t.Errorf("path = %s, authorization = %s, err = %v", r.URL.Path, r.Header.Get("Authorization"), err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		req := make(gopay.BodyMap)
+		if err := json.Unmarshal(body, &req); err != nil || req.GetString("version") != version {
+			t.Errorf("request body = %s, err = %v", body, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reqData := make(gopay.BodyMap)
+		_ = json.Unmarshal([]byte(req.GetString("req_data")), &reqData)
+		rsp := make(gopay.BodyMap)
+		rsp.Set("code", CodeSuccess).
+			Set("msg", "成功").
+			Set("resp_time", "20221101143522").
+			Set("resp_data", respData(reqData))
+		bs := []byte(rsp.JsonBody())
+		sign, err := signer.sign(c.Appid + "\n01\n1667284522\n7e7bcf9a\n" + string(bs) + "\n")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		w.Header().Set("Lklapi-Appid", c.Appid)
+		w.Header().Set("Lklapi-Serial", "01")
+		w.Header().Set("Lklapi-Timestamp", "1667284522")
+		w.Header().Set("Lklapi-Nonce", "7e7bcf9a")
+		w.Header().Set("Lklapi-Signature", sign)
+		_, _ = w.Write(bs)
+	}))
+}
+
+// newTestClient 返回商户客户端及模拟的拉卡拉平台私钥
+func newTestClient(t *testing.T) (c *Client, platform *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	platform, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = &Client{Appid: "OP00000003", SerialNo: "00dfba8194c41b84cf", DebugSwitch: gopay.DebugOff, signType: RSA, privateKey: key, publicKey: &platform.PublicKey}
+	return c, platform
+}
+
+func TestClient_PreOrder(t *testing.T) {
+	c, platform := newTestClient(t)
+	ts := newTestServer(t, c, platform, preOrder, func(req gopay.BodyMap) gopay.BodyMap {
+		rsp := make(gopay.BodyMap)
+		rsp.Set("merchant_no", req.GetString("merchant_no")).
+			Set("out_trade_no", req.GetString("out_trade_no")).
+			Set("trade_no", "2022110166210311390388").
+			Set("log_no", "66210311390388").
+			Set("acc_resp_fields", map[string]string{"code": "https://qr.alipay.com/bax03431ljhokirwl38f00a7"})
+		return rsp
+	})
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("merchant_no", "8222900701107M5").
+		Set("term_no", "A1234567").
+		Set("out_trade_no", "GOPAY20221101001").
+		Set("account_type", AccountTypeAlipay).
+		Set("trans_type", TransTypeNative).
+		Set("total_amount", "1").
+		Set("location_info", map[string]string{"request_ip": "127.0.0.1"})
+	lklRsp, err := c.PreOrder(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lklRsp.IsSuccess() || lklRsp.RespData == nil || lklRsp.RespData.OutTradeNo != "GOPAY20221101001" || lklRsp.RespData.TradeNo != "2022110166210311390388" {
+		t.Fatalf("PreOrder() = %+v", lklRsp)
+	}
+	fields := make(map[string]string)
+	if err = json.Unmarshal(lklRsp.RespData.AccRespFields, &fields); err != nil || fields["code"] == "" {
+		t.Errorf("AccRespFields = %s, err = %v", lklRsp.RespData.AccRespFields, err)
+	}
+	if _, err = c.PreOrder(make(gopay.BodyMap)); err == nil {
+		t.Error("PreOrder() without required params should return error")
+	}
+}
+
+func TestClient_TradeQuery(t *testing.T) {
+	c, platform := newTestClient(t)
+	ts := newTestServer(t, c, platform, tradeQuery, func(req gopay.BodyMap) gopay.BodyMap {
+		rsp := make(gopay.BodyMap)
+		rsp.Set("merchant_no", req.GetString("merchant_no")).
+			Set("out_trade_no", req.GetString("out_trade_no")).
+			Set("trade_no", "2022110166210311390388").
+			Set("account_type", AccountTypeAlipay).
+			Set("trade_state", TradeStateSuccess).
+			Set("total_amount", "1").
+			Set("trade_time", "20221101143522")
+		return rsp
+	})
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("merchant_no", "8222900701107M5").
+		Set("term_no", "A1234567")
+	if _, err := c.TradeQuery(bm); err == nil {
+		t.Error("TradeQuery() without out_trade_no and trade_no should return error")
+	}
+	bm.Set("out_trade_no", "GOPAY20221101001")
+	lklRsp, err := c.TradeQuery(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lklRsp.IsSuccess() || lklRsp.RespData == nil || !lklRsp.RespData.IsPaid() || lklRsp.RespData.TotalAmount != "1" {
+		t.Errorf("TradeQuery() = %+v", lklRsp)
+	}
+
+	// 同步响应不是拉卡拉平台签名
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.publicKey = &other.PublicKey
+	if _, err = c.TradeQuery(bm); err == nil {
+		t.Error("TradeQuery() with forged response signature should return error")
+	}
+}
+
+func TestClient_Refund(t *testing.T) {
+	c, platform := newTestClient(t)
+	ts := newTestServer(t, c, platform, refund, func(req gopay.BodyMap) gopay.BodyMap {
+		rsp := make(gopay.BodyMap)
+		rsp.Set("merchant_no", req.GetString("merchant_no")).
+			Set("out_trade_no", req.GetString("out_trade_no")).
+			Set("trade_no", "2022110166210311390399").
+			Set("total_amount", "1").
+			Set("refund_amount", req.GetString("refund_amount")).
+			Set("trade_state", TradeStateSuccess)
+		return rsp
+	})
+	defer ts.Close()
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("merchant_no", "8222900701107M5").
+		Set("term_no", "A1234567").
+		Set("out_trade_no", "GOPAY20221101001R").
+		Set("refund_amount", "1").
+		Set("origin_out_trade_no", "GOPAY20221101001").
+		Set("location_info", map[string]string{"request_ip": "127.0.0.1"})
+	lklRsp, err := c.Refund(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lklRsp.IsSuccess() || lklRsp.RespData == nil || lklRsp.RespData.OutTradeNo != "GOPAY20221101001R" || lklRsp.RespData.RefundAmount != "1" {
+		t.Errorf("Refund() = %+v", lklRsp)
+	}
+}
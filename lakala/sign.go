@@ -0,0 +1,126 @@
+package lakala
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cedarwu/gopay/pkg/sm2"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// authorization 生成请求头 Authorization
+//	签名原文：appid\nserial_no\ntimestamp\nnonce_str\nbody\n
+func (c *Client) authorization(body []byte) (authorization string, err error) {
+	var (
+		timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+		nonceStr  = util.GetRandomString(32)
+	)
+	sign, err := c.sign(c.Appid + "\n" + c.SerialNo + "\n" + timestamp + "\n" + nonceStr + "\n" + string(body) + "\n")
+	if err != nil {
+		return util.NULL, err
+	}
+	schema := authSchemaRSA
+	if c.signType == SM2 {
+		schema = authSchemaSM2
+	}
+	return fmt.Sprintf(`%s appid="%s",serial_no="%s",timestamp="%s",nonce_str="%s",signature="%s"`, schema, c.Appid, c.SerialNo, timestamp, nonceStr, sign), nil
+}
+
+func (c *Client) sign(signStr string) (sign string, err error) {
+	var signBytes []byte
+	if c.signType == SM2 {
+		if c.smPrivateKey == nil {
+			return util.NULL, errors.New("sm2 private key is nil")
+		}
+		signBytes, err = sm2.Sign(c.smPrivateKey, nil, []byte(signStr))
+	} else {
+		if c.privateKey == nil {
+			return util.NULL, errors.New("rsa private key is nil")
+		}
+		h := sha256.Sum256([]byte(signStr))
+		signBytes, err = rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, h[:])
+	}
+	if err != nil {
+		return util.NULL, err
+	}
+	return base64.StdEncoding.EncodeToString(signBytes), nil
+}
+
+func (c *Client) verify(signStr, sign string) (err error) {
+	signBytes, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	if c.signType == SM2 {
+		if c.smPublicKey == nil {
+			return errors.New("lakala platform cert is not set, please call client.SetPlatformCert() first")
+		}
+		if !sm2.Verify(c.smPublicKey, nil, []byte(signStr), signBytes) {
+			return errors.New("lakala verify sign failed")
+		}
+		return nil
+	}
+	if c.publicKey == nil {
+		return errors.New("lakala platform cert is not set, please call client.SetPlatformCert() first")
+	}
+	h := sha256.Sum256([]byte(signStr))
+	if err = rsa.VerifyPKCS1v15(c.publicKey, crypto.SHA256, h[:], signBytes); err != nil {
+		return fmt.Errorf("lakala verify sign failed：%w", err)
+	}
+	return nil
+}
+
+// verifyResponse 同步响应验签
+//	签名原文：appid\nserial\ntimestamp\nnonce\nbody\n，取自响应头 Lklapi-*
+func (c *Client) verifyResponse(header http.Header, body []byte) (err error) {
+	signStr := header.Get("Lklapi-Appid") + "\n" +
+		header.Get("Lklapi-Serial") + "\n" +
+		header.Get("Lklapi-Timestamp") + "\n" +
+		header.Get("Lklapi-Nonce") + "\n" +
+		string(body) + "\n"
+	return c.verify(signStr, header.Get("Lklapi-Signature"))
+}
+
+// 拉卡拉异步通知验签
+//	req：*http.Request，读取后 req.Body 不可再次读取
+//	验签通过后返回通知请求体，可 json.Unmarshal 到对应结构体
+//	请求头 Authorization 形如：LKLAPI-SHA256withRSA timestamp="",nonce_str="",signature=""，签名原文：timestamp\nnonce_str\nbody\n
+func (c *Client) VerifyNotify(req *http.Request) (body []byte, err error) {
+	body, err = ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	defer req.Body.Close()
+	params := parseAuthorization(req.Header.Get("Authorization"))
+	if params["signature"] == util.NULL {
+		return nil, errors.New("notify authorization signature : cannot be empty")
+	}
+	if err = c.verify(params["timestamp"]+"\n"+params["nonce_str"]+"\n"+string(body)+"\n", params["signature"]); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// parseAuthorization 解析 Authorization 中的 key="value" 参数
+func parseAuthorization(authorization string) (params map[string]string) {
+	params = make(map[string]string)
+	if i := strings.IndexByte(authorization, ' '); i >= 0 {
+		authorization = authorization[i+1:]
+	}
+	for _, kv := range strings.Split(authorization, ",") {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			params[strings.TrimSpace(kv[:i])] = strings.Trim(strings.TrimSpace(kv[i+1:]), `"`)
+		}
+	}
+	return params
+}
@@ -0,0 +1,66 @@
+package lakala
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay/pkg/sm2"
+)
+
+func TestClient_VerifyNotify(t *testing.T) {
+	body := `{"merchant_no":"8222900701107M5","out_trade_no":"FD660E1FAA3A4470933CDEDAE1EC1D8E","trade_no":"2021111866210311390388","trade_status":"SUCCESS","total_amount":"1"}`
+	newReq := func(c *Client, timestamp, nonce, signBody string) *http.Request {
+		sign, err := c.sign(timestamp + "\n" + nonce + "\n" + signBody + "\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(http.MethodPost, "/lakala/notify", strings.NewReader(body))
+		req.Header.Set("Authorization", authSchemaRSA+` timestamp="`+timestamp+`",nonce_str="`+nonce+`",signature="`+sign+`"`)
+		return req
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 使用同一密钥对模拟拉卡拉签名
+	c := &Client{Appid: "OP00000003", SerialNo: "00dfba8194c41b84cf", signType: RSA, privateKey: key, publicKey: &key.PublicKey}
+	bs, err := c.VerifyNotify(newReq(c, "1637230337", "7e7bcf9a", body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bs) != body {
+		t.Errorf("VerifyNotify() = %s", bs)
+	}
+	if _, err = c.VerifyNotify(newReq(c, "1637230337", "7e7bcf9a", body+" ")); err == nil {
+		t.Error("VerifyNotify() with modified body should return error")
+	}
+
+	// 国密
+	smKey, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smc := &Client{Appid: "OP00000003", SerialNo: "00dfba8194c41b84cf", signType: SM2, smPrivateKey: smKey, smPublicKey: &smKey.PublicKey}
+	if _, err = smc.VerifyNotify(newReq(smc, "1637230337", "7e7bcf9a", body)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 同步响应
+	header := make(http.Header)
+	header.Set("Lklapi-Appid", c.Appid)
+	header.Set("Lklapi-Serial", "01")
+	header.Set("Lklapi-Timestamp", "1637230337")
+	header.Set("Lklapi-Nonce", "7e7bcf9a")
+	sign, _ := c.sign(c.Appid + "\n01\n1637230337\n7e7bcf9a\n" + body + "\n")
+	header.Set("Lklapi-Signature", sign)
+	if err = c.verifyResponse(header, []byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.authorization([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+}
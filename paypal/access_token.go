@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/xhttp"
@@ -50,8 +51,45 @@ func (c *Client) GetAccessToken() (token *AccessToken, err error) {
 	if err = json.Unmarshal(bs, token); err != nil {
 		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
 	}
+	c.mu.Lock()
 	c.Appid = token.Appid
 	c.AccessToken = token.AccessToken
 	c.ExpiresIn = token.ExpiresIn
+	c.expireAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	c.mu.Unlock()
 	return token, nil
 }
+
+// ensureAccessToken 获取有效的 AccessToken，即将过期（提前 tokenRefreshAhead）或已失效时自动刷新
+func (c *Client) ensureAccessToken() (accessToken string, err error) {
+	if accessToken, ok := c.validAccessToken(); ok {
+		return accessToken, nil
+	}
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	// 等待锁期间可能已被其他请求刷新
+	if accessToken, ok := c.validAccessToken(); ok {
+		return accessToken, nil
+	}
+	token, err := c.GetAccessToken()
+	if err != nil {
+		return "", fmt.Errorf("refresh access token：%w", err)
+	}
+	return token.AccessToken, nil
+}
+
+func (c *Client) validAccessToken() (accessToken string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.AccessToken == "" || time.Now().Add(tokenRefreshAhead).After(c.expireAt) {
+		return "", false
+	}
+	return c.AccessToken, true
+}
+
+// expireAccessToken 请求返回 401 时标记 AccessToken 失效，下次请求重新获取
+func (c *Client) expireAccessToken() {
+	c.mu.Lock()
+	c.expireAt = time.Time{}
+	c.mu.Unlock()
+}
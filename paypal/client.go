@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/xhttp"
@@ -19,6 +21,10 @@ type Client struct {
 	ExpiresIn   int
 	IsProd      bool
 	DebugSwitch gopay.DebugSwitch
+
+	mu        sync.RWMutex
+	refreshMu sync.Mutex
+	expireAt  time.Time // AccessToken 过期时间
 }
 
 // NewClient 初始化PayPal支付客户端
@@ -41,8 +47,12 @@ func (c *Client) doPayPalGet(ctx context.Context, uri string) (res *http.Respons
 	if !c.IsProd {
 		url = baseUrlSandbox + uri
 	}
+	accessToken, err := c.ensureAccessToken()
+	if err != nil {
+		return nil, nil, err
+	}
 	httpClient := xhttp.NewClient()
-	authHeader := AuthorizationPrefixBearer + c.AccessToken
+	authHeader := AuthorizationPrefixBearer + accessToken
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("PayPal_Url: %s", url)
 		xlog.Debugf("PayPal_Authorization: %s", authHeader)
@@ -57,6 +67,9 @@ func (c *Client) doPayPalGet(ctx context.Context, uri string) (res *http.Respons
 		xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
 		xlog.Debugf("PayPal_Headers: %#v", res.Header)
 	}
+	if res.StatusCode == http.StatusUnauthorized {
+		c.expireAccessToken()
+	}
 	return res, bs, nil
 }
 
@@ -65,8 +78,12 @@ func (c *Client) doPayPalPost(ctx context.Context, bm gopay.BodyMap, path string
 	if !c.IsProd {
 		url = baseUrlSandbox + path
 	}
+	accessToken, err := c.ensureAccessToken()
+	if err != nil {
+		return nil, nil, err
+	}
 	httpClient := xhttp.NewClient()
-	authHeader := AuthorizationPrefixBearer + c.AccessToken
+	authHeader := AuthorizationPrefixBearer + accessToken
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("PayPal_RequestBody: %s", bm.JsonBody())
 		xlog.Debugf("PayPal_Authorization: %s", authHeader)
@@ -81,6 +98,9 @@ func (c *Client) doPayPalPost(ctx context.Context, bm gopay.BodyMap, path string
 		xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
 		xlog.Debugf("PayPal_Headers: %#v", res.Header)
 	}
+	if res.StatusCode == http.StatusUnauthorized {
+		c.expireAccessToken()
+	}
 	return res, bs, nil
 }
 
@@ -89,8 +109,12 @@ func (c *Client) doPayPalPatch(ctx context.Context, patchs []*Patch, path string
 	if !c.IsProd {
 		url = baseUrlSandbox + path
 	}
+	accessToken, err := c.ensureAccessToken()
+	if err != nil {
+		return nil, nil, err
+	}
 	httpClient := xhttp.NewClient()
-	authHeader := AuthorizationPrefixBearer + c.AccessToken
+	authHeader := AuthorizationPrefixBearer + accessToken
 	if c.DebugSwitch == gopay.DebugOn {
 		jb, _ := json.Marshal(patchs)
 		xlog.Debugf("PayPal_RequestBody: %s", string(jb))
@@ -106,5 +130,8 @@ func (c *Client) doPayPalPatch(ctx context.Context, patchs []*Patch, path string
 		xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
 		xlog.Debugf("PayPal_Headers: %#v", res.Header)
 	}
+	if res.StatusCode == http.StatusUnauthorized {
+		c.expireAccessToken()
+	}
 	return res, bs, nil
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/xlog"
@@ -39,3 +40,22 @@ func TestBasicAuth(t *testing.T) {
 	auth := base64.StdEncoding.EncodeToString([]byte(uname + ":" + passwd))
 	xlog.Debugf("Basic %s", auth)
 }
+
+func TestClient_EnsureAccessToken(t *testing.T) {
+	c := &Client{AccessToken: "A21AAFEpH4PsADK7qSS7pSRsgzfENtu", expireAt: time.Now().Add(time.Hour)}
+	token, err := c.ensureAccessToken()
+	if err != nil || token != "A21AAFEpH4PsADK7qSS7pSRsgzfENtu" {
+		t.Fatalf("ensureAccessToken() = %s, %v", token, err)
+	}
+	// 即将过期，需要刷新
+	c.expireAt = time.Now().Add(time.Minute)
+	if _, ok := c.validAccessToken(); ok {
+		t.Error("validAccessToken() should be false when token is about to expire")
+	}
+	// 401 后标记失效
+	c.expireAt = time.Now().Add(time.Hour)
+	c.expireAccessToken()
+	if _, ok := c.validAccessToken(); ok {
+		t.Error("validAccessToken() should be false after expireAccessToken()")
+	}
+}
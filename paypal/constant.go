@@ -1,5 +1,7 @@
 package paypal
 
+import "time"
+
 const (
 	Success = 0
 
@@ -7,9 +9,25 @@ const (
 	AuthorizationPrefixBasic  = "Basic "
 	AuthorizationPrefixBearer = "Bearer "
 
+	// Webhook 通知请求头
+	HeaderTransmissionId   = "PAYPAL-TRANSMISSION-ID"
+	HeaderTransmissionTime = "PAYPAL-TRANSMISSION-TIME"
+	HeaderTransmissionSig  = "PAYPAL-TRANSMISSION-SIG"
+	HeaderCertUrl          = "PAYPAL-CERT-URL"
+	HeaderAuthAlgo         = "PAYPAL-AUTH-ALGO"
+
+	// Webhook 支付捕获事件 event_type
+	EventPaymentCaptureCompleted = "PAYMENT.CAPTURE.COMPLETED"
+	EventPaymentCaptureDenied    = "PAYMENT.CAPTURE.DENIED"
+	EventPaymentCapturePending   = "PAYMENT.CAPTURE.PENDING"
+	EventPaymentCaptureRefunded  = "PAYMENT.CAPTURE.REFUNDED"
+	EventPaymentCaptureReversed  = "PAYMENT.CAPTURE.REVERSED"
+
 	baseUrlProd    = "https://api-m.paypal.com"         // 正式 URL
 	baseUrlSandbox = "https://api-m.sandbox.paypal.com" // 沙箱 URL
 
+	tokenRefreshAhead = 5 * time.Minute // AccessToken 提前刷新时间
+
 	// 获取AccessToken
 	getAccessToken = "/v1/oauth2/token" // 获取AccessToken POST
 
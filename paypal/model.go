@@ -1,5 +1,10 @@
 package paypal
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type AccessToken struct {
 	Scope       string `json:"scope"`
 	AccessToken string `json:"access_token"`
@@ -17,6 +22,14 @@ type ErrorResponse struct {
 	Links   []Link        `json:"links,omitempty"`
 }
 
+// Error 实现 error 接口，可将 ppRsp.ErrorResponse 直接作为 error 返回
+func (e *ErrorResponse) Error() string {
+	if len(e.Details) > 0 {
+		return fmt.Sprintf("%s: %s (issue: %s, debug_id: %s)", e.Name, e.Message, e.Details[0].Issue, e.DebugId)
+	}
+	return fmt.Sprintf("%s: %s (debug_id: %s)", e.Name, e.Message, e.DebugId)
+}
+
 type ErrorDetail struct {
 	Issue       string `json:"issue,omitempty"`
 	Field       string `json:"field,omitempty"`
@@ -337,3 +350,30 @@ type NetAmountBreakdown struct {
 	ConvertedAmount *Amount       `json:"converted_amount,omitempty"`
 	ExchangeRate    *ExchangeRate `json:"exchange_rate,omitempty"`
 }
+
+type WebhookEvent struct {
+	Id              string          `json:"id,omitempty"`
+	CreateTime      string          `json:"create_time,omitempty"`
+	ResourceType    string          `json:"resource_type,omitempty"`
+	EventType       string          `json:"event_type,omitempty"` // PAYMENT.CAPTURE.COMPLETED、PAYMENT.CAPTURE.DENIED、PAYMENT.CAPTURE.PENDING、PAYMENT.CAPTURE.REFUNDED、PAYMENT.CAPTURE.REVERSED
+	EventVersion    string          `json:"event_version,omitempty"`
+	ResourceVersion string          `json:"resource_version,omitempty"`
+	Summary         string          `json:"summary,omitempty"`
+	Resource        json.RawMessage `json:"resource,omitempty"` // PAYMENT.CAPTURE.* 事件可通过 event.CaptureResource() 解析
+	Links           []*Link         `json:"links,omitempty"`
+}
+
+type WebhookCapture struct {
+	PaymentAuthorizeCapture
+	SupplementaryData *SupplementaryData `json:"supplementary_data,omitempty"`
+}
+
+type SupplementaryData struct {
+	RelatedIds *RelatedIds `json:"related_ids,omitempty"`
+}
+
+type RelatedIds struct {
+	OrderId         string `json:"order_id,omitempty"`
+	AuthorizationId string `json:"authorization_id,omitempty"`
+	CaptureId       string `json:"capture_id,omitempty"`
+}
@@ -0,0 +1,171 @@
+package paypal
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 缓存已下载并校验通过的 PayPal 签名证书，key 为 PAYPAL-CERT-URL
+var webhookCerts sync.Map
+
+// webhookCertRoots 校验 PayPal 签名证书链的根证书，为 nil 时使用系统根证书，测试时可替换
+var webhookCertRoots *x509.CertPool
+
+// webhookHttpClient 下载 PayPal 签名证书，校验 TLS 证书
+var webhookHttpClient = &http.Client{Timeout: 30 * time.Second}
+
+// fetchWebhookCert 下载 PayPal 签名证书，测试时可替换
+var fetchWebhookCert = func(certUrl string) (certPem []byte, err error) {
+	res, err := webhookHttpClient.Get(certUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	return ioutil.ReadAll(io.LimitReader(res.Body, int64(1<<20)))
+}
+
+// VerifyWebhookSign 验证 PayPal Webhook 通知签名，验签通过后解析通知内容
+//	req：Webhook 通知请求，读取后 req.Body 不可再次读取
+//	webhookId：开发者后台创建 Webhook 时生成的 Webhook ID
+//	文档：https://developer.paypal.com/api/rest/webhooks/rest/#link-selfverificationmethod
+func VerifyWebhookSign(req *http.Request, webhookId string) (event *WebhookEvent, err error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	defer req.Body.Close()
+	return VerifyWebhook(req.Header, body, webhookId)
+}
+
+// VerifyWebhook 验证 PayPal Webhook 通知签名，验签通过后解析通知内容
+//	header：Webhook 通知请求头
+//	body：Webhook 通知请求体原文
+//	webhookId：开发者后台创建 Webhook 时生成的 Webhook ID
+//	签名原文：transmission_id|transmission_time|webhook_id|crc32(body)，使用 PAYPAL-CERT-URL 证书以 SHA256withRSA 验签
+func VerifyWebhook(header http.Header, body []byte, webhookId string) (event *WebhookEvent, err error) {
+	var (
+		transmissionId   = header.Get(HeaderTransmissionId)
+		transmissionTime = header.Get(HeaderTransmissionTime)
+		transmissionSig  = header.Get(HeaderTransmissionSig)
+		certUrl          = header.Get(HeaderCertUrl)
+		authAlgo         = header.Get(HeaderAuthAlgo)
+	)
+	if transmissionId == "" || transmissionTime == "" || transmissionSig == "" || certUrl == "" {
+		return nil, errors.New("paypal webhook headers are incomplete")
+	}
+	if authAlgo != "" && authAlgo != "SHA256withRSA" {
+		return nil, fmt.Errorf("unsupported paypal webhook auth algo: %s", authAlgo)
+	}
+	pubKey, err := webhookCertPublicKey(certUrl)
+	if err != nil {
+		return nil, err
+	}
+	signStr := transmissionId + "|" + transmissionTime + "|" + webhookId + "|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)
+	signBytes, err := base64.StdEncoding.DecodeString(transmissionSig)
+	if err != nil {
+		return nil, fmt.Errorf("base64.StdEncoding.DecodeString(%s)：%w", transmissionSig, err)
+	}
+	h := sha256.Sum256([]byte(signStr))
+	if err = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, h[:], signBytes); err != nil {
+		return nil, fmt.Errorf("paypal webhook verify sign failed：%w", err)
+	}
+	event = new(WebhookEvent)
+	if err = json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(body), err)
+	}
+	return event, nil
+}
+
+// webhookCertPublicKey 获取 PAYPAL-CERT-URL 证书公钥，只允许 paypal.com 域名下的 https 地址
+//	证书需由受信任的根证书签发、在有效期内，且证书主体为 PayPal 域名
+func webhookCertPublicKey(certUrl string) (pubKey *rsa.PublicKey, err error) {
+	now := time.Now()
+	if v, ok := webhookCerts.Load(certUrl); ok {
+		cert := v.(*x509.Certificate)
+		if now.Before(cert.NotAfter) && now.After(cert.NotBefore) {
+			return cert.PublicKey.(*rsa.PublicKey), nil
+		}
+		webhookCerts.Delete(certUrl)
+	}
+	u, err := url.Parse(certUrl)
+	if err != nil {
+		return nil, fmt.Errorf("url.Parse(%s)：%w", certUrl, err)
+	}
+	if u.Scheme != "https" || !isPayPalHost(u.Hostname()) {
+		return nil, fmt.Errorf("invalid paypal cert url: %s", certUrl)
+	}
+	certPem, err := fetchWebhookCert(certUrl)
+	if err != nil {
+		return nil, fmt.Errorf("download paypal cert(%s)：%w", certUrl, err)
+	}
+	// 第一个证书为签名证书，其余为中间证书
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(certPem); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("x509.ParseCertificate：%w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("paypal cert pem.Decode() return nil")
+	}
+	cert := certs[0]
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		Roots:         webhookCertRoots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("paypal cert %s verify failed：%w", certUrl, err)
+	}
+	if !isPayPalHost(cert.Subject.CommonName) {
+		return nil, fmt.Errorf("paypal cert subject %s is not paypal", cert.Subject.CommonName)
+	}
+	pubKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("paypal cert is not rsa public key")
+	}
+	webhookCerts.Store(certUrl, cert)
+	return pubKey, nil
+}
+
+// isPayPalHost 是否为 paypal.com 或其子域名
+func isPayPalHost(host string) bool {
+	return host == "paypal.com" || strings.HasSuffix(host, ".paypal.com")
+}
+
+// CaptureResource 解析 PAYMENT.CAPTURE.* 事件的 resource
+func (e *WebhookEvent) CaptureResource() (capture *WebhookCapture, err error) {
+	if !strings.HasPrefix(e.EventType, "PAYMENT.CAPTURE.") {
+		return nil, fmt.Errorf("event_type %s is not PAYMENT.CAPTURE.*", e.EventType)
+	}
+	capture = new(WebhookCapture)
+	if err = json.Unmarshal(e.Resource, capture); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(e.Resource), err)
+	}
+	return capture, nil
+}
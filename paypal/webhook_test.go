@@ -0,0 +1,148 @@
+package paypal
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"hash/crc32"
+	"math/big"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTpl, caTpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(caDer)
+	roots := webhookCertRoots
+	defer func() { webhookCertRoots = roots }()
+	webhookCertRoots = x509.NewCertPool()
+	webhookCertRoots.AddCert(caCert)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := func(cn string, parent *x509.Certificate, parentKey *rsa.PrivateKey) []byte {
+		tpl := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(24 * time.Hour),
+		}
+		if parent == nil {
+			parent, parentKey = tpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	certPem := issue("messageverificationcerts.paypal.com", caCert, caKey)
+
+	fetch := fetchWebhookCert
+	defer func() { fetchWebhookCert = fetch }()
+	var fetched int
+	fetchWebhookCert = func(certUrl string) ([]byte, error) {
+		fetched++
+		return certPem, nil
+	}
+
+	var (
+		webhookId = "1JE4291016473214C"
+		certUrl   = "https://api.sandbox.paypal.com/v1/notifications/certs/CERT-360caa42-fca2a594-test"
+		body      = []byte(`{"id":"WH-58D329510W468432D-8HN650336L201105X","create_time":"2019-02-14T21:50:07.940Z","resource_type":"capture","event_type":"PAYMENT.CAPTURE.COMPLETED","summary":"Payment completed for $ 2.51 USD","resource":{"id":"27M47624FP291604U","amount":{"currency_code":"USD","value":"2.51"},"final_capture":true,"status":"COMPLETED","supplementary_data":{"related_ids":{"order_id":"5O190127TN364715T"}}},"event_version":"1.0","resource_version":"2.0"}`)
+	)
+	signStr := "b2384410-f8d2-11ec-a26b-3d5e8b1e5f52|2022-06-30T02:44:05Z|" + webhookId + "|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)
+	h := sha256.Sum256([]byte(signStr))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := make(http.Header)
+	header.Set(HeaderTransmissionId, "b2384410-f8d2-11ec-a26b-3d5e8b1e5f52")
+	header.Set(HeaderTransmissionTime, "2022-06-30T02:44:05Z")
+	header.Set(HeaderTransmissionSig, base64.StdEncoding.EncodeToString(sig))
+	header.Set(HeaderCertUrl, certUrl)
+	header.Set(HeaderAuthAlgo, "SHA256withRSA")
+
+	event, err := VerifyWebhook(header, body, webhookId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.EventType != EventPaymentCaptureCompleted {
+		t.Errorf("event.EventType = %s", event.EventType)
+	}
+	capture, err := event.CaptureResource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capture.Id != "27M47624FP291604U" || capture.Status != "COMPLETED" || capture.Amount.Value != "2.51" ||
+		capture.SupplementaryData == nil || capture.SupplementaryData.RelatedIds.OrderId != "5O190127TN364715T" {
+		t.Errorf("capture = %+v", capture)
+	}
+
+	// 证书已缓存
+	if _, err = VerifyWebhook(header, body, webhookId); err != nil || fetched != 1 {
+		t.Errorf("VerifyWebhook() err = %v, fetched = %d", err, fetched)
+	}
+	// webhookId 不一致
+	if _, err = VerifyWebhook(header, body, "OTHER"); err == nil {
+		t.Error("VerifyWebhook() with wrong webhookId should return error")
+	}
+	// 报文被篡改
+	if _, err = VerifyWebhook(header, append(body, ' '), webhookId); err == nil {
+		t.Error("VerifyWebhook() with modified body should return error")
+	}
+	// 非 PayPal 证书地址
+	header.Set(HeaderCertUrl, "https://api.sandbox.paypal.com.evil.com/cert")
+	if _, err = VerifyWebhook(header, body, webhookId); err == nil {
+		t.Error("VerifyWebhook() with invalid cert url should return error")
+	}
+	// 自签名证书（非受信任根证书签发）
+	certPem = issue("messageverificationcerts.paypal.com", nil, nil)
+	header.Set(HeaderCertUrl, certUrl+"-self-signed")
+	if _, err = VerifyWebhook(header, body, webhookId); err == nil {
+		t.Error("VerifyWebhook() with untrusted cert should return error")
+	}
+	// 证书主体不是 PayPal
+	certPem = issue("example.com", caCert, caKey)
+	header.Set(HeaderCertUrl, certUrl+"-other-subject")
+	if _, err = VerifyWebhook(header, body, webhookId); err == nil {
+		t.Error("VerifyWebhook() with non paypal cert subject should return error")
+	}
+	// 缓存的证书过期后重新下载
+	cached, _ := webhookCerts.Load(certUrl)
+	expired := *cached.(*x509.Certificate)
+	expired.NotAfter = time.Now().Add(-time.Minute)
+	webhookCerts.Store(certUrl, &expired)
+	certPem = issue("messageverificationcerts.paypal.com", caCert, caKey)
+	header.Set(HeaderCertUrl, certUrl)
+	fetched = 0
+	if _, err = VerifyWebhook(header, body, webhookId); err != nil || fetched != 1 {
+		t.Errorf("VerifyWebhook() with expired cached cert err = %v, fetched = %d", err, fetched)
+	}
+}
@@ -0,0 +1,55 @@
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+
+	"github.com/emmansun/gmsm/sm2"
+)
+
+// 曲线运算、签名及验签基于 github.com/emmansun/gmsm/sm2（常数时间实现），本包仅做密钥解析及接口适配
+
+// DefaultUID 签名默认用户身份标识（GM/T 0009-2012）
+var DefaultUID = []byte("1234567812345678")
+
+// PublicKey SM2 公钥
+type PublicKey = ecdsa.PublicKey
+
+// PrivateKey SM2 私钥
+type PrivateKey = sm2.PrivateKey
+
+// P256 国密 SM2 推荐曲线（GB/T 32918.5-2017）
+func P256() elliptic.Curve {
+	return sm2.P256()
+}
+
+// GenerateKey 生成 SM2 密钥对
+func GenerateKey(random io.Reader) (priv *PrivateKey, err error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	return sm2.GenerateKey(random)
+}
+
+// Sign SM3 摘要后使用私钥签名，返回 ASN.1 DER 编码的签名
+//	uid：用户身份标识，为空时使用 DefaultUID
+func Sign(priv *PrivateKey, uid, msg []byte) (sign []byte, err error) {
+	if len(uid) == 0 {
+		uid = DefaultUID
+	}
+	return priv.Sign(rand.Reader, msg, sm2.NewSM2SignerOption(true, uid))
+}
+
+// Verify 使用公钥验证 ASN.1 DER 编码的签名
+//	uid：用户身份标识，为空时使用 DefaultUID
+func Verify(pub *PublicKey, uid, msg, sign []byte) bool {
+	if pub == nil || pub.Curve != P256() {
+		return false
+	}
+	if len(uid) == 0 {
+		uid = DefaultUID
+	}
+	return sm2.VerifyASN1WithSM2(pub, uid, msg, sign)
+}
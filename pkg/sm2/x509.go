@@ -0,0 +1,146 @@
+package sm2
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveSM2  = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type publicKeyInfo struct {
+	Raw       asn1.RawContent
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type tbsCertificate struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           asn1.RawValue
+	Subject            asn1.RawValue
+	PublicKey          publicKeyInfo
+	UniqueId           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+type certificate struct {
+	TBSCertificate     tbsCertificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+// ParsePrivateKey 解析 PEM 格式的 SM2 私钥，支持 PKCS#8（PRIVATE KEY）及 SEC1（EC PRIVATE KEY、SM2 PRIVATE KEY）
+func ParsePrivateKey(pemContent []byte) (priv *PrivateKey, err error) {
+	block, _ := pem.Decode(pemContent)
+	if block == nil {
+		return nil, errors.New("sm2: parse private key error, pem.Decode() return nil")
+	}
+	der := block.Bytes
+	// 先按 PKCS#8 解析，失败时按 SEC1 解析（EC PRIVATE KEY、SM2 PRIVATE KEY）
+	var key pkcs8
+	if _, err = asn1.Unmarshal(der, &key); err == nil && key.Algo.Algorithm != nil {
+		if !key.Algo.Algorithm.Equal(oidPublicKeyECDSA) && !key.Algo.Algorithm.Equal(oidNamedCurveSM2) {
+			return nil, fmt.Errorf("sm2: unsupported private key algorithm %v", key.Algo.Algorithm)
+		}
+		der = key.PrivateKey
+	}
+	var ecKey ecPrivateKey
+	if _, err = asn1.Unmarshal(der, &ecKey); err != nil {
+		return nil, fmt.Errorf("sm2: parse ec private key error: %w", err)
+	}
+	c := P256()
+	d := new(big.Int).SetBytes(ecKey.PrivateKey)
+	if d.Sign() <= 0 || d.Cmp(c.Params().N) >= 0 {
+		return nil, errors.New("sm2: invalid private key")
+	}
+	priv = new(PrivateKey)
+	priv.Curve = c
+	priv.D = d
+	// 定长标量，避免泄露私钥长度
+	priv.X, priv.Y = c.ScalarBaseMult(d.FillBytes(make([]byte, 32)))
+	return priv, nil
+}
+
+// ParsePublicKey 解析 PEM 格式的 SM2 公钥，支持 X.509 证书（CERTIFICATE）及公钥（PUBLIC KEY）
+//	serialNo：证书序列号（十六进制大写），非证书时为空
+func ParsePublicKey(pemContent []byte) (pub *PublicKey, serialNo string, err error) {
+	block, _ := pem.Decode(pemContent)
+	if block == nil {
+		return nil, "", errors.New("sm2: parse public key error, pem.Decode() return nil")
+	}
+	var info publicKeyInfo
+	if block.Type == "CERTIFICATE" {
+		var cert certificate
+		if _, err = asn1.Unmarshal(block.Bytes, &cert); err != nil {
+			return nil, "", fmt.Errorf("sm2: parse certificate error: %w", err)
+		}
+		info = cert.TBSCertificate.PublicKey
+		serialNo = strings.ToUpper(cert.TBSCertificate.SerialNumber.Text(16))
+	} else if _, err = asn1.Unmarshal(block.Bytes, &info); err != nil {
+		return nil, "", fmt.Errorf("sm2: parse public key error: %w", err)
+	}
+	pub, err = unmarshalPublicKey(info.PublicKey.RightAlign())
+	if err != nil {
+		return nil, "", err
+	}
+	return pub, serialNo, nil
+}
+
+// MarshalPublicKey 将 SM2 公钥编码为 PEM 格式（PUBLIC KEY）
+func MarshalPublicKey(pub *PublicKey) (pemContent []byte, err error) {
+	params, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return nil, err
+	}
+	point := make([]byte, 65)
+	point[0] = 4
+	pub.X.FillBytes(point[1:33])
+	pub.Y.FillBytes(point[33:])
+	der, err := asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: params}},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// unmarshalPublicKey 解析未压缩的公钥点 04 || X || Y
+func unmarshalPublicKey(point []byte) (pub *PublicKey, err error) {
+	if len(point) != 65 || point[0] != 4 {
+		return nil, errors.New("sm2: invalid public key point")
+	}
+	c := P256()
+	x := new(big.Int).SetBytes(point[1:33])
+	y := new(big.Int).SetBytes(point[33:])
+	if !c.IsOnCurve(x, y) {
+		return nil, errors.New("sm2: public key point is not on curve")
+	}
+	return &PublicKey{Curve: c, X: x, Y: y}, nil
+}
@@ -0,0 +1,27 @@
+package sm3
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	// GB/T 32905-2016 附录A 示例
+	tests := map[string]string{
+		"abc":                      "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0",
+		strings.Repeat("abcd", 16): "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732",
+	}
+	for in, want := range tests {
+		sum := Sum([]byte(in))
+		if got := hex.EncodeToString(sum[:]); got != want {
+			t.Fatalf("Sum(%s) = %s, want %s", in, got, want)
+		}
+		h := New()
+		h.Write([]byte(in[:1]))
+		h.Write([]byte(in[1:]))
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Fatalf("New().Sum(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
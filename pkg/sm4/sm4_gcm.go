@@ -0,0 +1,39 @@
+package sm4
+
+import (
+	"crypto/cipher"
+	"fmt"
+
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// GCMDecrypt SM4-GCM 解密
+func GCMDecrypt(cipherText, nonce, additional, key []byte) ([]byte, error) {
+	block, err := NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cipher.NewGCM(),error:%w", err)
+	}
+	originByte, err := gcm.Open(nil, nonce, cipherText, additional)
+	if err != nil {
+		return nil, err
+	}
+	return originByte, nil
+}
+
+// GCMEncrypt SM4-GCM 加密，随机生成12位 nonce
+func GCMEncrypt(originText, additional, key []byte) (nonce []byte, cipherText []byte, err error) {
+	block, err := NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = []byte(util.GetRandomString(12))
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cipher.NewGCM(),error:%w", err)
+	}
+	return nonce, gcm.Seal(nil, nonce, originText, additional), nil
+}
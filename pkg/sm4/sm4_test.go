@@ -0,0 +1,41 @@
+package sm4
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSM4(t *testing.T) {
+	// GB/T 32907-2016 附录A 示例1
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	want, _ := hex.DecodeString("681edf34d206965e86b3e94f536e4246")
+	block, err := NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]byte, BlockSize)
+	block.Encrypt(dst, key)
+	if !bytes.Equal(dst, want) {
+		t.Fatalf("Encrypt() = %x, want %x", dst, want)
+	}
+	block.Decrypt(dst, dst)
+	if !bytes.Equal(dst, key) {
+		t.Fatalf("Decrypt() = %x, want %x", dst, key)
+	}
+}
+
+func TestSM4GCM(t *testing.T) {
+	key := []byte("GYBh3Rmey7nNzR/N")
+	nonce, cipherText, err := GCMEncrypt([]byte("https://www.fmm.ink"), []byte("certificate"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	origin, err := GCMDecrypt(cipherText, nonce, []byte("certificate"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(origin) != "https://www.fmm.ink" {
+		t.Fatalf("GCMDecrypt() = %s", origin)
+	}
+}
@@ -144,6 +144,7 @@ func NewClient() (client *Client) {
 		requestType:   TypeUrlencoded,
 		unmarshalType: string(TypeJSON),
 		Errors:        make([]error, 0),
+		ctx:           context.Background(),
 	}
 	return client
 }
@@ -161,6 +162,9 @@ func NewClientFromHttpClient(ctx context.Context, httpClient *http.Client) (clie
 		}
 	}
 
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	client = &Client{
 		HttpClient:    httpClient,
 		Transport:     nil,
@@ -0,0 +1,171 @@
+package gopay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ErrNotSupported 支付渠道不支持该操作，Provider 未实现的方法或不支持的支付场景返回此错误
+var ErrNotSupported = errors.New("gopay: operation not supported by provider")
+
+// Provider 与支付渠道无关的通用支付接口
+//	请求及返回使用统一的结构体，金额单位统一为分，应用可通过配置切换渠道
+//	渠道实现此接口后，在实现包的 init() 中通过 gopay.RegisterProvider() 注册，如 wechat、alipay
+type Provider interface {
+	// CreateOrder 下单
+	CreateOrder(ctx context.Context, req *OrderRequest) (rsp *OrderResponse, err error)
+	// QueryOrder 查询订单
+	QueryOrder(ctx context.Context, req *QueryRequest) (rsp *TradeResult, err error)
+	// Refund 申请退款
+	Refund(ctx context.Context, req *RefundRequest) (rsp *RefundResult, err error)
+	// QueryRefund 查询退款
+	QueryRefund(ctx context.Context, req *RefundQueryRequest) (rsp *RefundResult, err error)
+	// Close 关闭订单
+	Close(ctx context.Context, req *QueryRequest) (err error)
+	// VerifyNotify 解析并验签支付结果异步通知
+	VerifyNotify(req *http.Request) (notify *TradeResult, err error)
+}
+
+// 支付场景
+const (
+	SceneNative = "NATIVE" // 扫码支付，商户展示二维码
+	SceneJSAPI  = "JSAPI"  // 公众号、小程序、生活号内支付
+	SceneApp    = "APP"    // APP支付
+	SceneH5     = "H5"     // 手机网页支付
+	ScenePage   = "PAGE"   // 电脑网页支付
+)
+
+// TradeStatus 通用交易状态
+type TradeStatus string
+
+const (
+	TradeStatusNotPay   TradeStatus = "NOTPAY"   // 未支付，含支付中、支付失败
+	TradeStatusSuccess  TradeStatus = "SUCCESS"  // 支付成功
+	TradeStatusClosed   TradeStatus = "CLOSED"   // 已关闭，含已撤销
+	TradeStatusRefund   TradeStatus = "REFUND"   // 转入退款
+	TradeStatusFinished TradeStatus = "FINISHED" // 交易结束，不可退款
+	TradeStatusUnknown  TradeStatus = "UNKNOWN"  // 无法识别的渠道状态，见 Raw
+)
+
+// RefundStatus 通用退款状态
+type RefundStatus string
+
+const (
+	RefundStatusProcessing RefundStatus = "PROCESSING" // 退款处理中
+	RefundStatusSuccess    RefundStatus = "SUCCESS"    // 退款成功
+	RefundStatusFail       RefundStatus = "FAIL"       // 退款关闭或异常
+)
+
+// OrderRequest 通用下单请求
+type OrderRequest struct {
+	OutTradeNo string  // 商户订单号
+	Amount     int64   // 订单金额，单位：分
+	Subject    string  // 商品描述
+	Scene      string  // 支付场景，gopay.SceneNative 等
+	NotifyUrl  string  // 异步通知地址，为空时使用渠道配置
+	ReturnUrl  string  // 支付完成跳转地址，手机网页、电脑网页支付使用
+	OpenId     string  // 用户标识，JSAPI 场景必填：微信 openid、支付宝 buyer_id
+	ClientIp   string  // 用户IP
+	Extra      BodyMap // 渠道特有参数，原样合并到渠道请求参数中
+}
+
+// OrderResponse 通用下单结果
+type OrderResponse struct {
+	OutTradeNo string  // 商户订单号
+	TradeNo    string  // 渠道订单号，部分场景下单时不返回
+	PayInfo    string  // 调起支付所需信息：二维码链接、支付跳转地址、prepay_id 或 APP 支付参数，见各渠道实现
+	Raw        BodyMap // 渠道原始应答
+}
+
+// QueryRequest 查询订单、关闭订单请求，OutTradeNo 与 TradeNo 二选一
+type QueryRequest struct {
+	OutTradeNo string // 商户订单号
+	TradeNo    string // 渠道订单号
+}
+
+// TradeResult 通用交易结果，查询订单及支付结果异步通知返回
+type TradeResult struct {
+	OutTradeNo string      // 商户订单号
+	TradeNo    string      // 渠道订单号
+	Status     TradeStatus // 交易状态
+	Amount     int64       // 订单金额，单位：分
+	Raw        BodyMap     // 渠道原始应答或通知参数
+}
+
+// RefundRequest 通用退款请求，OutTradeNo 与 TradeNo 二选一
+type RefundRequest struct {
+	OutTradeNo   string // 商户订单号
+	TradeNo      string // 渠道订单号
+	OutRefundNo  string // 商户退款单号，重试时使用相同的退款单号避免重复退款
+	TotalAmount  int64  // 原订单金额，单位：分
+	RefundAmount int64  // 退款金额，单位：分
+	Reason       string // 退款原因
+}
+
+// RefundQueryRequest 通用退款查询请求
+type RefundQueryRequest struct {
+	OutTradeNo  string // 商户订单号，与 TradeNo 二选一
+	TradeNo     string // 渠道订单号
+	OutRefundNo string // 商户退款单号
+}
+
+// RefundResult 通用退款结果
+type RefundResult struct {
+	OutRefundNo string       // 商户退款单号
+	RefundNo    string       // 渠道退款单号，部分渠道不返回
+	Status      RefundStatus // 退款状态
+	Amount      int64        // 退款金额，单位：分
+	Raw         BodyMap      // 渠道原始应答
+}
+
+// ProviderFactory 根据配置创建 Provider
+//	config：渠道配置，如 appid、私钥等，由实现方自行约定
+type ProviderFactory func(config BodyMap) (provider Provider, err error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]ProviderFactory)
+)
+
+// RegisterProvider 注册支付渠道，一般在实现包的 init() 中调用
+//	name 重复注册或 factory 为 nil 时 panic
+func RegisterProvider(name string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if factory == nil {
+		panic("gopay: RegisterProvider factory is nil")
+	}
+	if _, dup := providers[name]; dup {
+		panic("gopay: RegisterProvider called twice for provider " + name)
+	}
+	providers[name] = factory
+}
+
+// NewProvider 根据渠道名称及配置创建 Provider
+//	name：gopay.RegisterProvider() 注册的渠道名称，需先导入对应的渠道包，如 _ "github.com/cedarwu/gopay/wechat"
+//	config：渠道配置
+func NewProvider(name string, config BodyMap) (provider Provider, err error) {
+	providersMu.RLock()
+	factory, ok := providers[name]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("gopay: unknown provider %q (forgotten import?)", name)
+	}
+	return factory(config)
+}
+
+// Providers 已注册的渠道名称，按名称排序
+func Providers() (names []string) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names = make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
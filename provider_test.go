@@ -0,0 +1,109 @@
+package gopay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type mockProvider struct {
+	merchant string
+}
+
+func (p *mockProvider) CreateOrder(ctx context.Context, req *OrderRequest) (rsp *OrderResponse, err error) {
+	raw := make(BodyMap)
+	raw.Set("merchant", p.merchant)
+	return &OrderResponse{OutTradeNo: req.OutTradeNo, PayInfo: "mock://pay/" + req.OutTradeNo, Raw: raw}, nil
+}
+
+func (p *mockProvider) QueryOrder(ctx context.Context, req *QueryRequest) (rsp *TradeResult, err error) {
+	return nil, ErrNotSupported
+}
+
+func (p *mockProvider) Refund(ctx context.Context, req *RefundRequest) (rsp *RefundResult, err error) {
+	return nil, ErrNotSupported
+}
+
+func (p *mockProvider) QueryRefund(ctx context.Context, req *RefundQueryRequest) (rsp *RefundResult, err error) {
+	return nil, ErrNotSupported
+}
+
+func (p *mockProvider) Close(ctx context.Context, req *QueryRequest) (err error) {
+	return ErrNotSupported
+}
+
+func (p *mockProvider) VerifyNotify(req *http.Request) (notify *TradeResult, err error) {
+	if err = req.ParseForm(); err != nil {
+		return nil, err
+	}
+	return &TradeResult{
+		OutTradeNo: req.PostForm.Get("out_trade_no"),
+		Status:     TradeStatus(req.PostForm.Get("status")),
+	}, nil
+}
+
+func TestRegisterProvider(t *testing.T) {
+	RegisterProvider("mock", func(config BodyMap) (Provider, error) {
+		if err := config.CheckEmptyError("merchant"); err != nil {
+			return nil, err
+		}
+		return &mockProvider{merchant: config.GetString("merchant")}, nil
+	})
+	defer func() {
+		providersMu.Lock()
+		delete(providers, "mock")
+		providersMu.Unlock()
+	}()
+
+	found := false
+	for _, name := range Providers() {
+		found = found || name == "mock"
+	}
+	if !found {
+		t.Fatalf("Providers() = %v", Providers())
+	}
+
+	config := make(BodyMap)
+	config.Set("merchant", "10001")
+	p, err := NewProvider("mock", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp, err := p.CreateOrder(context.Background(), &OrderRequest{OutTradeNo: "GOPAY001", Amount: 1, Scene: SceneNative})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Raw.GetString("merchant") != "10001" || rsp.OutTradeNo != "GOPAY001" || rsp.PayInfo == "" {
+		t.Fatalf("CreateOrder() = %+v", rsp)
+	}
+	if err = p.Close(context.Background(), &QueryRequest{OutTradeNo: "GOPAY001"}); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("Close() error = %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader("out_trade_no=GOPAY001&status=SUCCESS"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	notify, err := p.VerifyNotify(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notify.Status != TradeStatusSuccess || notify.OutTradeNo != "GOPAY001" {
+		t.Fatalf("VerifyNotify() = %+v", notify)
+	}
+
+	if _, err = NewProvider("mock", make(BodyMap)); err == nil {
+		t.Error("NewProvider() with empty config should return error")
+	}
+	if _, err = NewProvider("unknown", config); err == nil {
+		t.Error("NewProvider() with unknown provider should return error")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("RegisterProvider() twice should panic")
+			}
+		}()
+		RegisterProvider("mock", func(config BodyMap) (Provider, error) { return nil, nil })
+	}()
+}
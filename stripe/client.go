@@ -0,0 +1,90 @@
+package stripe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+// Client Stripe 客户端
+type Client struct {
+	SecretKey   string // API 密钥，sk_test_ 开头为测试模式
+	ApiVersion  string // Stripe-Version 请求头，为空时使用帐户默认版本
+	DebugSwitch gopay.DebugSwitch
+
+	apiUrl string
+}
+
+// 初始化 Stripe 客户端
+//	secretKey：API 密钥（Secret key）
+func NewClient(secretKey string) (client *Client, err error) {
+	if secretKey == util.NULL {
+		return nil, errors.New("secretKey cannot be empty")
+	}
+	return &Client{
+		SecretKey:   secretKey,
+		DebugSwitch: gopay.DebugOff,
+		apiUrl:      baseUrl,
+	}, nil
+}
+
+// 向 Stripe 发送请求，对于本库未提供的 Stripe 接口，可自行实现，通过此方法发送请求
+//	method：http.MethodGet 或 http.MethodPost
+//	bm：请求参数，嵌套参数使用 metadata[order_id] 形式的 key，GET 请求时为 query 参数
+//	uri：接口地址，例如：/customers
+//	idempotencyKey：幂等键，POST 请求重试时传相同的值，可为空
+func (c *Client) DoStripeAPISelf(method string, bm gopay.BodyMap, uri, idempotencyKey string, v interface{}) (err error) {
+	return c.doStripe(method, bm, uri, idempotencyKey, v)
+}
+
+func (c *Client) doStripe(method string, bm gopay.BodyMap, uri, idempotencyKey string, v interface{}) (err error) {
+	var url = c.apiUrl + uri
+	httpClient := xhttp.NewClient()
+	httpClient.Header.Add("Authorization", "Bearer "+c.SecretKey)
+	if c.ApiVersion != util.NULL {
+		httpClient.Header.Add("Stripe-Version", c.ApiVersion)
+	}
+	if idempotencyKey != util.NULL {
+		httpClient.Header.Add("Idempotency-Key", idempotencyKey)
+	}
+	params := bm.EncodeURLParams()
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Stripe_Url: %s %s", method, url)
+		xlog.Debugf("Stripe_Request: %s", params)
+	}
+	switch method {
+	case http.MethodGet:
+		if params != util.NULL {
+			url += "?" + params
+		}
+		httpClient.Type(xhttp.TypeForm).Get(url)
+	case http.MethodPost:
+		httpClient.Type(xhttp.TypeForm).Post(url).SendString(params)
+	default:
+		return fmt.Errorf("unsupported method: %s", method)
+	}
+	res, bs, errs := httpClient.EndBytes()
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Stripe_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		errRsp := &ErrorResponse{HttpCode: res.StatusCode}
+		if len(bs) > 0 {
+			_ = json.Unmarshal(bs, errRsp)
+		}
+		return errRsp
+	}
+	if err = json.Unmarshal(bs, v); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
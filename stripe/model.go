@@ -0,0 +1,129 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	baseUrl = "https://api.stripe.com/v1"
+
+	paymentIntents       = "/payment_intents"            // 创建 PaymentIntent POST
+	paymentIntentById    = "/payment_intents/%s"         // 查询 PaymentIntent GET
+	paymentIntentConfirm = "/payment_intents/%s/confirm" // 确认 PaymentIntent POST
+	paymentIntentCapture = "/payment_intents/%s/capture" // 请款 PaymentIntent POST
+	paymentIntentCancel  = "/payment_intents/%s/cancel"  // 取消 PaymentIntent POST
+	refunds              = "/refunds"                    // 创建退款 POST
+
+	// Webhook 签名请求头
+	HeaderSignature = "Stripe-Signature"
+
+	// Webhook 签名时间戳默认容差
+	DefaultTolerance = 300 // 秒
+
+	// PaymentIntent 状态 status
+	StatusRequiresPaymentMethod = "requires_payment_method"
+	StatusRequiresConfirmation  = "requires_confirmation"
+	StatusRequiresAction        = "requires_action"
+	StatusProcessing            = "processing"
+	StatusRequiresCapture       = "requires_capture"
+	StatusCanceled              = "canceled"
+	StatusSucceeded             = "succeeded"
+
+	// 请款方式 capture_method
+	CaptureMethodAutomatic = "automatic"
+	CaptureMethodManual    = "manual"
+
+	// Webhook 事件类型 type
+	EventPaymentIntentSucceeded      = "payment_intent.succeeded"
+	EventPaymentIntentPaymentFailed  = "payment_intent.payment_failed"
+	EventPaymentIntentCanceled       = "payment_intent.canceled"
+	EventPaymentIntentRequiresAction = "payment_intent.requires_action"
+	EventChargeRefunded              = "charge.refunded"
+)
+
+// ErrorResponse Stripe API 错误响应
+type ErrorResponse struct {
+	HttpCode int `json:"-"`
+	Err      struct {
+		Type        string `json:"type"`
+		Code        string `json:"code,omitempty"`
+		DeclineCode string `json:"decline_code,omitempty"`
+		Message     string `json:"message,omitempty"`
+		Param       string `json:"param,omitempty"`
+	} `json:"error"`
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("stripe api error, StatusCode = %d, type = %s, code = %s, message = %s", e.HttpCode, e.Err.Type, e.Err.Code, e.Err.Message)
+}
+
+// PaymentIntent 支付意图
+type PaymentIntent struct {
+	Id                 string            `json:"id"`
+	Object             string            `json:"object"`
+	Amount             int64             `json:"amount"`
+	AmountCapturable   int64             `json:"amount_capturable"`
+	AmountReceived     int64             `json:"amount_received"`
+	Currency           string            `json:"currency"`
+	Status             string            `json:"status"` // 取值见 Status* 常量
+	ClientSecret       string            `json:"client_secret,omitempty"`
+	CaptureMethod      string            `json:"capture_method,omitempty"`
+	ConfirmationMethod string            `json:"confirmation_method,omitempty"`
+	Customer           string            `json:"customer,omitempty"`
+	Description        string            `json:"description,omitempty"`
+	LatestCharge       string            `json:"latest_charge,omitempty"`
+	PaymentMethod      string            `json:"payment_method,omitempty"`
+	PaymentMethodTypes []string          `json:"payment_method_types,omitempty"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+	LastPaymentError   *PaymentError     `json:"last_payment_error,omitempty"`
+	NextAction         json.RawMessage   `json:"next_action,omitempty"` // status 为 requires_action 时客户端需执行的操作
+	Metadata           map[string]string `json:"metadata,omitempty"`
+	Created            int64             `json:"created"`
+	Livemode           bool              `json:"livemode"`
+}
+
+type PaymentError struct {
+	Type        string `json:"type"`
+	Code        string `json:"code,omitempty"`
+	DeclineCode string `json:"decline_code,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// Refund 退款
+type Refund struct {
+	Id            string            `json:"id"`
+	Object        string            `json:"object"`
+	Amount        int64             `json:"amount"`
+	Currency      string            `json:"currency"`
+	PaymentIntent string            `json:"payment_intent,omitempty"`
+	Charge        string            `json:"charge,omitempty"`
+	Status        string            `json:"status"` // pending、requires_action、succeeded、failed、canceled
+	Reason        string            `json:"reason,omitempty"`
+	FailureReason string            `json:"failure_reason,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	Created       int64             `json:"created"`
+}
+
+// Event Webhook 事件
+type Event struct {
+	Id         string `json:"id"`
+	Object     string `json:"object"`
+	Type       string `json:"type"` // 取值见 Event* 常量
+	ApiVersion string `json:"api_version,omitempty"`
+	Created    int64  `json:"created"`
+	Livemode   bool   `json:"livemode"`
+	Data       struct {
+		Object             json.RawMessage `json:"object"`
+		PreviousAttributes json.RawMessage `json:"previous_attributes,omitempty"`
+	} `json:"data"`
+}
+
+// PaymentIntent 解析 payment_intent.* 事件的 data.object
+func (e *Event) PaymentIntent() (pi *PaymentIntent, err error) {
+	pi = new(PaymentIntent)
+	if err = json.Unmarshal(e.Data.Object, pi); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(e.Data.Object), err)
+	}
+	return pi, nil
+}
@@ -0,0 +1,97 @@
+package stripe
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 创建 PaymentIntent
+//	必填：amount（最小货币单位，如美分）、currency（小写 ISO 币种，如 usd）
+//	手动请款时传 capture_method=manual，之后调用 client.CapturePaymentIntent()
+//	idempotencyKey：幂等键，重试时传相同的值，可为空
+//	pi.ClientSecret 交给前端 Stripe.js 完成支付
+func (c *Client) CreatePaymentIntent(bm gopay.BodyMap, idempotencyKey string) (pi *PaymentIntent, err error) {
+	if err = bm.CheckEmptyError("amount", "currency"); err != nil {
+		return nil, err
+	}
+	pi = new(PaymentIntent)
+	if err = c.doStripe(http.MethodPost, bm, paymentIntents, idempotencyKey, pi); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+// 查询 PaymentIntent
+//	id：PaymentIntent ID（pi_ 开头）
+func (c *Client) RetrievePaymentIntent(id string) (pi *PaymentIntent, err error) {
+	if id == util.NULL {
+		return nil, errors.New("id cannot be empty")
+	}
+	pi = new(PaymentIntent)
+	if err = c.doStripe(http.MethodGet, nil, fmt.Sprintf(paymentIntentById, url.PathEscape(id)), util.NULL, pi); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+// 确认 PaymentIntent（服务端确认）
+//	id：PaymentIntent ID
+//	bm：可选参数，如 payment_method、return_url，可为 nil
+//	pi.Status 为 stripe.StatusRequiresAction 时需前端处理 pi.NextAction（如 3DS 验证）
+func (c *Client) ConfirmPaymentIntent(id string, bm gopay.BodyMap) (pi *PaymentIntent, err error) {
+	if id == util.NULL {
+		return nil, errors.New("id cannot be empty")
+	}
+	pi = new(PaymentIntent)
+	if err = c.doStripe(http.MethodPost, bm, fmt.Sprintf(paymentIntentConfirm, url.PathEscape(id)), util.NULL, pi); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+// 请款 PaymentIntent，仅适用于 capture_method=manual 且状态为 requires_capture
+//	id：PaymentIntent ID
+//	bm：可选参数，如 amount_to_capture（部分请款），可为 nil
+func (c *Client) CapturePaymentIntent(id string, bm gopay.BodyMap) (pi *PaymentIntent, err error) {
+	if id == util.NULL {
+		return nil, errors.New("id cannot be empty")
+	}
+	pi = new(PaymentIntent)
+	if err = c.doStripe(http.MethodPost, bm, fmt.Sprintf(paymentIntentCapture, url.PathEscape(id)), util.NULL, pi); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+// 取消 PaymentIntent
+//	id：PaymentIntent ID
+//	bm：可选参数，如 cancellation_reason，可为 nil
+func (c *Client) CancelPaymentIntent(id string, bm gopay.BodyMap) (pi *PaymentIntent, err error) {
+	if id == util.NULL {
+		return nil, errors.New("id cannot be empty")
+	}
+	pi = new(PaymentIntent)
+	if err = c.doStripe(http.MethodPost, bm, fmt.Sprintf(paymentIntentCancel, url.PathEscape(id)), util.NULL, pi); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+// 创建退款
+//	必填：payment_intent（PaymentIntent ID），amount 为空时全额退款
+//	idempotencyKey：幂等键，重试时传相同的值，可为空
+func (c *Client) CreateRefund(bm gopay.BodyMap, idempotencyKey string) (refund *Refund, err error) {
+	if err = bm.CheckEmptyError("payment_intent"); err != nil {
+		return nil, err
+	}
+	refund = new(Refund)
+	if err = c.doStripe(http.MethodPost, bm, refunds, idempotencyKey, refund); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
@@ -0,0 +1,96 @@
+package stripe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 校验 Stripe Webhook 签名并解析事件
+//	req：Webhook 请求
+//	endpointSecret：Webhook 端点签名密钥（whsec_ 开头）
+//	签名时间戳与当前时间相差超过 stripe.DefaultTolerance 秒时校验失败
+func VerifyWebhook(req *http.Request, endpointSecret string) (event *Event, err error) {
+	bs, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	return ConstructEvent(bs, req.Header.Get(HeaderSignature), endpointSecret, DefaultTolerance)
+}
+
+// 校验 Stripe Webhook 签名并解析事件
+//	payload：原始请求体，不可重新序列化
+//	sigHeader：Stripe-Signature 请求头，形如 t=1492774577,v1=5257a869...
+//	endpointSecret：Webhook 端点签名密钥
+//	tolerance：签名时间戳容差（秒），小于等于 0 时不校验时间戳
+func ConstructEvent(payload []byte, sigHeader, endpointSecret string, tolerance int64) (event *Event, err error) {
+	if err = verifySignature(payload, sigHeader, endpointSecret, tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	event = new(Event)
+	if err = json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(payload), err)
+	}
+	return event, nil
+}
+
+// verifySignature 签名：HMAC-SHA256(endpointSecret, t + "." + payload)，与任一 v1 签名相同即通过
+func verifySignature(payload []byte, sigHeader, endpointSecret string, tolerance int64, now time.Time) (err error) {
+	if sigHeader == "" {
+		return errors.New("stripe signature header : cannot be empty")
+	}
+	var (
+		timestamp string
+		signs     [][]byte
+	)
+	for _, item := range strings.Split(sigHeader, ",") {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			if sign, err := hex.DecodeString(kv[1]); err == nil {
+				signs = append(signs, sign)
+			}
+		}
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid stripe signature timestamp: %s", timestamp)
+	}
+	if len(signs) == 0 {
+		return errors.New("stripe signature header has no v1 signature")
+	}
+	h := hmac.New(sha256.New, []byte(endpointSecret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(payload)
+	expected := h.Sum(nil)
+	var matched bool
+	for _, sign := range signs {
+		if hmac.Equal(sign, expected) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return errors.New("stripe webhook verify sign failed")
+	}
+	if tolerance > 0 {
+		if d := now.Unix() - ts; d > tolerance || d < -tolerance {
+			return fmt.Errorf("stripe signature timestamp %d is outside the tolerance zone", ts)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package stripe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	secret := "whsec_test_secret"
+	payload := `{"id":"evt_1","object":"event","type":"payment_intent.succeeded","created":1672531200,"livemode":false,"data":{"object":{"id":"pi_1","object":"payment_intent","amount":2000,"amount_received":2000,"currency":"usd","status":"succeeded","metadata":{"order_id":"6735"}}}}`
+	now := time.Now()
+	ts := strconv.FormatInt(now.Unix(), 10)
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts + "." + payload))
+	sign := hex.EncodeToString(h.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	req.Header.Set(HeaderSignature, "t="+ts+",v1=deadbeef,v1="+sign+",v0=ignored")
+	event, err := VerifyWebhook(req, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != EventPaymentIntentSucceeded {
+		t.Fatalf("VerifyWebhook() = %+v", event)
+	}
+	pi, err := event.PaymentIntent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pi.Id != "pi_1" || pi.Amount != 2000 || pi.Status != StatusSucceeded || pi.Metadata["order_id"] != "6735" {
+		t.Fatalf("PaymentIntent() = %+v", pi)
+	}
+
+	tests := []struct {
+		name      string
+		payload   string
+		sigHeader string
+		now       time.Time
+	}{
+		{"modified payload", strings.Replace(payload, "2000", "1", 1), "t=" + ts + ",v1=" + sign, now},
+		{"wrong secret", payload, "t=" + ts + ",v1=" + hex.EncodeToString(hmac.New(sha256.New, []byte("other")).Sum(nil)), now},
+		{"no v1", payload, "t=" + ts + ",v0=" + sign, now},
+		{"expired", payload, "t=" + ts + ",v1=" + sign, now.Add(10 * time.Minute)},
+		{"empty", payload, "", now},
+	}
+	for _, tt := range tests {
+		if err = verifySignature([]byte(tt.payload), tt.sigHeader, secret, DefaultTolerance, tt.now); err == nil {
+			t.Errorf("%s: verifySignature() should return error", tt.name)
+		}
+	}
+}
+
+func TestClient_CreatePaymentIntent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk_test_123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"Invalid API Key provided"}}`))
+			return
+		}
+		switch r.URL.Path {
+		case paymentIntents:
+			if r.Header.Get("Idempotency-Key") != "order-6735" || r.FormValue("amount") != "2000" || r.FormValue("metadata[order_id]") != "6735" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"pi_1","object":"payment_intent","amount":2000,"currency":"usd","status":"requires_payment_method","client_secret":"pi_1_secret_abc","capture_method":"manual"}`))
+		case "/payment_intents/pi_1/capture":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"type":"invalid_request_error","code":"payment_intent_unexpected_state","message":"This PaymentIntent could not be captured."}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("sk_test_123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.apiUrl = ts.URL
+
+	bm := make(gopay.BodyMap)
+	bm.Set("amount", 2000).
+		Set("currency", "usd").
+		Set("capture_method", CaptureMethodManual).
+		Set("metadata[order_id]", "6735")
+	pi, err := c.CreatePaymentIntent(bm, "order-6735")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pi.Id != "pi_1" || pi.ClientSecret != "pi_1_secret_abc" || pi.Status != StatusRequiresPaymentMethod {
+		t.Fatalf("CreatePaymentIntent() = %+v", pi)
+	}
+
+	_, err = c.CapturePaymentIntent("pi_1", nil)
+	errRsp, ok := err.(*ErrorResponse)
+	if !ok || errRsp.HttpCode != http.StatusBadRequest || errRsp.Err.Code != "payment_intent_unexpected_state" {
+		t.Fatalf("CapturePaymentIntent() error = %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package unionpay
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/xlog"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// Client 银联全渠道支付客户端
+type Client struct {
+	MerId       string
+	IsProd      bool
+	DebugSwitch gopay.DebugSwitch
+
+	// QrSandboxHost 二维码产品测试环境地址，以银联分配的测试参数为准，为空时使用 gateway.test.95516.com
+	QrSandboxHost string
+
+	certId        string          // 商户签名证书序列号
+	privateKey    *rsa.PrivateKey // 商户签名私钥
+	roots         *x509.CertPool  // 银联根证书
+	intermediates *x509.CertPool  // 银联中级证书
+}
+
+// 初始化银联全渠道支付客户端
+//	merId：商户号
+//	pfxContent：商户签名证书（.pfx）文件内容
+//	pfxPwd：商户签名证书密码
+//	isProd：是否是正式环境，false 时请求测试环境 gateway.test.95516.com
+func NewClient(merId string, pfxContent []byte, pfxPwd string, isProd bool) (client *Client, err error) {
+	if merId == util.NULL {
+		return nil, errors.New("merId cannot be empty")
+	}
+	key, cert, err := pkcs12.Decode(pfxContent, pfxPwd)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs12.Decode：%w", err)
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("pfx private key is not rsa private key")
+	}
+	return &Client{
+		MerId:       merId,
+		IsProd:      isProd,
+		DebugSwitch: gopay.DebugOff,
+		certId:      cert.SerialNumber.String(),
+		privateKey:  privateKey,
+	}, nil
+}
+
+// 设置银联验签证书，设置后自动验签同步响应，client.VerifySign() 验签异步通知
+//	rootCert：银联根证书（acp_prod_root.cer / acp_test_root.cer）文件内容
+//	middleCert：银联中级证书（acp_prod_middle.cer / acp_test_middle.cer）文件内容
+func (c *Client) AutoVerifySign(rootCert, middleCert []byte) (err error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootCert) {
+		return errors.New("failed to parse unionpay root cert")
+	}
+	intermediates := x509.NewCertPool()
+	if !intermediates.AppendCertsFromPEM(middleCert) {
+		return errors.New("failed to parse unionpay middle cert")
+	}
+	c.roots, c.intermediates = roots, intermediates
+	return nil
+}
+
+// setCommonParams 设置公共请求参数并签名
+func (c *Client) setCommonParams(bm gopay.BodyMap) (err error) {
+	bm.Set("version", version).
+		Set("encoding", "UTF-8").
+		Set("signMethod", signMethodRSA).
+		Set("certId", c.certId)
+	if bm.GetString("merId") == util.NULL {
+		bm.Set("merId", c.MerId)
+	}
+	if bm.GetString("accessType") == util.NULL {
+		bm.Set("accessType", "0")
+	}
+	if bm.GetString("txnTime") == util.NULL {
+		bm.Set("txnTime", time.Now().Format("20060102150405"))
+	}
+	bm.Remove(signatureKey)
+	sign, err := getRsaSign(bm, c.privateKey)
+	if err != nil {
+		return fmt.Errorf("GetRsaSign Error: %w", err)
+	}
+	bm.Set(signatureKey, sign)
+	return nil
+}
+
+// 向银联发送后台请求
+func (c *Client) doUnionPay(bm gopay.BodyMap, uri string) (rsp gopay.BodyMap, err error) {
+	var url = baseUrlProd + uri
+	if !c.IsProd {
+		url = baseUrlSandbox + uri
+	}
+	return c.doUnionPayUrl(bm, url)
+}
+
+// 向银联二维码产品发送后台请求，测试环境使用 client.QrSandboxHost
+func (c *Client) doUnionPayQr(bm gopay.BodyMap, uri string) (rsp gopay.BodyMap, err error) {
+	var url = baseUrlProd + uri
+	if !c.IsProd {
+		url = baseUrlSandbox + uri
+		if c.QrSandboxHost != util.NULL {
+			url = strings.TrimSuffix(c.QrSandboxHost, "/") + uri
+		}
+	}
+	return c.doUnionPayUrl(bm, url)
+}
+
+func (c *Client) doUnionPayUrl(bm gopay.BodyMap, url string) (rsp gopay.BodyMap, err error) {
+	if err = c.setCommonParams(bm); err != nil {
+		return nil, err
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("UnionPay_Request: %s", bm.JsonBody())
+	}
+	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeForm).Post(url).SendString(bm.EncodeURLParams()).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("UnionPay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	}
+	rsp = parseResponse(string(bs))
+	// 应答码非 00 时银联可能不签名，应答码 00 的报文必须验签
+	if c.roots != nil && (rsp.GetString("respCode") == RespCodeSuccess || rsp.GetString(signatureKey) != util.NULL) {
+		if err = c.VerifySign(rsp); err != nil {
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+// parseResponse 解析银联应答报文 key1=value1&key2=value2，值中 {} 内的 & 和 = 不作为分隔符
+func parseResponse(body string) (bm gopay.BodyMap) {
+	bm = make(gopay.BodyMap)
+	var (
+		key   string
+		start int
+		depth int
+		inKey = true
+	)
+	for i := 0; i <= len(body); i++ {
+		if i == len(body) || (body[i] == '&' && depth == 0) {
+			if !inKey && key != "" {
+				bm.Set(key, body[start:i])
+			}
+			key, start, inKey = "", i+1, true
+			continue
+		}
+		switch body[i] {
+		case '=':
+			if inKey {
+				key, start, inKey = body[start:i], i+1, false
+			}
+		case '{', '[':
+			if !inKey {
+				depth++
+			}
+		case '}', ']':
+			if !inKey && depth > 0 {
+				depth--
+			}
+		}
+	}
+	return bm
+}
+
+// unmarshalResponse 将应答报文转换为响应结构体
+func unmarshalResponse(bm gopay.BodyMap, ptr interface{}) (err error) {
+	bs, err := json.Marshal(bm)
+	if err != nil {
+		return fmt.Errorf("json.Marshal：%w", err)
+	}
+	if err = json.Unmarshal(bs, ptr); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
+
+// buildAutoSubmitForm 生成自动提交到银联前台交易地址的 HTML 表单
+func buildAutoSubmitForm(action string, bm gopay.BodyMap) (html string) {
+	var buf strings.Builder
+	buf.WriteString(`<html><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8"/></head><body><form id="pay_form" name="pay_form" action="`)
+	buf.WriteString(htmlEscape(action))
+	buf.WriteString(`" method="post">`)
+	for k := range bm {
+		buf.WriteString(`<input type="hidden" name="`)
+		buf.WriteString(htmlEscape(k))
+		buf.WriteString(`" value="`)
+		buf.WriteString(htmlEscape(bm.GetString(k)))
+		buf.WriteString(`"/>`)
+	}
+	buf.WriteString(`</form><script type="text/javascript">document.pay_form.submit();</script></body></html>`)
+	return buf.String()
+}
+
+var htmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&#34;", "'", "&#39;")
+
+func htmlEscape(s string) string {
+	return htmlReplacer.Replace(s)
+}
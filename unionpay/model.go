@@ -0,0 +1,121 @@
+package unionpay
+
+const (
+	// URL
+	baseUrlProd    = "https://gateway.95516.com"            // 正式 URL
+	baseUrlSandbox = "https://gateway.test.95516.com"       // 测试 URL
+	fileUrlProd    = "https://filedownload.95516.com/"      // 正式 对账文件下载 URL
+	fileUrlSandbox = "https://filedownload.test.95516.com/" // 测试 对账文件下载 URL
+	frontTransReq  = "/gateway/api/frontTransReq.do"        // 前台交易请求（网关支付）
+	appTransReq    = "/gateway/api/appTransReq.do"          // APP交易请求
+	backTransReq   = "/gateway/api/backTransReq.do"         // 后台交易请求
+	queryTrans     = "/gateway/api/queryTrans.do"           // 交易状态查询
+
+	version             = "5.1.0"
+	signMethodRSA       = "01" // 证书签名（SHA-256 + RSA）
+	unionPayCompanyName = "中国银联股份有限公司"
+	signatureKey        = "signature"
+
+	// 应答码 respCode
+	RespCodeSuccess  = "00" // 成功
+	RespCodeTimeout  = "03" // 交易通讯超时，请发起查询交易
+	RespCodeUnknown  = "04" // 交易状态未明，请查询对账结果
+	RespCodeAccepted = "05" // 交易已受理，请稍后查询交易结果
+
+	// 渠道类型 channelType
+	ChannelTypePC     = "07" // 互联网
+	ChannelTypeMobile = "08" // 移动
+
+	// 产品类型 bizType
+	BizTypeB2C     = "000201" // B2C网关支付、手机支付
+	BizTypeDefault = "000000" // 查询、对账文件下载、二维码申码
+
+	// 对账文件类型 fileType
+	FileTypeNormal = "00" // 一般商户对账文件
+)
+
+// Response 全渠道交易响应公共参数
+type Response struct {
+	Version        string `json:"version,omitempty"`
+	Encoding       string `json:"encoding,omitempty"`
+	SignMethod     string `json:"signMethod,omitempty"`
+	TxnType        string `json:"txnType,omitempty"`
+	TxnSubType     string `json:"txnSubType,omitempty"`
+	BizType        string `json:"bizType,omitempty"`
+	AccessType     string `json:"accessType,omitempty"`
+	MerId          string `json:"merId,omitempty"`
+	OrderId        string `json:"orderId,omitempty"`
+	TxnTime        string `json:"txnTime,omitempty"`
+	RespCode       string `json:"respCode,omitempty"`
+	RespMsg        string `json:"respMsg,omitempty"`
+	Reserved       string `json:"reserved,omitempty"`
+	ReqReserved    string `json:"reqReserved,omitempty"`
+	Signature      string `json:"signature,omitempty"`
+	SignPubKeyCert string `json:"signPubKeyCert,omitempty"`
+}
+
+// IsSuccess 交易请求是否受理成功（respCode = 00）
+func (r *Response) IsSuccess() bool {
+	return r.RespCode == RespCodeSuccess
+}
+
+// AppPayResponse APP支付响应，tn 交给银联手机控件发起支付
+type AppPayResponse struct {
+	Response
+	Tn string `json:"tn,omitempty"`
+}
+
+// QrPayResponse 二维码申码（主扫）响应
+type QrPayResponse struct {
+	Response
+	QrCode string `json:"qrCode,omitempty"`
+}
+
+// QueryResponse 交易状态查询响应
+type QueryResponse struct {
+	Response
+	QueryId            string `json:"queryId,omitempty"`
+	TraceNo            string `json:"traceNo,omitempty"`
+	TraceTime          string `json:"traceTime,omitempty"`
+	SettleDate         string `json:"settleDate,omitempty"`
+	SettleAmt          string `json:"settleAmt,omitempty"`
+	SettleCurrencyCode string `json:"settleCurrencyCode,omitempty"`
+	TxnAmt             string `json:"txnAmt,omitempty"`
+	CurrencyCode       string `json:"currencyCode,omitempty"`
+	OrigRespCode       string `json:"origRespCode,omitempty"`
+	OrigRespMsg        string `json:"origRespMsg,omitempty"`
+	AccNo              string `json:"accNo,omitempty"`
+	PayType            string `json:"payType,omitempty"`
+	PayCardType        string `json:"payCardType,omitempty"`
+	IssuerIdentifyMode string `json:"issuerIdentifyMode,omitempty"`
+}
+
+// IsPaid 原交易是否成功（respCode = 00 且 origRespCode = 00）
+func (r *QueryResponse) IsPaid() bool {
+	return r.RespCode == RespCodeSuccess && r.OrigRespCode == RespCodeSuccess
+}
+
+// FileDownloadResponse 对账文件下载响应
+type FileDownloadResponse struct {
+	Response
+	SettleDate  string `json:"settleDate,omitempty"`
+	FileType    string `json:"fileType,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	FileContent string `json:"fileContent,omitempty"` // base64 编码的 deflate 压缩 zip 文件，可通过 rsp.DecodeFile() 解压
+}
+
+// QrRefundResponse 二维码退货响应
+type QrRefundResponse struct {
+	Response
+	QueryId   string `json:"queryId,omitempty"`
+	OrigQryId string `json:"origQryId,omitempty"`
+	TxnAmt    string `json:"txnAmt,omitempty"`
+}
+
+// QrCloseResponse 二维码关单（消费撤销）响应
+type QrCloseResponse struct {
+	Response
+	QueryId   string `json:"queryId,omitempty"`
+	OrigQryId string `json:"origQryId,omitempty"`
+	TxnAmt    string `json:"txnAmt,omitempty"`
+}
@@ -0,0 +1,140 @@
+package unionpay
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 网关支付（PC、WAP 消费）
+//	返回自动提交到银联支付页面的 HTML 表单，直接输出到浏览器即可
+//	必填：orderId、txnAmt（单位：分）、frontUrl、backUrl
+//	channelType 默认 07（互联网），WAP 支付请传 08（移动）
+func (c *Client) GatewayPay(bm gopay.BodyMap) (html string, err error) {
+	err = bm.CheckEmptyError("orderId", "txnAmt", "frontUrl", "backUrl")
+	if err != nil {
+		return util.NULL, err
+	}
+	bm.Set("txnType", "01").
+		Set("txnSubType", "01").
+		Set("bizType", BizTypeB2C)
+	if bm.GetString("channelType") == util.NULL {
+		bm.Set("channelType", ChannelTypePC)
+	}
+	if bm.GetString("currencyCode") == util.NULL {
+		bm.Set("currencyCode", "156")
+	}
+	if err = c.setCommonParams(bm); err != nil {
+		return util.NULL, err
+	}
+	url := baseUrlProd + frontTransReq
+	if !c.IsProd {
+		url = baseUrlSandbox + frontTransReq
+	}
+	return buildAutoSubmitForm(url, bm), nil
+}
+
+// APP支付（手机控件支付）
+//	返回的 tn 交给 App 调起银联手机支付控件
+//	必填：orderId、txnAmt（单位：分）、backUrl
+func (c *Client) AppPay(bm gopay.BodyMap) (upRsp *AppPayResponse, err error) {
+	err = bm.CheckEmptyError("orderId", "txnAmt", "backUrl")
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("txnType", "01").
+		Set("txnSubType", "01").
+		Set("bizType", BizTypeB2C).
+		Set("channelType", ChannelTypeMobile)
+	if bm.GetString("currencyCode") == util.NULL {
+		bm.Set("currencyCode", "156")
+	}
+	rsp, err := c.doUnionPay(bm, appTransReq)
+	if err != nil {
+		return nil, err
+	}
+	upRsp = new(AppPayResponse)
+	if err = unmarshalResponse(rsp, upRsp); err != nil {
+		return nil, err
+	}
+	return upRsp, nil
+}
+
+// 交易状态查询
+//	必填：orderId、txnTime（原交易的订单发送时间）
+//	upRsp.IsPaid() 为 true 时原交易成功
+func (c *Client) Query(bm gopay.BodyMap) (upRsp *QueryResponse, err error) {
+	err = bm.CheckEmptyError("orderId", "txnTime")
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("txnType", "00").
+		Set("txnSubType", "00").
+		Set("bizType", BizTypeDefault)
+	rsp, err := c.doUnionPay(bm, queryTrans)
+	if err != nil {
+		return nil, err
+	}
+	upRsp = new(QueryResponse)
+	if err = unmarshalResponse(rsp, upRsp); err != nil {
+		return nil, err
+	}
+	return upRsp, nil
+}
+
+// 对账文件下载
+//	settleDate：清算日期，格式 MMDD
+//	fileType：文件类型，一般商户传 unionpay.FileTypeNormal
+//	返回 upRsp.FileName 及 解压后的 zip 文件内容 file
+func (c *Client) FileDownload(settleDate, fileType string) (upRsp *FileDownloadResponse, file []byte, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("txnType", "76").
+		Set("txnSubType", "01").
+		Set("bizType", BizTypeDefault).
+		Set("settleDate", settleDate).
+		Set("fileType", fileType)
+	if err = bm.CheckEmptyError("settleDate", "fileType"); err != nil {
+		return nil, nil, err
+	}
+	url := fileUrlProd
+	if !c.IsProd {
+		url = fileUrlSandbox
+	}
+	rsp, err := c.doUnionPayUrl(bm, url)
+	if err != nil {
+		return nil, nil, err
+	}
+	upRsp = new(FileDownloadResponse)
+	if err = unmarshalResponse(rsp, upRsp); err != nil {
+		return nil, nil, err
+	}
+	if !upRsp.IsSuccess() {
+		return upRsp, nil, nil
+	}
+	if file, err = upRsp.DecodeFile(); err != nil {
+		return nil, nil, err
+	}
+	return upRsp, file, nil
+}
+
+// DecodeFile 解码并解压 fileContent，返回 zip 文件内容
+func (r *FileDownloadResponse) DecodeFile() (file []byte, err error) {
+	bs, err := base64.StdEncoding.DecodeString(r.FileContent)
+	if err != nil {
+		return nil, fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(bs))
+	if err != nil {
+		return nil, fmt.Errorf("zlib.NewReader：%w", err)
+	}
+	defer zr.Close()
+	if file, err = ioutil.ReadAll(zr); err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,101 @@
+package unionpay
+
+import (
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 二维码支付（申码，消费者主扫）
+//	返回的 qrCode 生成二维码供消费者使用云闪付或银行 App 扫码支付
+//	必填：orderId、txnAmt（单位：分）、backUrl
+//	测试环境请求 client.QrSandboxHost
+func (c *Client) QrPay(bm gopay.BodyMap) (upRsp *QrPayResponse, err error) {
+	err = bm.CheckEmptyError("orderId", "txnAmt", "backUrl")
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("txnType", "01").
+		Set("txnSubType", "07").
+		Set("bizType", BizTypeDefault).
+		Set("channelType", ChannelTypeMobile)
+	if bm.GetString("currencyCode") == util.NULL {
+		bm.Set("currencyCode", "156")
+	}
+	rsp, err := c.doUnionPayQr(bm, backTransReq)
+	if err != nil {
+		return nil, err
+	}
+	upRsp = new(QrPayResponse)
+	if err = unmarshalResponse(rsp, upRsp); err != nil {
+		return nil, err
+	}
+	return upRsp, nil
+}
+
+// 二维码交易状态查询
+//	必填：orderId、txnTime（原交易的订单发送时间）
+//	upRsp.IsPaid() 为 true 时原交易成功
+func (c *Client) QrQuery(bm gopay.BodyMap) (upRsp *QueryResponse, err error) {
+	err = bm.CheckEmptyError("orderId", "txnTime")
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("txnType", "00").
+		Set("txnSubType", "00").
+		Set("bizType", BizTypeDefault)
+	rsp, err := c.doUnionPayQr(bm, queryTrans)
+	if err != nil {
+		return nil, err
+	}
+	upRsp = new(QueryResponse)
+	if err = unmarshalResponse(rsp, upRsp); err != nil {
+		return nil, err
+	}
+	return upRsp, nil
+}
+
+// 二维码退货
+//	必填：orderId（退货订单号）、origQryId（原消费交易的 queryId）、txnAmt（退货金额，单位：分）、backUrl
+//	退货结果以异步通知或 client.QrQuery() 查询为准
+func (c *Client) QrRefund(bm gopay.BodyMap) (upRsp *QrRefundResponse, err error) {
+	err = bm.CheckEmptyError("orderId", "origQryId", "txnAmt", "backUrl")
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("txnType", "04").
+		Set("txnSubType", "00").
+		Set("bizType", BizTypeDefault).
+		Set("channelType", ChannelTypeMobile)
+	rsp, err := c.doUnionPayQr(bm, backTransReq)
+	if err != nil {
+		return nil, err
+	}
+	upRsp = new(QrRefundResponse)
+	if err = unmarshalResponse(rsp, upRsp); err != nil {
+		return nil, err
+	}
+	return upRsp, nil
+}
+
+// 二维码关单（消费撤销）
+//	仅限当日（清算前）的消费交易，已支付的订单资金原路退回
+//	必填：orderId（撤销订单号）、origQryId（原消费交易的 queryId）、txnAmt（原消费金额，单位：分）、backUrl
+func (c *Client) QrClose(bm gopay.BodyMap) (upRsp *QrCloseResponse, err error) {
+	err = bm.CheckEmptyError("orderId", "origQryId", "txnAmt", "backUrl")
+	if err != nil {
+		return nil, err
+	}
+	bm.Set("txnType", "31").
+		Set("txnSubType", "00").
+		Set("bizType", BizTypeDefault).
+		Set("channelType", ChannelTypeMobile)
+	rsp, err := c.doUnionPayQr(bm, backTransReq)
+	if err != nil {
+		return nil, err
+	}
+	upRsp = new(QrCloseResponse)
+	if err = unmarshalResponse(rsp, upRsp); err != nil {
+		return nil, err
+	}
+	return upRsp, nil
+}
@@ -0,0 +1,81 @@
+package unionpay
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestClient_QrRefund(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != backTransReq {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil || r.Form.Get("txnType") != "04" || r.Form.Get("bizType") != BizTypeDefault || r.Form.Get("signature") == "" {
+			t.Errorf("form = %v, %v", r.Form, err)
+		}
+		_, _ = w.Write([]byte("respCode=00&respMsg=成功[0000000]&orderId=" + r.Form.Get("orderId") + "&origQryId=" + r.Form.Get("origQryId") + "&queryId=782210281010010000000&reqReserved={a=1&b=2}"))
+	}))
+	defer srv.Close()
+
+	c := &Client{MerId: "777290058110048", certId: "69629715588", privateKey: key, QrSandboxHost: srv.URL + "/"}
+	bm := make(gopay.BodyMap)
+	bm.Set("orderId", "20221028100102").
+		Set("origQryId", "782210281010000000000").
+		Set("txnAmt", "1").
+		Set("backUrl", "https://www.fmm.ink/unionpay/notify")
+	upRsp, err := c.QrRefund(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !upRsp.IsSuccess() || upRsp.QueryId != "782210281010010000000" || upRsp.OrigQryId != "782210281010000000000" || upRsp.ReqReserved != "{a=1&b=2}" {
+		t.Errorf("upRsp = %+v", upRsp)
+	}
+}
+
+func TestClient_QrRefundUnsignedResponse(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	c := &Client{MerId: "777290058110048", certId: "69629715588", privateKey: key, QrSandboxHost: srv.URL + "/", roots: x509.NewCertPool()}
+	newBm := func() gopay.BodyMap {
+		bm := make(gopay.BodyMap)
+		bm.Set("orderId", "20221028100102").
+			Set("origQryId", "782210281010000000000").
+			Set("txnAmt", "1").
+			Set("backUrl", "https://www.fmm.ink/unionpay/notify")
+		return bm
+	}
+
+	// 设置验签证书后，未签名的成功应答须验签失败
+	body = "respCode=00&respMsg=成功[0000000]&orderId=20221028100102&queryId=782210281010010000000"
+	if _, err = c.QrRefund(newBm()); err == nil {
+		t.Error("QrRefund() with unsigned success response should return error")
+	}
+
+	// 未签名的失败应答直接返回
+	body = "respCode=12&respMsg=重复交易[1000012]&orderId=20221028100102"
+	upRsp, err := c.QrRefund(newBm())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upRsp.IsSuccess() || upRsp.RespCode != "12" {
+		t.Errorf("upRsp = %+v", upRsp)
+	}
+}
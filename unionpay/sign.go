@@ -0,0 +1,114 @@
+package unionpay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// getSignData 签名原文：除 signature 外的参数按 key 排序拼接为 key1=value1&key2=value2，再取 SHA-256 十六进制小写
+func getSignData(bm gopay.BodyMap) (signData []byte) {
+	bm = bm.Clone()
+	bm.Remove(signatureKey)
+	h := sha256.Sum256([]byte(bm.EncodeAliPaySignParams()))
+	return []byte(hex.EncodeToString(h[:]))
+}
+
+// getRsaSign 5.1.0 版本证书签名：SHA256withRSA(SHA-256(签名原文))，base64 编码
+func getRsaSign(bm gopay.BodyMap, privateKey *rsa.PrivateKey) (sign string, err error) {
+	if privateKey == nil {
+		return util.NULL, errors.New("unionpay private key is nil")
+	}
+	h := sha256.Sum256(getSignData(bm))
+	signBytes, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, h[:])
+	if err != nil {
+		return util.NULL, err
+	}
+	return base64.StdEncoding.EncodeToString(signBytes), nil
+}
+
+// 解析银联异步通知的参数到BodyMap
+//	req：*http.Request
+//	返回参数bm：Notify请求的参数
+//	返回参数err：错误信息
+func ParseNotifyToBodyMap(req *http.Request) (bm gopay.BodyMap, err error) {
+	if err = req.ParseForm(); err != nil {
+		return nil, err
+	}
+	bm = make(gopay.BodyMap, len(req.Form))
+	for k, v := range req.Form {
+		if len(v) == 1 {
+			bm.Set(k, v[0])
+		}
+	}
+	return bm, nil
+}
+
+// 银联同步响应、异步通知验签
+//	注意：需先调用 client.AutoVerifySign() 设置银联根证书及中级证书
+//	bm：银联响应或通知参数，异步通知使用 unionpay.ParseNotifyToBodyMap() 解析
+//	使用报文中的 signPubKeyCert 验签，验签前校验该证书由银联根证书签发且属于银联
+func (c *Client) VerifySign(bm gopay.BodyMap) (err error) {
+	if c.roots == nil {
+		return errors.New("unionpay verify cert is not set, please call client.AutoVerifySign() first")
+	}
+	sign := bm.GetString(signatureKey)
+	if sign == util.NULL {
+		return errors.New("signature : cannot be empty")
+	}
+	pubKey, err := c.verifySignPubKeyCert(bm.GetString("signPubKeyCert"))
+	if err != nil {
+		return err
+	}
+	signBytes, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString：%w", err)
+	}
+	h := sha256.Sum256(getSignData(bm))
+	if err = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, h[:], signBytes); err != nil {
+		return fmt.Errorf("unionpay verify sign failed：%w", err)
+	}
+	return nil
+}
+
+// verifySignPubKeyCert 校验报文中的银联签名证书
+func (c *Client) verifySignPubKeyCert(certPem string) (pubKey *rsa.PublicKey, err error) {
+	block, _ := pem.Decode([]byte(certPem))
+	if block == nil {
+		return nil, errors.New("signPubKeyCert pem.Decode() return nil")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("x509.ParseCertificate：%w", err)
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		Roots:         c.roots,
+		Intermediates: c.intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("verify signPubKeyCert：%w", err)
+	}
+	// 证书 CN 形如：CFCA@中国银联股份有限公司@00040000:SIGN@00000002，测试环境不校验
+	if c.IsProd {
+		if cn := strings.Split(cert.Subject.CommonName, "@"); len(cn) < 2 || cn[1] != unionPayCompanyName {
+			return nil, fmt.Errorf("signPubKeyCert is not issued to %s", unionPayCompanyName)
+		}
+	}
+	pubKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signPubKeyCert is not rsa public key")
+	}
+	return pubKey, nil
+}
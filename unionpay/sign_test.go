@@ -0,0 +1,129 @@
+package unionpay
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestClient_VerifySign(t *testing.T) {
+	newCert := func(cn string, serial int64, isCA bool, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, []byte) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(24 * time.Hour),
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+		}
+		if isCA {
+			tpl.KeyUsage = x509.KeyUsageCertSign
+		}
+		if parent == nil {
+			parent, parentKey = tpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	root, rootKey, rootPem := newCert("CFCA ACS TEST OCA31", 1, true, nil, nil)
+	middle, middleKey, middlePem := newCert("CFCA ACS TEST OCA32", 2, true, root, rootKey)
+	_, signKey, signPem := newCert("CFCA@中国银联股份有限公司@00040000:SIGN@00000002", 3, false, middle, middleKey)
+	_, otherKey, otherPem := newCert("CFCA@其他公司@00040000:SIGN@00000002", 4, false, middle, middleKey)
+
+	c := &Client{MerId: "777290058110048", IsProd: true, certId: "69629715588", privateKey: signKey}
+	if err := c.AutoVerifySign(rootPem, middlePem); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟银联应答报文
+	bm := make(gopay.BodyMap)
+	bm.Set("orderId", "20221028100101").
+		Set("respCode", RespCodeSuccess).
+		Set("respMsg", "成功[0000000]").
+		Set("tn", "784519436578273719401").
+		Set("reqReserved", "{a=1&b=2}").
+		Set("signPubKeyCert", string(signPem))
+	if err := c.setCommonParams(bm); err != nil {
+		t.Fatal(err)
+	}
+	if bm.GetString("certId") != "69629715588" || bm.GetString("signature") == "" {
+		t.Fatalf("bm = %v", bm)
+	}
+	if err := c.VerifySign(bm); err != nil {
+		t.Fatal(err)
+	}
+
+	// 应答报文解析
+	var body string
+	for k := range bm {
+		if body != "" {
+			body += "&"
+		}
+		body += k + "=" + bm.GetString(k)
+	}
+	rsp := parseResponse(body)
+	if rsp.GetString("reqReserved") != "{a=1&b=2}" || rsp.GetString("signPubKeyCert") != string(signPem) {
+		t.Errorf("parseResponse() = %v", rsp)
+	}
+	if err := c.VerifySign(rsp); err != nil {
+		t.Fatal(err)
+	}
+	upRsp := new(AppPayResponse)
+	if err := unmarshalResponse(rsp, upRsp); err != nil {
+		t.Fatal(err)
+	}
+	if !upRsp.IsSuccess() || upRsp.Tn != "784519436578273719401" {
+		t.Errorf("upRsp = %+v", upRsp)
+	}
+
+	// 报文被篡改
+	rsp.Set("tn", "784519436578273719402")
+	if err := c.VerifySign(rsp); err == nil {
+		t.Error("VerifySign() with modified body should return error")
+	}
+	// 非银联证书签名
+	c.privateKey = otherKey
+	bm.Set("signPubKeyCert", string(otherPem))
+	if err := c.setCommonParams(bm); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifySign(bm); err == nil {
+		t.Error("VerifySign() with non-unionpay cert should return error")
+	}
+}
+
+func TestFileDownloadResponse_DecodeFile(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write([]byte("PK-zip-content"))
+	_ = zw.Close()
+	r := &FileDownloadResponse{FileName: "RD0000_1028_777290058110048.zip", FileContent: base64.StdEncoding.EncodeToString(buf.Bytes())}
+	file, err := r.DecodeFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(file) != "PK-zip-content" {
+		t.Errorf("DecodeFile() = %s", file)
+	}
+}
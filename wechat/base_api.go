@@ -15,157 +15,214 @@ import (
 // 统一下单
 //
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter3_1.shtml
-func (w *Client) UnifiedOrder(ctx context.Context, bm gopay.BodyMap) (wxRsp *UnifiedOrderResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) UnifiedOrderWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *UnifiedOrderResponse, res *APIResult, err error) {
 	// err = bm.CheckEmptyError("nonce_str", "body", "out_trade_no", "total_fee", "spbill_create_ip", "notify_url", "trade_type")
 	// if err != nil {
-	// 	return nil, nil, "", 0, nil, err
+	// 	return nil, nil, err
 	// }
+	if bm.GetString("trade_type") != util.NULL {
+		if err = checkEnumParam(bm, "trade_type", "https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter3_1.shtml", tradeTypes); err != nil {
+			return nil, nil, err
+		}
+	}
+	res = new(APIResult)
 	if w.IsProd {
-		bs, url, statusCode, header, err = w.doProdPost(ctx, bm, unifiedOrder, nil)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, unifiedOrder, nil)
 	} else {
-		bm.Set("total_fee", 101)
-		bs, url, statusCode, header, err = w.doSanBoxPost(ctx, bm, sandboxUnifiedOrder)
+		if !w.DisableSandboxFeeOverride {
+			bm = bm.Clone().Set("total_fee", 101)
+		}
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doSanBoxPost(ctx, bm, sandboxUnifiedOrder)
 	}
 	if err != nil {
-		return nil, nil, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(UnifiedOrderResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, nil, url, statusCode, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.Unmarshal(%s)：%w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.UnifiedOrderWithResult()
+func (w *Client) UnifiedOrder(ctx context.Context, bm gopay.BodyMap) (wxRsp *UnifiedOrderResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.UnifiedOrderWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
 
 // 提交付款码支付
 //
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter4_1.shtml
-func (w *Client) Micropay(ctx context.Context, bm gopay.BodyMap) (wxRsp *MicropayResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) MicropayWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *MicropayResponse, res *APIResult, err error) {
 	// err = bm.CheckEmptyError("nonce_str", "body", "out_trade_no", "total_fee", "spbill_create_ip", "auth_code")
 	// if err != nil {
-	// 	return nil, nil, "", 0, nil, err
+	// 	return nil, nil, err
 	// }
+	res = new(APIResult)
 	if w.IsProd {
-		bs, url, statusCode, header, err = w.doProdPost(ctx, bm, microPay, nil)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, microPay, nil)
 	} else {
-		bm.Set("total_fee", 1)
-		bs, url, statusCode, header, err = w.doSanBoxPost(ctx, bm, sandboxMicroPay)
+		if !w.DisableSandboxFeeOverride {
+			bm = bm.Clone().Set("total_fee", 1)
+		}
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doSanBoxPost(ctx, bm, sandboxMicroPay)
 	}
 	if err != nil {
-		return nil, nil, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(MicropayResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, bs, url, statusCode, header, fmt.Errorf("xml.Unmarshal(%s): %w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.Unmarshal(%s): %w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.MicropayWithResult()
+func (w *Client) Micropay(ctx context.Context, bm gopay.BodyMap) (wxRsp *MicropayResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.MicropayWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
 
 // 查询订单
 //
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter3_2.shtml
-func (w *Client) QueryOrder(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryOrderResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) QueryOrderWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryOrderResponse, res *APIResult, err error) {
 	err = bm.CheckEmptyError("nonce_str")
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
 	if bm.GetString("out_trade_no") == util.NULL && bm.GetString("transaction_id") == util.NULL {
-		return nil, nil, "", 0, nil, errors.New("out_trade_no and transaction_id are not allowed to be null at the same time")
+		return nil, nil, errors.New("out_trade_no and transaction_id are not allowed to be null at the same time")
 	}
+	res = new(APIResult)
 	if w.IsProd {
-		bs, url, statusCode, header, err = w.doProdPost(ctx, bm, orderQuery, nil)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, orderQuery, nil)
 	} else {
-		bs, url, statusCode, header, err = w.doSanBoxPost(ctx, bm, sandboxOrderQuery)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doSanBoxPost(ctx, bm, sandboxOrderQuery)
 	}
 	if err != nil {
-		return nil, nil, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(QueryOrderResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, nil, url, statusCode, header, fmt.Errorf("xml.UnmarshalStruct(%s)：%w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.UnmarshalStruct(%s)：%w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.QueryOrderWithResult()
+func (w *Client) QueryOrder(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryOrderResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.QueryOrderWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
 
 // 关闭订单
 //
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter3_3.shtml
-func (w *Client) CloseOrder(ctx context.Context, bm gopay.BodyMap) (wxRsp *CloseOrderResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) CloseOrderWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *CloseOrderResponse, res *APIResult, err error) {
 	err = bm.CheckEmptyError("nonce_str", "out_trade_no")
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
+	res = new(APIResult)
 	if w.IsProd {
-		bs, url, statusCode, header, err = w.doProdPost(ctx, bm, closeOrder, nil)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, closeOrder, nil)
 	} else {
-		bs, url, statusCode, header, err = w.doSanBoxPost(ctx, bm, sandboxCloseOrder)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doSanBoxPost(ctx, bm, sandboxCloseOrder)
 	}
 	if err != nil {
-		return nil, nil, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(CloseOrderResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, nil, url, statusCode, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.Unmarshal(%s)：%w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.CloseOrderWithResult()
+func (w *Client) CloseOrder(ctx context.Context, bm gopay.BodyMap) (wxRsp *CloseOrderResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.CloseOrderWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
 
 // 申请退款
 //
 //	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter3_4.shtml
-func (w *Client) Refund(ctx context.Context, bm gopay.BodyMap) (wxRsp *RefundResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) RefundWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *RefundResponse, res *APIResult, err error) {
 	err = bm.CheckEmptyError("nonce_str", "out_refund_no", "total_fee", "refund_fee")
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
 	if bm.GetString("out_trade_no") == util.NULL && bm.GetString("transaction_id") == util.NULL {
-		return nil, nil, "", 0, nil, errors.New("out_trade_no and transaction_id are not allowed to be null at the same time")
+		return nil, nil, errors.New("out_trade_no and transaction_id are not allowed to be null at the same time")
 	}
 	var (
 		tlsConfig *tls.Config
 	)
+	res = new(APIResult)
 	if w.IsProd {
 		if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
-			return nil, nil, "", 0, nil, err
+			return nil, nil, err
 		}
-		bs, url, statusCode, header, err = w.doProdPost(ctx, bm, refund, tlsConfig)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, refund, tlsConfig)
 	} else {
-		bs, url, statusCode, header, err = w.doSanBoxPost(ctx, bm, sandboxRefund)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doSanBoxPost(ctx, bm, sandboxRefund)
 	}
 	if err != nil {
-		return nil, nil, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(RefundResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, nil, url, statusCode, header, fmt.Errorf("xml.UnmarshalStruct(%s)：%w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.UnmarshalStruct(%s)：%w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.RefundWithResult()
+func (w *Client) Refund(ctx context.Context, bm gopay.BodyMap) (wxRsp *RefundResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.RefundWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
 
 // 查询退款
 //
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter3_5.shtml
-func (w *Client) QueryRefund(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryRefundResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) QueryRefundWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryRefundResponse, res *APIResult, err error) {
 	err = bm.CheckEmptyError("nonce_str")
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
 	if bm.GetString("refund_id") == util.NULL && bm.GetString("out_refund_no") == util.NULL && bm.GetString("transaction_id") == util.NULL && bm.GetString("out_trade_no") == util.NULL {
-		return nil, nil, "", 0, nil, errors.New("refund_id, out_refund_no, out_trade_no, transaction_id are not allowed to be null at the same time")
+		return nil, nil, errors.New("refund_id, out_refund_no, out_trade_no, transaction_id are not allowed to be null at the same time")
 	}
+	res = new(APIResult)
 	if w.IsProd {
-		bs, url, statusCode, header, err = w.doProdPost(ctx, bm, refundQuery, nil)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, refundQuery, nil)
 	} else {
-		bs, url, statusCode, header, err = w.doSanBoxPost(ctx, bm, sandboxRefundQuery)
+		res.Raw, res.URL, res.StatusCode, res.Header, err = w.doSanBoxPost(ctx, bm, sandboxRefundQuery)
 	}
 	if err != nil {
-		return nil, nil, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(QueryRefundResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, nil, url, statusCode, header, fmt.Errorf("xml.UnmarshalStruct(%s)：%w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.UnmarshalStruct(%s)：%w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.QueryRefundWithResult()
+func (w *Client) QueryRefund(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryRefundResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.QueryRefundWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
 
 // 撤销订单
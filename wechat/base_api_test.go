@@ -2,6 +2,10 @@ package wechat
 
 import (
 	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
@@ -176,3 +180,37 @@ func TestClient_Reverse(t *testing.T) {
 	}
 	xlog.Debug("Response:", wxRsp)
 }
+
+func TestClient_DisableSandboxFeeOverride(t *testing.T) {
+	var reqBm gopay.BodyMap
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := ioutil.ReadAll(r.Body)
+		reqBm = make(gopay.BodyMap)
+		_ = xml.Unmarshal(bs, &reqBm)
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, false)
+	c.BaseURL = ts.URL + "/"
+
+	// 预先设置 sign，跳过沙箱秘钥获取
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("total_fee", 552).
+		Set("sign", "SANDBOX_SIGN")
+	if _, _, err := c.UnifiedOrderWithResult(context.Background(), bm); err != nil {
+		t.Fatal(err)
+	}
+	if reqBm.GetString("total_fee") != "101" || bm.GetString("total_fee") != "552" {
+		t.Fatalf("total_fee = %s, caller total_fee = %s", reqBm.GetString("total_fee"), bm.GetString("total_fee"))
+	}
+
+	c.DisableSandboxFeeOverride = true
+	if _, _, err := c.UnifiedOrderWithResult(context.Background(), bm); err != nil {
+		t.Fatal(err)
+	}
+	if reqBm.GetString("total_fee") != "552" {
+		t.Fatalf("total_fee = %s, want 552", reqBm.GetString("total_fee"))
+	}
+}
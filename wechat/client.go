@@ -12,20 +12,43 @@ import (
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/util"
-	"github.com/cedarwu/gopay/pkg/xhttp"
 	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
+// ErrSandboxUnsupported 接口无对应的沙箱环境，client 为沙箱环境（isProd = false）时返回此错误，不会请求正式环境
+var ErrSandboxUnsupported = errors.New("wechat: api does not support sandbox environment")
+
 type Client struct {
 	AppId       string
 	MchId       string
+	SubAppId    string // 服务商模式：子商户公众账号ID，请求时 bm 中未设置 sub_appid 则使用此值
+	SubMchId    string // 服务商模式：子商户号，请求时 bm 中未设置 sub_mch_id 则使用此值
 	ApiKey      string
 	BaseURL     string
 	IsProd      bool
 	HttpClient  *http.Client
 	DebugSwitch gopay.DebugSwitch
-	certificate *tls.Certificate
-	mu          sync.RWMutex
+	// 沙箱环境下，统一下单、付款码支付默认将 total_fee 改写为沙箱用例金额（101、1），设为 true 则保留调用方传入的金额
+	DisableSandboxFeeOverride bool
+	certificate               *tls.Certificate
+	middlewares               []Middleware
+	secondKey                 string
+	mu                        sync.RWMutex
+}
+
+// APIResult 微信V2接口请求的原始响应信息
+type APIResult struct {
+	Raw        []byte      // 原始响应Body
+	URL        string      // 请求地址
+	StatusCode int         // HTTP状态码
+	Header     http.Header // 响应Header
+}
+
+func (r *APIResult) unpack() (bs []byte, url string, statusCode int, header http.Header) {
+	if r == nil {
+		return nil, "", 0, nil
+	}
+	return r.Raw, r.URL, r.StatusCode, r.Header
 }
 
 // 初始化微信客户端 V2
@@ -55,11 +78,56 @@ func NewClientFromHttpClient(appId, mchId, apiKey string, isProd bool, httpClien
 	}
 }
 
+// Clone 复制一个新的 client，与原 client 共用 HttpClient 及证书
+//
+//	复制后修改新 client 的 AppId、MchId 等字段，不影响原 client
+func (w *Client) Clone() (client *Client) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	client = &Client{
+		AppId:       w.AppId,
+		MchId:       w.MchId,
+		SubAppId:    w.SubAppId,
+		SubMchId:    w.SubMchId,
+		ApiKey:      w.ApiKey,
+		BaseURL:     w.BaseURL,
+		IsProd:      w.IsProd,
+		HttpClient:  w.HttpClient,
+		DebugSwitch: w.DebugSwitch,
+		certificate: w.certificate,
+		secondKey:   w.secondKey,
+
+		DisableSandboxFeeOverride: w.DisableSandboxFeeOverride,
+	}
+	client.middlewares = append(client.middlewares, w.middlewares...)
+	return client
+}
+
+// CloneWithSubMerchant 服务商模式下，复制一个指定子商户的 client，与原 client 共用 HttpClient 及证书
+//
+//	subAppId：子商户公众账号ID，可为空
+//	subMchId：子商户号
+//	注意：复制代价很小，可按请求创建，并发安全
+func (w *Client) CloneWithSubMerchant(subAppId, subMchId string) (client *Client) {
+	client = w.Clone()
+	client.SubAppId = subAppId
+	client.SubMchId = subMchId
+	return client
+}
+
 // 向微信发送Post请求，对于本库未提供的微信API，可自行实现，通过此方法发送请求
 //
 //	bm：请求参数的BodyMap
 //	path：接口地址去掉baseURL的path，例如：url为https://api.mch.weixin.qq.com/pay/micropay，只需传 pay/micropay
 //	tlsConfig：tls配置，如无需证书请求，传nil
+//	注意：沙箱环境返回 ErrSandboxUnsupported
+func (w *Client) PostWeChatAPISelfWithResult(ctx context.Context, bm gopay.BodyMap, path string, tlsConfig *tls.Config) (res *APIResult, err error) {
+	res = new(APIResult)
+	res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, path, tlsConfig)
+	return res, err
+}
+
+// Deprecated: 推荐使用 client.PostWeChatAPISelfWithResult()
 func (w *Client) PostWeChatAPISelf(ctx context.Context, bm gopay.BodyMap, path string, tlsConfig *tls.Config) (bs []byte, url string, statusCode int, header http.Header, err error) {
 	return w.doProdPost(ctx, bm, path, tlsConfig)
 }
@@ -67,21 +135,29 @@ func (w *Client) PostWeChatAPISelf(ctx context.Context, bm gopay.BodyMap, path s
 // 授权码查询openid（正式）
 //
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter4_8.shtml
-func (w *Client) AuthCodeToOpenId(ctx context.Context, bm gopay.BodyMap) (wxRsp *AuthCodeToOpenIdResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) AuthCodeToOpenIdWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *AuthCodeToOpenIdResponse, res *APIResult, err error) {
 	err = bm.CheckEmptyError("nonce_str", "auth_code")
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
 
-	bs, url, statusCode, header, err = w.doProdPost(ctx, bm, authCodeToOpenid, nil)
+	res = new(APIResult)
+	res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, authCodeToOpenid, nil)
 	if err != nil {
-		return nil, bs, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(AuthCodeToOpenIdResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, bs, url, statusCode, header, fmt.Errorf("xml.Unmarshal(%s): %w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.Unmarshal(%s): %w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.AuthCodeToOpenIdWithResult()
+func (w *Client) AuthCodeToOpenId(ctx context.Context, bm gopay.BodyMap) (wxRsp *AuthCodeToOpenIdResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.AuthCodeToOpenIdWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
 
 // 下载对账单
@@ -92,9 +168,8 @@ func (w *Client) DownloadBill(bm gopay.BodyMap) (wxRsp string, header http.Heade
 	if err != nil {
 		return util.NULL, nil, err
 	}
-	billType := bm.GetString("bill_type")
-	if billType != "ALL" && billType != "SUCCESS" && billType != "REFUND" && billType != "RECHARGE_REFUND" {
-		return util.NULL, nil, errors.New("bill_type error, please reference: https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_6")
+	if err = checkEnumParam(bm, "bill_type", "https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_6", billTypes); err != nil {
+		return util.NULL, nil, err
 	}
 	var bs []byte
 	if w.IsProd {
@@ -118,9 +193,9 @@ func (w *Client) DownloadFundFlow(bm gopay.BodyMap) (wxRsp string, header http.H
 	if err != nil {
 		return util.NULL, nil, err
 	}
-	accountType := bm.GetString("account_type")
-	if accountType != "Basic" && accountType != "Operation" && accountType != "Fees" {
-		return util.NULL, nil, errors.New("account_type error, please reference: https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_18&index=7")
+	bm = bm.Clone()
+	if err = checkEnumParam(bm, "account_type", "https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_18&index=7", accountTypes); err != nil {
+		return util.NULL, nil, err
 	}
 	bm.Set("sign_type", SignType_HMAC_SHA256)
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
@@ -148,6 +223,22 @@ func (w *Client) Report(bm gopay.BodyMap) (wxRsp *ReportResponse, header http.He
 	if err != nil {
 		return nil, nil, err
 	}
+	return w.doReport(bm)
+}
+
+// 交易保障（付款码支付批量上报）
+//
+//	trades：交易列表的JSON字符串，推荐使用 client.NewReporter() 的 reporter.SubmitTrade() 异步批量上报
+//	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/open/chapter4_9.shtml
+func (w *Client) ReportMicropay(bm gopay.BodyMap) (wxRsp *ReportResponse, header http.Header, err error) {
+	err = bm.CheckEmptyError("nonce_str", "interface_url", "user_ip", "trades")
+	if err != nil {
+		return nil, nil, err
+	}
+	return w.doReport(bm)
+}
+
+func (w *Client) doReport(bm gopay.BodyMap) (wxRsp *ReportResponse, header http.Header, err error) {
 	var bs []byte
 	if w.IsProd {
 		bs, _, _, header, err = w.doProdPost(context.Background(), bm, report, nil)
@@ -174,6 +265,7 @@ func (w *Client) BatchQueryComment(bm gopay.BodyMap) (wxRsp string, header http.
 	if err != nil {
 		return util.NULL, nil, err
 	}
+	bm = bm.Clone()
 	bm.Set("sign_type", SignType_HMAC_SHA256)
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
 	if err != nil {
@@ -187,10 +279,14 @@ func (w *Client) BatchQueryComment(bm gopay.BodyMap) (wxRsp string, header http.
 }
 
 // doSanBoxPost sanbox环境post请求
+//
+//	注意：appid、mch_id、sign_type、sign 等字段写入 bm 的副本，不会修改调用方传入的 bm
 func (w *Client) doSanBoxPost(ctx context.Context, bm gopay.BodyMap, path string) (bs []byte, url string, statusCode int, header http.Header, err error) {
+	bm = bm.Clone()
 	url = baseUrlCh + path
 	bm.Set("appid", w.AppId)
 	bm.Set("mch_id", w.MchId)
+	w.setSubMerchant(bm)
 
 	if bm.GetString("sign") == util.NULL {
 		bm.Set("sign_type", SignType_MD5)
@@ -205,27 +301,35 @@ func (w *Client) doSanBoxPost(ctx context.Context, bm gopay.BodyMap, path string
 		url = w.BaseURL + path
 	}
 	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
+	if w.isDebug(ctx) {
 		xlog.Debugf("Wechat_Request: %s", req)
 	}
-	res, bs, errs := xhttp.NewClientFromHttpClient(ctx, w.HttpClient).Type(xhttp.TypeXML).Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, url, 0, nil, errs[0]
-	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	res, err := w.doRequest(ctx, &Request{Method: http.MethodPost, URL: url, Body: req})
+	if err != nil {
+		_, _, statusCode, header = res.unpack()
+		return nil, url, statusCode, header, err
 	}
-	if res.StatusCode != 200 {
-		return nil, url, res.StatusCode, res.Header, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	return res.Raw, res.URL, res.StatusCode, res.Header, nil
+}
+
+// setSubMerchant 服务商模式下，bm 中未设置 sub_appid、sub_mch_id 时，使用 client 的 SubAppId、SubMchId
+func (w *Client) setSubMerchant(bm gopay.BodyMap) {
+	if w.SubAppId != util.NULL && bm.GetString("sub_appid") == util.NULL {
+		bm.Set("sub_appid", w.SubAppId)
 	}
-	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, url, res.StatusCode, res.Header, errors.New(string(bs))
+	if w.SubMchId != util.NULL && bm.GetString("sub_mch_id") == util.NULL {
+		bm.Set("sub_mch_id", w.SubMchId)
 	}
-	return bs, url, res.StatusCode, res.Header, nil
 }
 
 // Post请求、正式
+//
+//	注意：appid、mch_id、sign_type、sign 等字段写入 bm 的副本，不会修改调用方传入的 bm
 func (w *Client) doProdPost(ctx context.Context, bm gopay.BodyMap, path string, tlsConfig *tls.Config) (bs []byte, url string, statusCode int, header http.Header, err error) {
+	if !w.IsProd {
+		return nil, url, 0, nil, ErrSandboxUnsupported
+	}
+	bm = bm.Clone()
 	if strings.HasPrefix(path, "http") {
 		url = path
 	} else {
@@ -237,69 +341,81 @@ func (w *Client) doProdPost(ctx context.Context, bm gopay.BodyMap, path string,
 	if bm.GetString("mch_id") == util.NULL && bm.GetString("combine_mch_id") == util.NULL {
 		bm.Set("mch_id", w.MchId)
 	}
+	w.setSubMerchant(bm)
+	if signType, ok := signTypeFromContext(ctx); ok {
+		bm.Set("sign_type", signType)
+	}
 	if bm.GetString("sign") == util.NULL {
 		sign := GetReleaseSign(w.ApiKey, bm.GetString("sign_type"), bm)
 		bm.Set("sign", sign)
 	}
 
-	httpClient := xhttp.NewClientFromHttpClient(ctx, w.HttpClient)
-	if w.IsProd && tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
-	}
 	if w.BaseURL != util.NULL {
 		url = w.BaseURL + path
 	}
 	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
+	if w.isDebug(ctx) {
 		xlog.Debugf("Wechat_Request: %s", req)
 	}
-	res, bs, errs := httpClient.Type(xhttp.TypeXML).Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, url, 0, nil, errs[0]
+	r := &Request{Method: http.MethodPost, URL: url, Body: req}
+	if tlsConfig != nil {
+		r.TLSConfig = tlsConfig
 	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
-	}
-	if res.StatusCode != 200 {
-		return nil, url, res.StatusCode, res.Header, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
-	}
-	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, url, res.StatusCode, res.Header, errors.New(string(bs))
+	res, err := w.doRequest(ctx, r)
+	if err != nil {
+		_, _, statusCode, header = res.unpack()
+		return nil, url, statusCode, header, err
 	}
-	return bs, url, res.StatusCode, res.Header, nil
+	return res.Raw, res.URL, res.StatusCode, res.Header, nil
 }
 
-func (w *Client) doProdPostPure(ctx context.Context, bm gopay.BodyMap, path string, tlsConfig *tls.Config) (bs []byte, header http.Header, err error) {
-	var url = baseUrlCh + path
-	httpClient := xhttp.NewClientFromHttpClient(ctx, w.HttpClient)
-	if w.IsProd && tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+// Post请求、正式，不自动填充 appid、mch_id
+//
+//	注意：sign 按 signType（或 WithSignType 设置的签名类型）重新计算，并写入 bm 的副本，不会修改调用方传入的 bm
+func (w *Client) doProdPostPure(ctx context.Context, bm gopay.BodyMap, path, signType string, tlsConfig *tls.Config) (bs []byte, header http.Header, err error) {
+	if !w.IsProd {
+		return nil, nil, ErrSandboxUnsupported
 	}
-	if w.BaseURL != util.NULL {
+	bm = bm.Clone()
+	var url string
+	if strings.HasPrefix(path, "http") {
+		url = path
+	} else if w.BaseURL != util.NULL {
 		url = w.BaseURL + path
+	} else {
+		url = baseUrlCh + path
+	}
+	if st, ok := signTypeFromContext(ctx); ok {
+		signType = st
+		bm.Set("sign_type", signType)
 	}
+	bm.Remove("sign")
+	sign := GetReleaseSign(w.ApiKey, signType, bm)
+	bm.Set("sign", sign)
 	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
+	if w.isDebug(ctx) {
 		xlog.Debugf("Wechat_Request: %s", req)
 	}
-	res, bs, errs := httpClient.Type(xhttp.TypeXML).Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, errs[0]
+	r := &Request{Method: http.MethodPost, URL: url, Body: req}
+	if tlsConfig != nil {
+		r.TLSConfig = tlsConfig
 	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
-	}
-	if res.StatusCode != 200 {
-		return nil, res.Header, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
-	}
-	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, res.Header, errors.New(string(bs))
+	res, err := w.doRequest(ctx, r)
+	if err != nil {
+		_, _, _, header = res.unpack()
+		return nil, header, err
 	}
-	return bs, res.Header, nil
+	return res.Raw, res.Header, nil
 }
 
 // Get请求、正式
+//
+//	注意：appid、mch_id、sign 等字段写入 bm 的副本，不会修改调用方传入的 bm
 func (w *Client) doProdGet(ctx context.Context, bm gopay.BodyMap, path, signType string) (bs []byte, header http.Header, err error) {
+	if !w.IsProd {
+		return nil, nil, ErrSandboxUnsupported
+	}
+	bm = bm.Clone()
 	var url = baseUrlCh + path
 	if bm.GetString("appid") == util.NULL {
 		bm.Set("appid", w.AppId)
@@ -307,6 +423,11 @@ func (w *Client) doProdGet(ctx context.Context, bm gopay.BodyMap, path, signType
 	if bm.GetString("mch_id") == util.NULL {
 		bm.Set("mch_id", w.MchId)
 	}
+	w.setSubMerchant(bm)
+	if st, ok := signTypeFromContext(ctx); ok {
+		signType = st
+		bm.Set("sign_type", signType)
+	}
 	bm.Remove("sign")
 	sign := GetReleaseSign(w.ApiKey, signType, bm)
 	bm.Set("sign", sign)
@@ -314,23 +435,33 @@ func (w *Client) doProdGet(ctx context.Context, bm gopay.BodyMap, path, signType
 		url = w.BaseURL + path
 	}
 
-	if w.DebugSwitch == gopay.DebugOn {
+	if w.isDebug(ctx) {
 		xlog.Debugf("Wechat_Request: %s", bm.JsonBody())
 	}
 	param := bm.EncodeURLParams()
 	url = url + "?" + param
-	res, bs, errs := xhttp.NewClientFromHttpClient(ctx, w.HttpClient).Get(url).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, errs[0]
+	res, err := w.doRequest(ctx, &Request{Method: http.MethodGet, URL: url})
+	if err != nil {
+		_, _, _, header = res.unpack()
+		return nil, header, err
+	}
+	return res.Raw, res.Header, nil
+}
+
+// doRequest 经过中间件链发送请求，并校验响应状态
+func (w *Client) doRequest(ctx context.Context, req *Request) (res *APIResult, err error) {
+	res, err = w.handler()(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+	if w.isDebug(ctx) {
+		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(res.Raw))
 	}
 	if res.StatusCode != 200 {
-		return nil, res.Header, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return &APIResult{URL: res.URL, StatusCode: res.StatusCode, Header: res.Header}, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
 	}
-	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, res.Header, errors.New(string(bs))
+	if strings.Contains(string(res.Raw), "HTML") || strings.Contains(string(res.Raw), "html") {
+		return &APIResult{URL: res.URL, StatusCode: res.StatusCode, Header: res.Header}, errors.New(string(res.Raw))
 	}
-	return bs, res.Header, nil
+	return res, nil
 }
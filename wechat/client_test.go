@@ -2,7 +2,13 @@ package wechat
 
 import (
 	"context"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/cedarwu/gopay"
@@ -131,3 +137,145 @@ func TestClient_BatchQueryComment(t *testing.T) {
 	}
 	xlog.Debug("wxRsp：", wxRsp)
 }
+
+func TestClient_SandboxUnsupported(t *testing.T) {
+	c := NewClient(appId, mchId, apiKey, false)
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("auth_code", "134753997737645794")
+
+	_, _, err := c.AuthCodeToOpenIdWithResult(context.Background(), bm)
+	if !errors.Is(err, ErrSandboxUnsupported) {
+		t.Fatalf("AuthCodeToOpenIdWithResult() error = %v, want ErrSandboxUnsupported", err)
+	}
+	_, err = c.GetTransferInfo(bm.Set("partner_trade_no", util.GetRandomString(32)))
+	if !errors.Is(err, ErrSandboxUnsupported) {
+		t.Fatalf("GetTransferInfo() error = %v, want ErrSandboxUnsupported", err)
+	}
+}
+
+func TestClient_CloneWithSubMerchant(t *testing.T) {
+	var reqBm gopay.BodyMap
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := ioutil.ReadAll(r.Body)
+		reqBm = make(gopay.BodyMap)
+		_ = xml.Unmarshal(bs, &reqBm)
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+	sub := c.CloneWithSubMerchant("wx_sub_appid", "1900000109")
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_trade_no", "GOPAY_TEST")
+	if _, _, err := sub.CloseOrderWithResult(context.Background(), bm); err != nil {
+		t.Fatal(err)
+	}
+	if reqBm.GetString("sub_appid") != "wx_sub_appid" || reqBm.GetString("sub_mch_id") != "1900000109" || reqBm.GetString("mch_id") != mchId {
+		t.Fatalf("unexpected request: %+v", reqBm)
+	}
+	if _, _, err := c.CloseOrderWithResult(context.Background(), bm); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reqBm["sub_mch_id"]; ok {
+		t.Fatalf("origin client should not send sub_mch_id: %+v", reqBm)
+	}
+}
+
+func TestClient_BodyMapNotMutated(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code></xml>"))
+	}))
+	defer ts.Close()
+	c := newCertTestClient(t, ts)
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("partner_trade_no", "GOPAY_TEST").
+		Set("out_trade_no", "GOPAY_TEST").
+		Set("out_order_no", "GOPAY_TEST").
+		Set("out_return_no", "GOPAY_TEST").
+		Set("mch_billno", "GOPAY_TEST").
+		Set("transaction_id", "4208450740201411110007820472").
+		Set("appid", appId).
+		Set("wxappid", appId).
+		Set("openid", "oxTWIuGaIt6gTKsQRLau2M0yL16E").
+		Set("re_openid", "oxTWIuGaIt6gTKsQRLau2M0yL16E").
+		Set("check_name", "NO_CHECK").
+		Set("amount", 100).
+		Set("total_amount", 100).
+		Set("return_amount", 100).
+		Set("total_num", 1).
+		Set("amt_type", "ALL_RAND").
+		Set("desc", "GOPAY_TEST").
+		Set("description", "GOPAY_TEST").
+		Set("send_name", "gopay").
+		Set("wishing", "gopay").
+		Set("act_name", "gopay").
+		Set("remark", "gopay").
+		Set("notify_way", "MINI_PROGRAM_JSAPI").
+		Set("spbill_create_ip", "127.0.0.1").
+		Set("client_ip", "127.0.0.1").
+		Set("ww_msg_type", "NORMAL_MSG").
+		Set("enc_bank_no", "enc_bank_no").
+		Set("enc_true_name", "enc_true_name").
+		Set("bank_code", "1002").
+		Set("bill_type", "MCHT").
+		Set("bill_date", "20210101").
+		Set("account_type", string(AccountTypeBasic)).
+		Set("begin_time", "20210101000000").
+		Set("end_time", "20210102000000").
+		Set("offset", 0).
+		Set("customs", "GUANGZHOU").
+		Set("mch_customs_no", "D00411").
+		Set("return_account_type", "MERCHANT_ID").
+		Set("return_account", "86693852").
+		Set("receiver", `{"type":"MERCHANT_ID","account":"190001001"}`).
+		Set("receivers", `[{"type":"MERCHANT_ID","account":"190001001","amount":100}]`)
+	want := bm.Clone()
+
+	ctx := context.Background()
+	calls := map[string]func() error{
+		// 企业付款
+		"Transfer":        func() error { _, err := c.Transfer(bm); return err },
+		"GetTransferInfo": func() error { _, err := c.GetTransferInfo(bm); return err },
+		"PayBank":         func() error { _, err := c.PayBank(bm); return err },
+		"QueryBank":       func() error { _, err := c.QueryBank(bm); return err },
+		// 分账
+		"ProfitSharing":               func() error { _, _, err := c.ProfitSharingWithResult(bm); return err },
+		"ProfitSharingQuery":          func() error { _, _, err := c.ProfitSharingQuery(bm); return err },
+		"ProfitSharingAddReceiver":    func() error { _, _, err := c.ProfitSharingAddReceiver(bm); return err },
+		"ProfitSharingRemoveReceiver": func() error { _, _, err := c.ProfitSharingRemoveReceiver(bm); return err },
+		"ProfitSharingFinish":         func() error { _, _, err := c.ProfitSharingFinish(bm); return err },
+		"ProfitSharingReturn":         func() error { _, _, err := c.ProfitSharingReturn(bm); return err },
+		"ProfitSharingReturnQuery":    func() error { _, _, err := c.ProfitSharingReturnQuery(bm); return err },
+		// 现金红包
+		"SendCashRed":      func() error { _, _, err := c.SendCashRed(ctx, bm); return err },
+		"SendGroupCashRed": func() error { _, _, err := c.SendGroupCashRed(ctx, bm); return err },
+		"SendAppletRed":    func() error { _, _, err := c.SendAppletRed(ctx, bm); return err },
+		"QueryRedRecord":   func() error { _, _, err := c.QueryRedRecord(ctx, bm); return err },
+		// 企业微信
+		"PayWorkWxToPocket":   func() error { _, _, err := c.PayWorkWxToPocket(ctx, bm, "secret"); return err },
+		"QueryWorkWxToPocket": func() error { _, _, err := c.QueryWorkWxToPocket(ctx, bm); return err },
+		"SendWorkWxRed":       func() error { _, _, err := c.SendWorkWxRed(ctx, bm, "secret"); return err },
+		"QueryWorkWxRed":      func() error { _, _, err := c.QueryWorkWxRed(ctx, bm); return err },
+		// 海关
+		"CustomsDeclareOrder":   func() error { _, _, err := c.CustomsDeclareOrder(bm); return err },
+		"CustomsDeclareQuery":   func() error { _, _, err := c.CustomsDeclareQuery(bm); return err },
+		"CustomsReDeclareOrder": func() error { _, _, err := c.CustomsReDeclareOrder(bm); return err },
+		// 账单、评价
+		"DownloadFundFlow":  func() error { _, _, err := c.DownloadFundFlow(bm); return err },
+		"BatchQueryComment": func() error { _, _, err := c.BatchQueryComment(bm); return err },
+	}
+	for name, call := range calls {
+		if err := call(); err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		if !reflect.DeepEqual(bm, want) {
+			t.Fatalf("%s() mutated caller BodyMap: %+v", name, bm)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package wechat
+
+import (
+	"context"
+
+	"github.com/cedarwu/gopay"
+)
+
+type (
+	signTypeCtxKey struct{}
+	debugCtxKey    struct{}
+)
+
+// WithSignType 为单次请求指定签名类型，优先级高于 BodyMap 中的 sign_type
+//
+//	signType：SignType_MD5 或 SignType_HMAC_SHA256
+//	注意：沙箱环境仅支持MD5签名，此设置不生效
+func WithSignType(ctx context.Context, signType string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, signTypeCtxKey{}, signType)
+}
+
+// signTypeFromContext 获取 WithSignType() 设置的签名类型
+func signTypeFromContext(ctx context.Context) (signType string, ok bool) {
+	if ctx == nil {
+		return "", false
+	}
+	signType, ok = ctx.Value(signTypeCtxKey{}).(string)
+	return signType, ok && signType != ""
+}
+
+// WithDebug 为单次请求打开Debug日志，输出该请求的请求及响应内容，不影响 client.DebugSwitch 的设置
+func WithDebug(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, debugCtxKey{}, true)
+}
+
+// isDebug client.DebugSwitch 打开，或 ctx 通过 WithDebug() 打开时，输出Debug日志
+func (w *Client) isDebug(ctx context.Context) bool {
+	if w.DebugSwitch == gopay.DebugOn {
+		return true
+	}
+	if ctx == nil {
+		return false
+	}
+	on, _ := ctx.Value(debugCtxKey{}).(bool)
+	return on
+}
@@ -0,0 +1,137 @@
+package wechat
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"encoding/xml"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+func TestWithSignType(t *testing.T) {
+	var reqBm gopay.BodyMap
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := ioutil.ReadAll(r.Body)
+		reqBm = make(gopay.BodyMap)
+		_ = xml.Unmarshal(bs, &reqBm)
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_trade_no", "GOPAY_TEST")
+	_, _, err := c.CloseOrderWithResult(WithSignType(context.Background(), SignType_HMAC_SHA256), bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reqBm.GetString("sign_type") != SignType_HMAC_SHA256 {
+		t.Fatalf("sign_type = %s, want %s", reqBm.GetString("sign_type"), SignType_HMAC_SHA256)
+	}
+	ok, err := VerifySign(apiKey, SignType_HMAC_SHA256, reqBm)
+	if err != nil || !ok {
+		t.Fatalf("VerifySign() = %v, %v", ok, err)
+	}
+	// 调用方传入的 BodyMap 不应被写入内部字段
+	for _, k := range []string{"appid", "mch_id", "sign_type", "sign"} {
+		if _, ok := bm[k]; ok {
+			t.Fatalf("caller BodyMap was mutated with %s", k)
+		}
+	}
+}
+
+func TestWithDebug(t *testing.T) {
+	c := NewClient(appId, mchId, apiKey, true)
+	if c.isDebug(context.Background()) {
+		t.Fatal("debug should be off by default")
+	}
+	if !c.isDebug(WithDebug(context.Background())) {
+		t.Fatal("WithDebug(ctx) should turn on debug for the request")
+	}
+	c.DebugSwitch = gopay.DebugOn
+	if !c.isDebug(context.Background()) {
+		t.Fatal("client.DebugSwitch should turn on debug for all requests")
+	}
+}
+
+// newTestCertPem 生成自签名的 apiclient_cert.pem、apiclient_key.pem 内容
+func newTestCertPem(t *testing.T) (certPem, keyPem []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: mchId},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+// newCertTestClient 返回请求发往 ts 且已添加自签名证书的 client，用于测试需要证书的接口
+func newCertTestClient(t *testing.T, ts *httptest.Server) *Client {
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+	if err := c.AddCertPemFileContent(newTestCertPem(t)); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestWithSignType_PostPure(t *testing.T) {
+	var reqBm gopay.BodyMap
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := ioutil.ReadAll(r.Body)
+		reqBm = make(gopay.BodyMap)
+		_ = xml.Unmarshal(bs, &reqBm)
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code></xml>"))
+	}))
+	defer ts.Close()
+	c := newCertTestClient(t, ts)
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("mch_billno", "GOPAY_TEST").
+		Set("appid", appId).
+		Set("bill_type", "MCHT")
+	if _, _, err := c.QueryRedRecord(context.Background(), bm); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifySign(apiKey, SignType_MD5, reqBm); err != nil || !ok {
+		t.Fatalf("VerifySign(MD5) = %v, %v", ok, err)
+	}
+
+	if _, _, err := c.QueryRedRecord(WithSignType(context.Background(), SignType_HMAC_SHA256), bm); err != nil {
+		t.Fatal(err)
+	}
+	if reqBm.GetString("sign_type") != SignType_HMAC_SHA256 {
+		t.Fatalf("sign_type = %s, want %s", reqBm.GetString("sign_type"), SignType_HMAC_SHA256)
+	}
+	if ok, err := VerifySign(apiKey, SignType_HMAC_SHA256, reqBm); err != nil || !ok {
+		t.Fatalf("VerifySign(HMAC-SHA256) = %v, %v", ok, err)
+	}
+}
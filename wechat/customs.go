@@ -17,6 +17,7 @@ func (w *Client) CustomsDeclareOrder(bm gopay.BodyMap) (wxRsp *CustomsDeclareOrd
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	bm.Set("sign_type", SignType_MD5)
 	bs, _, _, header, err := w.doProdPost(context.Background(), bm, customsDeclareOrder, nil)
 	if err != nil {
@@ -37,6 +38,7 @@ func (w *Client) CustomsDeclareQuery(bm gopay.BodyMap) (wxRsp *CustomsDeclareQue
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	bm.Set("sign_type", SignType_MD5)
 	bs, _, _, header, err := w.doProdPost(context.Background(), bm, customsDeclareQuery, nil)
 	if err != nil {
@@ -57,6 +59,7 @@ func (w *Client) CustomsReDeclareOrder(bm gopay.BodyMap) (wxRsp *CustomsReDeclar
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	bm.Set("sign_type", SignType_MD5)
 	bs, _, _, header, err := w.doProdPost(context.Background(), bm, customsReDeclareOrder, nil)
 	if err != nil {
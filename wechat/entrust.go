@@ -69,21 +69,29 @@ func (w *Client) EntrustH5(ctx context.Context, bm gopay.BodyMap) (wxRsp *Entrus
 // 支付中签约（正式）
 //
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/wxpay_v2/papay/chapter3_5.shtml
-func (w *Client) EntrustPaying(ctx context.Context, bm gopay.BodyMap) (wxRsp *EntrustPayingResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) EntrustPayingWithResult(ctx context.Context, bm gopay.BodyMap) (wxRsp *EntrustPayingResponse, res *APIResult, err error) {
 	err = bm.CheckEmptyError("contract_mchid", "contract_appid",
 		"out_trade_no", "nonce_str", "body", "notify_url", "total_fee",
 		"spbill_create_ip", "trade_type", "plan_id", "contract_code",
 		"request_serial", "contract_display_account", "contract_notify_url")
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
-	bs, url, statusCode, header, err = w.doProdPost(ctx, bm, entrustPaying, nil)
+	res = new(APIResult)
+	res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(ctx, bm, entrustPaying, nil)
 	if err != nil {
-		return nil, nil, "", 0, header, err
+		return nil, res, err
 	}
 	wxRsp = new(EntrustPayingResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, bs, url, statusCode, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.Unmarshal(%s)：%w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
+}
+
+// Deprecated: 推荐使用 client.EntrustPayingWithResult()
+func (w *Client) EntrustPaying(ctx context.Context, bm gopay.BodyMap) (wxRsp *EntrustPayingResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.EntrustPayingWithResult(ctx, bm)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
 }
@@ -0,0 +1,71 @@
+package wechat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// TradeType 交易类型，trade_type 字段
+type TradeType string
+
+// BillType 账单类型，下载对账单 bill_type 字段
+type BillType string
+
+// AccountType 资金账户类型，下载资金账单 account_type 字段
+type AccountType string
+
+const (
+	// 支付类型，与 TradeType_* 常量取值相同
+	TradeTypeMini   TradeType = TradeType_Mini   // 小程序支付
+	TradeTypeJsApi  TradeType = TradeType_JsApi  // JSAPI支付
+	TradeTypeApp    TradeType = TradeType_App    // app支付
+	TradeTypeH5     TradeType = TradeType_H5     // H5支付
+	TradeTypeNative TradeType = TradeType_Native // Native支付
+
+	// 对账单类型
+	BillTypeAll            BillType = "ALL"             // 当日所有订单信息（不含充值退款订单）
+	BillTypeSuccess        BillType = "SUCCESS"         // 当日成功支付的订单（不含充值退款订单）
+	BillTypeRefund         BillType = "REFUND"          // 当日退款订单（不含充值退款订单）
+	BillTypeRechargeRefund BillType = "RECHARGE_REFUND" // 当日充值退款订单
+
+	// 资金账户类型
+	AccountTypeBasic     AccountType = "Basic"     // 基本账户
+	AccountTypeOperation AccountType = "Operation" // 运营账户
+	AccountTypeFees      AccountType = "Fees"      // 手续费账户
+)
+
+var (
+	tradeTypes   = []string{TradeType_JsApi, TradeType_App, TradeType_H5, TradeType_Native}
+	billTypes    = []string{string(BillTypeAll), string(BillTypeSuccess), string(BillTypeRefund), string(BillTypeRechargeRefund)}
+	accountTypes = []string{string(AccountTypeBasic), string(AccountTypeOperation), string(AccountTypeFees)}
+)
+
+// ParamError 请求参数取值错误
+type ParamError struct {
+	Key     string   // 参数名
+	Value   string   // 传入的值
+	Allowed []string // 允许的取值
+	DocURL  string   // 参数说明文档
+}
+
+func (e *ParamError) Error() string {
+	msg := fmt.Sprintf("%s error, value [%s] is not allowed, allowed values: %s", e.Key, e.Value, strings.Join(e.Allowed, ", "))
+	if e.DocURL != util.NULL {
+		msg += ", please reference: " + e.DocURL
+	}
+	return msg
+}
+
+// checkEnumParam 校验 bm 中 key 的取值是否在 allowed 中，不合法时返回 *ParamError
+func checkEnumParam(bm gopay.BodyMap, key, docURL string, allowed []string) error {
+	value := bm.GetString(key)
+	for _, v := range allowed {
+		if value == v {
+			return nil
+		}
+	}
+	return &ParamError{Key: key, Value: value, Allowed: allowed, DocURL: docURL}
+}
@@ -0,0 +1,42 @@
+package wechat
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+func TestCheckEnumParam(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("bill_date", "20190722").
+		Set("bill_type", BillTypeSuccess)
+	if err := checkEnumParam(bm, "bill_type", "", billTypes); err != nil {
+		t.Fatal(err)
+	}
+
+	bm.Set("bill_type", "FAIL")
+	_, _, err := client.DownloadBill(bm)
+	var pErr *ParamError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("DownloadBill() error = %v, want *ParamError", err)
+	}
+	if pErr.Key != "bill_type" || pErr.Value != "FAIL" || len(pErr.Allowed) != 4 {
+		t.Fatalf("unexpected ParamError: %+v", pErr)
+	}
+}
+
+func TestTradeType(t *testing.T) {
+	// TradeType_* 为无类型常量，可直接赋值给 string
+	var tradeType string = TradeType_Native
+	bm := make(gopay.BodyMap)
+	bm.Set("trade_type", TradeTypeNative)
+	if bm.GetString("trade_type") != tradeType {
+		t.Fatalf("trade_type = %s, want %s", bm.GetString("trade_type"), tradeType)
+	}
+	if err := checkEnumParam(bm, "trade_type", "", tradeTypes); err != nil {
+		t.Fatal(err)
+	}
+}
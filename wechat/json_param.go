@@ -0,0 +1,109 @@
+package wechat
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 部分V2接口的XML字段值为JSON字符串（如 receivers、receiver、scene_info、detail），
+// 以下结构体均实现了 gopay.JSONStringer，可直接 Set 到 BodyMap，生成XML及计算签名时自动转为JSON字符串
+//
+//	bm.Set("receivers", wechat.ProfitSharingReceivers{
+//		{Type: "MERCHANT_ID", Account: "190001001", Amount: 100, Description: "分到商户"},
+//	})
+
+// ProfitSharingReceiver 分账接收方，用于 client.ProfitSharing()、client.MultiProfitSharing() 的 receivers 字段
+type ProfitSharingReceiver struct {
+	Type        string `json:"type"`
+	Account     string `json:"account"`
+	Amount      int    `json:"amount"`
+	Description string `json:"description"`
+	Name        string `json:"name,omitempty"`
+}
+
+// ProfitSharingReceivers 分账接收方列表
+type ProfitSharingReceivers []*ProfitSharingReceiver
+
+// ToJSONString 转为 receivers 字段的JSON字符串
+func (r ProfitSharingReceivers) ToJSONString() string {
+	return toJSONString(r)
+}
+
+// ProfitSharingRelation 分账接收方关系，用于 client.ProfitSharingAddReceiver()、client.ProfitSharingRemoveReceiver() 的 receiver 字段
+type ProfitSharingRelation struct {
+	Type           string `json:"type"`
+	Account        string `json:"account"`
+	Name           string `json:"name,omitempty"`
+	RelationType   string `json:"relation_type,omitempty"`
+	CustomRelation string `json:"custom_relation,omitempty"`
+}
+
+// ToJSONString 转为 receiver 字段的JSON字符串
+func (r *ProfitSharingRelation) ToJSONString() string {
+	return toJSONString(r)
+}
+
+// SceneInfo 场景信息，用于 client.UnifiedOrder()、client.Micropay() 的 scene_info 字段
+type SceneInfo struct {
+	StoreInfo *StoreInfo `json:"store_info,omitempty"`
+	H5Info    *H5Info    `json:"h5_info,omitempty"`
+}
+
+// StoreInfo 门店信息
+type StoreInfo struct {
+	Id       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	AreaCode string `json:"area_code,omitempty"`
+	Address  string `json:"address,omitempty"`
+}
+
+// H5Info H5支付场景信息
+//
+//	Type：场景类型，IOS、Android、Wap
+type H5Info struct {
+	Type        string `json:"type"`
+	AppName     string `json:"app_name,omitempty"`
+	BundleId    string `json:"bundle_id,omitempty"`
+	PackageName string `json:"package_name,omitempty"`
+	WapUrl      string `json:"wap_url,omitempty"`
+	WapName     string `json:"wap_name,omitempty"`
+}
+
+// ToJSONString 转为 scene_info 字段的JSON字符串
+func (s *SceneInfo) ToJSONString() string {
+	return toJSONString(s)
+}
+
+// GoodsDetail 单品优惠商品详情，用于 client.UnifiedOrder()、client.Micropay() 的 detail 字段
+type GoodsDetail struct {
+	CostPrice   int          `json:"cost_price,omitempty"`
+	ReceiptId   string       `json:"receipt_id,omitempty"`
+	GoodsDetail []*GoodsItem `json:"goods_detail"`
+}
+
+// GoodsItem 单品信息
+type GoodsItem struct {
+	GoodsId      string `json:"goods_id"`
+	WxpayGoodsId string `json:"wxpay_goods_id,omitempty"`
+	GoodsName    string `json:"goods_name,omitempty"`
+	Quantity     int    `json:"quantity"`
+	Price        int    `json:"price"`
+}
+
+// ToJSONString 转为 detail 字段的JSON字符串
+func (d *GoodsDetail) ToJSONString() string {
+	return toJSONString(d)
+}
+
+// toJSONString 不转义 <、>、& 的JSON序列化，与微信文档中的示例保持一致
+func toJSONString(v interface{}) string {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return util.NULL
+	}
+	return string(bytes.TrimRight(buf.Bytes(), "\n"))
+}
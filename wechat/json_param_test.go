@@ -0,0 +1,30 @@
+package wechat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+func TestJSONParam(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("receivers", ProfitSharingReceivers{
+		{Type: "MERCHANT_ID", Account: "190001001", Amount: 100, Description: "分到商户"},
+		{Type: "PERSONAL_OPENID", Account: "86693952", Amount: 888, Description: "分到个人"},
+	}).Set("scene_info", &SceneInfo{
+		H5Info: &H5Info{Type: "Wap", WapUrl: "https://www.fmm.ink?a=1&b=2", WapName: "H5测试支付"},
+	})
+
+	receivers := bm.GetString("receivers")
+	xlog.Debug("receivers:", receivers)
+	if receivers != `[{"type":"MERCHANT_ID","account":"190001001","amount":100,"description":"分到商户"},{"type":"PERSONAL_OPENID","account":"86693952","amount":888,"description":"分到个人"}]` {
+		t.Fatalf("receivers = %s", receivers)
+	}
+	xmlStr := GenerateXml(bm)
+	xlog.Debug("xml:", xmlStr)
+	if !strings.Contains(xmlStr, `<scene_info><![CDATA[{"h5_info":{"type":"Wap","wap_url":"https://www.fmm.ink?a=1&b=2","wap_name":"H5测试支付"}}]]></scene_info>`) {
+		t.Fatalf("scene_info not encoded as JSON string: %s", xmlStr)
+	}
+}
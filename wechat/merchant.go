@@ -2,7 +2,6 @@ package wechat
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -10,49 +9,30 @@ import (
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/util"
-	"github.com/cedarwu/gopay/pkg/xhttp"
-	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
 // 企业付款（企业向微信用户个人付款）
 //
 //	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
-//	注意：此方法未支持沙箱环境，默认正式环境，转账请慎重
+//	注意：此方法不支持沙箱环境，沙箱环境调用返回 ErrSandboxUnsupported，转账请慎重
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/tools/mch_pay.php?chapter=14_2
 func (w *Client) Transfer(bm gopay.BodyMap) (wxRsp *TransfersResponse, err error) {
 	if err = bm.CheckEmptyError("nonce_str", "partner_trade_no", "openid", "check_name", "amount", "desc", "spbill_create_ip"); err != nil {
 		return nil, err
 	}
+	bm = bm.Clone()
+	if !w.IsProd {
+		return nil, ErrSandboxUnsupported
+	}
 	bm.Set("mch_appid", w.AppId)
 	bm.Set("mchid", w.MchId)
-	var (
-		tlsConfig *tls.Config
-		url       = baseUrlCh + transfers
-	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
 		return nil, err
 	}
-	bm.Set("sign", GetReleaseSign(w.ApiKey, SignType_MD5, bm))
-
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
-	if w.BaseURL != util.NULL {
-		w.mu.RLock()
-		url = w.BaseURL + transfers
-		w.mu.RUnlock()
-	}
-	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Request: %s", req)
-	}
-	res, bs, errs := httpClient.Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, errs[0]
-	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
-	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	bs, _, err := w.doProdPostPure(context.Background(), bm, transfers, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	wxRsp = new(TransfersResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
@@ -64,42 +44,25 @@ func (w *Client) Transfer(bm gopay.BodyMap) (wxRsp *TransfersResponse, err error
 // 查询企业付款
 //
 //	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
-//	注意：此方法未支持沙箱环境，默认正式环境
+//	注意：此方法不支持沙箱环境，沙箱环境调用返回 ErrSandboxUnsupported
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/tools/mch_pay.php?chapter=14_3
 func (w *Client) GetTransferInfo(bm gopay.BodyMap) (wxRsp *TransfersInfoResponse, err error) {
 	if err = bm.CheckEmptyError("nonce_str", "partner_trade_no"); err != nil {
 		return nil, err
 	}
+	bm = bm.Clone()
+	if !w.IsProd {
+		return nil, ErrSandboxUnsupported
+	}
 	bm.Set("appid", w.AppId)
 	bm.Set("mch_id", w.MchId)
-	var (
-		tlsConfig *tls.Config
-		url       = baseUrlCh + getTransferInfo
-	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
 		return nil, err
 	}
-	bm.Set("sign", GetReleaseSign(w.ApiKey, SignType_MD5, bm))
-
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
-	if w.BaseURL != util.NULL {
-		w.mu.RLock()
-		url = w.BaseURL + getTransferInfo
-		w.mu.RUnlock()
-	}
-	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Request: %s", req)
-	}
-	res, bs, errs := httpClient.Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, errs[0]
-	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
-	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	bs, _, err := w.doProdPostPure(context.Background(), bm, getTransferInfo, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	wxRsp = new(TransfersInfoResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
@@ -111,7 +74,7 @@ func (w *Client) GetTransferInfo(bm gopay.BodyMap) (wxRsp *TransfersInfoResponse
 // 企业付款到银行卡API（正式）
 //
 //	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
-//	注意：此方法未支持沙箱环境，默认正式环境，转账请慎重
+//	注意：此方法不支持沙箱环境，沙箱环境调用返回 ErrSandboxUnsupported，转账请慎重
 //	注意：enc_bank_no、enc_true_name 两参数，开发者需自行获取RSA公钥，加密后再 Set 到 BodyMap，参考 client_test.go 里的 TestClient_PayBank() 方法
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/tools/mch_pay.php?chapter=24_2
 //	RSA加密文档地址：https://pay.weixin.qq.com/wiki/doc/api/tools/mch_pay.php?chapter=24_7
@@ -120,35 +83,18 @@ func (w *Client) PayBank(bm gopay.BodyMap) (wxRsp *PayBankResponse, err error) {
 	if err = bm.CheckEmptyError("partner_trade_no", "nonce_str", "enc_bank_no", "enc_true_name", "bank_code", "amount"); err != nil {
 		return nil, err
 	}
+	bm = bm.Clone()
+	if !w.IsProd {
+		return nil, ErrSandboxUnsupported
+	}
 	bm.Set("mch_id", w.MchId)
-	var (
-		tlsConfig *tls.Config
-		url       = baseUrlCh + payBank
-	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
 		return nil, err
 	}
-	bm.Set("sign", GetReleaseSign(w.ApiKey, SignType_MD5, bm))
-
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
-	if w.BaseURL != util.NULL {
-		w.mu.RLock()
-		url = w.BaseURL + payBank
-		w.mu.RUnlock()
-	}
-	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Request: %s", req)
-	}
-	res, bs, errs := httpClient.Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, errs[0]
-	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
-	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	bs, _, err := w.doProdPostPure(context.Background(), bm, payBank, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	wxRsp = new(PayBankResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
@@ -165,35 +111,18 @@ func (w *Client) QueryBank(bm gopay.BodyMap) (wxRsp *QueryBankResponse, err erro
 	if err = bm.CheckEmptyError("nonce_str", "partner_trade_no"); err != nil {
 		return nil, err
 	}
+	bm = bm.Clone()
+	if !w.IsProd {
+		return nil, ErrSandboxUnsupported
+	}
 	bm.Set("mch_id", w.MchId)
-	var (
-		tlsConfig *tls.Config
-		url       = baseUrlCh + queryBank
-	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
 		return nil, err
 	}
-	bm.Set("sign", GetReleaseSign(w.ApiKey, SignType_MD5, bm))
-
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
-	if w.BaseURL != util.NULL {
-		w.mu.RLock()
-		url = w.BaseURL + queryBank
-		w.mu.RUnlock()
-	}
-	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Request: %s", req)
-	}
-	res, bs, errs := httpClient.Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, errs[0]
-	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
-	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	bs, _, err := w.doProdPostPure(context.Background(), bm, queryBank, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	wxRsp = new(QueryBankResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
@@ -210,30 +139,18 @@ func (w *Client) GetRSAPublicKey(bm gopay.BodyMap) (wxRsp *RSAPublicKeyResponse,
 	if err = bm.CheckEmptyError("nonce_str", "sign_type"); err != nil {
 		return nil, err
 	}
+	bm = bm.Clone()
+	if !w.IsProd {
+		return nil, ErrSandboxUnsupported
+	}
 	bm.Set("mch_id", w.MchId)
-	var (
-		tlsConfig *tls.Config
-		url       = getPublicKey
-	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
 		return nil, err
 	}
-	bm.Set("sign", GetReleaseSign(w.ApiKey, bm.GetString("sign_type"), bm))
-
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
-	req := GenerateXml(bm)
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Request: %s", req)
-	}
-	res, bs, errs := httpClient.Post(url).SendString(req).EndBytes()
-	if len(errs) > 0 {
-		return nil, errs[0]
-	}
-	if w.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
-	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+	bs, _, err := w.doProdPostPure(context.Background(), bm, getPublicKey, bm.GetString("sign_type"), tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	wxRsp = new(RSAPublicKeyResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
@@ -249,10 +166,17 @@ func (w *Client) GetRSAPublicKey(bm gopay.BodyMap) (wxRsp *RSAPublicKeyResponse,
 //	故操作成功后，订单不能再进行分账，也不能进行分账完结。
 //	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/allocation.php?chapter=27_1&index=1
-func (w *Client) ProfitSharing(bm gopay.BodyMap) (wxRsp *ProfitSharingResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) ProfitSharingWithResult(bm gopay.BodyMap) (wxRsp *ProfitSharingResponse, res *APIResult, err error) {
 	return w.profitSharing(bm, profitSharing)
 }
 
+// Deprecated: 推荐使用 client.ProfitSharingWithResult()
+func (w *Client) ProfitSharing(bm gopay.BodyMap) (wxRsp *ProfitSharingResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.profitSharing(bm, profitSharing)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
+}
+
 // 请求多次分账
 //
 //	微信订单支付成功后，商户发起分账请求，将结算后的钱分到分账接收方。多次分账请求仅会按照传入的分账接收方进行分账，不会对剩余的金额进行任何操作。
@@ -261,31 +185,40 @@ func (w *Client) ProfitSharing(bm gopay.BodyMap) (wxRsp *ProfitSharingResponse,
 //	对同一笔订单最多能发起20次多次分账请求
 //	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
 //	文档地址：https://pay.weixin.qq.com/wiki/doc/api/allocation.php?chapter=27_1&index=1
-func (w *Client) MultiProfitSharing(bm gopay.BodyMap) (wxRsp *ProfitSharingResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+func (w *Client) MultiProfitSharingWithResult(bm gopay.BodyMap) (wxRsp *ProfitSharingResponse, res *APIResult, err error) {
 	return w.profitSharing(bm, multiProfitSharing)
 }
 
-func (w *Client) profitSharing(bm gopay.BodyMap, uri string) (wxRsp *ProfitSharingResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+// Deprecated: 推荐使用 client.MultiProfitSharingWithResult()
+func (w *Client) MultiProfitSharing(bm gopay.BodyMap) (wxRsp *ProfitSharingResponse, bs []byte, url string, statusCode int, header http.Header, err error) {
+	wxRsp, res, err := w.profitSharing(bm, multiProfitSharing)
+	bs, url, statusCode, header = res.unpack()
+	return wxRsp, bs, url, statusCode, header, err
+}
+
+func (w *Client) profitSharing(bm gopay.BodyMap, uri string) (wxRsp *ProfitSharingResponse, res *APIResult, err error) {
 	err = bm.CheckEmptyError("nonce_str", "transaction_id", "out_order_no", "receivers")
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
+	bm = bm.Clone()
 
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
 	if err != nil {
-		return nil, nil, "", 0, nil, err
+		return nil, nil, err
 	}
-	bs, url, statusCode, header, err = w.doProdPost(context.Background(), bm, uri, tlsConfig)
+	res = new(APIResult)
+	res.Raw, res.URL, res.StatusCode, res.Header, err = w.doProdPost(context.Background(), bm, uri, tlsConfig)
 	if err != nil {
-		return nil, bs, url, statusCode, header, err
+		return nil, res, err
 	}
 	wxRsp = new(ProfitSharingResponse)
-	if err = xml.Unmarshal(bs, wxRsp); err != nil {
-		return nil, bs, url, statusCode, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	if err = xml.Unmarshal(res.Raw, wxRsp); err != nil {
+		return nil, res, fmt.Errorf("xml.Unmarshal(%s)：%w", string(res.Raw), err)
 	}
-	return wxRsp, bs, url, statusCode, header, nil
+	return wxRsp, res, nil
 }
 
 // 查询分账结果
@@ -297,14 +230,11 @@ func (w *Client) ProfitSharingQuery(bm gopay.BodyMap) (wxRsp *ProfitSharingQuery
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
 	bm.Set("mch_id", w.MchId)
-	if bm.GetString("sign") == util.NULL {
-		sign := GetReleaseSign(w.ApiKey, bm.GetString("sign_type"), bm)
-		bm.Set("sign", sign)
-	}
-	bs, header, err := w.doProdPostPure(context.Background(), bm, profitSharingQuery, nil)
+	bs, header, err := w.doProdPostPure(context.Background(), bm, profitSharingQuery, SignType_HMAC_SHA256, nil)
 	if err != nil {
 		return nil, header, err
 	}
@@ -324,6 +254,7 @@ func (w *Client) ProfitSharingAddReceiver(bm gopay.BodyMap) (wxRsp *ProfitSharin
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
 	bs, _, _, header, err := w.doProdPost(context.Background(), bm, profitSharingAddReceiver, nil)
@@ -346,6 +277,7 @@ func (w *Client) ProfitSharingRemoveReceiver(bm gopay.BodyMap) (wxRsp *ProfitSha
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
 	bs, _, _, header, err := w.doProdPost(context.Background(), bm, profitSharingRemoveReceiver, nil)
@@ -371,6 +303,7 @@ func (w *Client) ProfitSharingFinish(bm gopay.BodyMap) (wxRsp *ProfitSharingResp
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
@@ -401,6 +334,7 @@ func (w *Client) ProfitSharingReturn(bm gopay.BodyMap) (wxRsp *ProfitSharingRetu
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 
 	if (bm.GetString("order_id") == util.NULL) && (bm.GetString("out_order_no") == util.NULL) {
 		return nil, nil, errors.New("param order_id and out_order_no can not be null at the same time")
@@ -432,6 +366,7 @@ func (w *Client) ProfitSharingReturnQuery(bm gopay.BodyMap) (wxRsp *ProfitSharin
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 
 	if (bm.GetString("order_id") == util.NULL) && (bm.GetString("out_order_no") == util.NULL) {
 		return nil, nil, errors.New("param order_id and out_order_no can not be null at the same time")
@@ -1,8 +1,12 @@
 package wechat
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/cedarwu/gopay"
@@ -33,6 +37,47 @@ func TestClient_Transfer(t *testing.T) {
 	xlog.Debug("wxRsp: ", *wxRsp)
 }
 
+func TestClient_TransferRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code></xml>"))
+	}))
+	defer ts.Close()
+	c := newCertTestClient(t, ts)
+
+	var req *Request
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) (*APIResult, error) {
+			req = r
+			return next(ctx, r)
+		}
+	})
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("partner_trade_no", "GOPAY_TEST").
+		Set("openid", "o0Df70H2Q0fY8JXh1aFPIRyOBgu8").
+		Set("check_name", "NO_CHECK").
+		Set("amount", 30).
+		Set("desc", "测试转账").
+		Set("spbill_create_ip", "127.0.0.1")
+	if _, err := c.Transfer(bm); err != nil {
+		t.Fatal(err)
+	}
+	// 企业付款与其他接口一样经过中间件链，且请求已签名
+	if req == nil || req.URL != ts.URL+"/"+transfers || req.TLSConfig == nil {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+	reqBm := make(gopay.BodyMap)
+	if err := xml.Unmarshal([]byte(req.Body), &reqBm); err != nil {
+		t.Fatal(err)
+	}
+	if reqBm.GetString("mch_appid") != appId || reqBm.GetString("mchid") != mchId {
+		t.Fatalf("unexpected request: %+v", reqBm)
+	}
+	if ok, err := VerifySign(apiKey, SignType_MD5, reqBm); err != nil || !ok {
+		t.Fatalf("VerifySign() = %v, %v", ok, err)
+	}
+}
+
 func Test_ProfitSharing(t *testing.T) {
 	type Receiver struct {
 		Type        string `json:"type"`
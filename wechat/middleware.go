@@ -0,0 +1,74 @@
+package wechat
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/cedarwu/gopay/pkg/xhttp"
+)
+
+// Request 微信V2出站请求
+type Request struct {
+	Method    string      // http.MethodPost 或 http.MethodGet
+	URL       string      // 完整请求地址，GET 请求包含 query 参数
+	Body      string      // 请求Body（已签名的XML），GET 请求为空
+	TLSConfig *tls.Config // 证书配置，无需证书的请求为 nil
+}
+
+// Handler 发送请求并返回原始响应，err 仅表示网络等请求层面的错误
+type Handler func(ctx context.Context, req *Request) (res *APIResult, err error)
+
+// Middleware 请求中间件，可用于统计耗时、重试、审计日志、修改请求等
+//
+//	client.Use(func(next wechat.Handler) wechat.Handler {
+//		return func(ctx context.Context, req *wechat.Request) (*wechat.APIResult, error) {
+//			start := time.Now()
+//			res, err := next(ctx, req)
+//			xlog.Infof("%s cost: %s", req.URL, time.Since(start))
+//			return res, err
+//		}
+//	})
+type Middleware func(next Handler) Handler
+
+// Use 添加请求中间件，多个中间件按添加顺序由外向内执行
+func (w *Client) Use(middlewares ...Middleware) (client *Client) {
+	w.mu.Lock()
+	w.middlewares = append(w.middlewares, middlewares...)
+	w.mu.Unlock()
+	return w
+}
+
+// handler 组装中间件链
+func (w *Client) handler() (h Handler) {
+	w.mu.RLock()
+	mws := w.middlewares
+	w.mu.RUnlock()
+	h = w.send
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// send 实际发送HTTP请求
+func (w *Client) send(ctx context.Context, req *Request) (res *APIResult, err error) {
+	httpClient := xhttp.NewClientFromHttpClient(ctx, w.HttpClient)
+	if req.TLSConfig != nil {
+		httpClient.SetTLSConfig(req.TLSConfig)
+	}
+	var (
+		rsp  *http.Response
+		bs   []byte
+		errs []error
+	)
+	if req.Method == http.MethodGet {
+		rsp, bs, errs = httpClient.Get(req.URL).EndBytes()
+	} else {
+		rsp, bs, errs = httpClient.Type(xhttp.TypeXML).Post(req.URL).SendString(req.Body).EndBytes()
+	}
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return &APIResult{Raw: bs, URL: req.URL, StatusCode: rsp.StatusCode, Header: rsp.Header}, nil
+}
@@ -0,0 +1,51 @@
+package wechat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+func TestClient_Use(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+
+	var order []string
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*APIResult, error) {
+			order = append(order, "outer")
+			return next(ctx, req)
+		}
+	}, func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*APIResult, error) {
+			order = append(order, "inner")
+			res, err := next(ctx, req)
+			xlog.Debugf("%s %s => %d", req.Method, req.URL, res.StatusCode)
+			return res, err
+		}
+	})
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_trade_no", "GOPAY_TEST")
+	wxRsp, res, err := c.QueryOrderWithResult(context.Background(), bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wxRsp.ReturnCode != gopay.SUCCESS || res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected response: %+v, %d", wxRsp, res.StatusCode)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Fatalf("middleware order = %v", order)
+	}
+}
@@ -53,6 +53,12 @@ const (
 	queryBank                   = "mmpaysptrans/query_bank"                           // 查询企业付款到银行卡API
 	getPublicKey                = "https://fraud.mch.weixin.qq.com/risk/getpublickey" // 获取RSA加密公钥API
 
+	// 企业微信支付
+	payWorkWxToPocket   = "mmpaymkttransfers/promotion/paywwsptrans2pocket"   // 向员工付款
+	queryWorkWxToPocket = "mmpaymkttransfers/promotion/querywwsptrans2pocket" // 查询向员工付款记录
+	sendWorkWxRed       = "mmpaymkttransfers/sendworkwxredpack"               // 发放企业红包
+	queryWorkWxRed      = "mmpaymkttransfers/queryworkwxredpack"              // 查询企业红包记录
+
 	// 海关自助清关
 	customsDeclareOrder   = "cgi-bin/mch/customs/customdeclareorder"        // 订单附加信息提交
 	customsDeclareQuery   = "cgi-bin/mch/customs/customdeclarequery"        // 订单附加信息查询
@@ -80,6 +86,21 @@ const (
 	// 签名方式
 	SignType_MD5         = "MD5"
 	SignType_HMAC_SHA256 = "HMAC-SHA256"
+
+	// 交易状态
+	TradeState_Success    = "SUCCESS"    // 支付成功
+	TradeState_Refund     = "REFUND"     // 转入退款
+	TradeState_NotPay     = "NOTPAY"     // 未支付
+	TradeState_Closed     = "CLOSED"     // 已关闭
+	TradeState_Revoked    = "REVOKED"    // 已撤销（付款码支付）
+	TradeState_UserPaying = "USERPAYING" // 用户支付中（付款码支付）
+	TradeState_PayError   = "PAYERROR"   // 支付失败
+
+	// 退款状态
+	RefundStatus_Success     = "SUCCESS"     // 退款成功
+	RefundStatus_RefundClose = "REFUNDCLOSE" // 退款关闭
+	RefundStatus_Processing  = "PROCESSING"  // 退款处理中
+	RefundStatus_Change      = "CHANGE"      // 退款异常
 )
 
 // Notify
@@ -943,3 +964,80 @@ type CustomsReDeclareOrderResponse struct {
 	ModifyTime    string `xml:"modify_time,omitempty" json:"modify_time,omitempty"`
 	Explanation   string `xml:"explanation,omitempty" json:"explanation,omitempty"`
 }
+
+type PayWorkWxToPocketResponse struct {
+	ReturnCode     string `xml:"return_code,omitempty" json:"return_code,omitempty"`
+	ReturnMsg      string `xml:"return_msg,omitempty" json:"return_msg,omitempty"`
+	Appid          string `xml:"appid,omitempty" json:"appid,omitempty"`
+	MchId          string `xml:"mch_id,omitempty" json:"mch_id,omitempty"`
+	DeviceInfo     string `xml:"device_info,omitempty" json:"device_info,omitempty"`
+	NonceStr       string `xml:"nonce_str,omitempty" json:"nonce_str,omitempty"`
+	ResultCode     string `xml:"result_code,omitempty" json:"result_code,omitempty"`
+	ErrCode        string `xml:"err_code,omitempty" json:"err_code,omitempty"`
+	ErrCodeDes     string `xml:"err_code_des,omitempty" json:"err_code_des,omitempty"`
+	PartnerTradeNo string `xml:"partner_trade_no,omitempty" json:"partner_trade_no,omitempty"`
+	PaymentNo      string `xml:"payment_no,omitempty" json:"payment_no,omitempty"`
+	PaymentTime    string `xml:"payment_time,omitempty" json:"payment_time,omitempty"`
+}
+
+type QueryWorkWxToPocketResponse struct {
+	ReturnCode     string `xml:"return_code,omitempty" json:"return_code,omitempty"`
+	ReturnMsg      string `xml:"return_msg,omitempty" json:"return_msg,omitempty"`
+	ResultCode     string `xml:"result_code,omitempty" json:"result_code,omitempty"`
+	ErrCode        string `xml:"err_code,omitempty" json:"err_code,omitempty"`
+	ErrCodeDes     string `xml:"err_code_des,omitempty" json:"err_code_des,omitempty"`
+	PartnerTradeNo string `xml:"partner_trade_no,omitempty" json:"partner_trade_no,omitempty"`
+	MchId          string `xml:"mch_id,omitempty" json:"mch_id,omitempty"`
+	DetailId       string `xml:"detail_id,omitempty" json:"detail_id,omitempty"`
+	Status         string `xml:"status,omitempty" json:"status,omitempty"`
+	Reason         string `xml:"reason,omitempty" json:"reason,omitempty"`
+	Openid         string `xml:"openid,omitempty" json:"openid,omitempty"`
+	TransferName   string `xml:"transfer_name,omitempty" json:"transfer_name,omitempty"`
+	PaymentAmount  string `xml:"payment_amount,omitempty" json:"payment_amount,omitempty"`
+	TransferTime   string `xml:"transfer_time,omitempty" json:"transfer_time,omitempty"`
+	Desc           string `xml:"desc,omitempty" json:"desc,omitempty"`
+}
+
+type SendWorkWxRedResponse struct {
+	ReturnCode          string `xml:"return_code,omitempty" json:"return_code,omitempty"`
+	ReturnMsg           string `xml:"return_msg,omitempty" json:"return_msg,omitempty"`
+	Sign                string `xml:"sign,omitempty" json:"sign,omitempty"`
+	ResultCode          string `xml:"result_code,omitempty" json:"result_code,omitempty"`
+	ErrCode             string `xml:"err_code,omitempty" json:"err_code,omitempty"`
+	ErrCodeDes          string `xml:"err_code_des,omitempty" json:"err_code_des,omitempty"`
+	MchBillno           string `xml:"mch_billno,omitempty" json:"mch_billno,omitempty"`
+	MchId               string `xml:"mch_id,omitempty" json:"mch_id,omitempty"`
+	Wxappid             string `xml:"wxappid,omitempty" json:"wxappid,omitempty"`
+	ReOpenid            string `xml:"re_openid,omitempty" json:"re_openid,omitempty"`
+	TotalAmount         string `xml:"total_amount,omitempty" json:"total_amount,omitempty"`
+	SendListid          string `xml:"send_listid,omitempty" json:"send_listid,omitempty"`
+	SenderName          string `xml:"sender_name,omitempty" json:"sender_name,omitempty"`
+	SenderHeaderMediaId string `xml:"sender_header_media_id,omitempty" json:"sender_header_media_id,omitempty"`
+}
+
+type QueryWorkWxRedResponse struct {
+	ReturnCode          string `xml:"return_code,omitempty" json:"return_code,omitempty"`
+	ReturnMsg           string `xml:"return_msg,omitempty" json:"return_msg,omitempty"`
+	Sign                string `xml:"sign,omitempty" json:"sign,omitempty"`
+	ResultCode          string `xml:"result_code,omitempty" json:"result_code,omitempty"`
+	ErrCode             string `xml:"err_code,omitempty" json:"err_code,omitempty"`
+	ErrCodeDes          string `xml:"err_code_des,omitempty" json:"err_code_des,omitempty"`
+	MchBillno           string `xml:"mch_billno,omitempty" json:"mch_billno,omitempty"`
+	MchId               string `xml:"mch_id,omitempty" json:"mch_id,omitempty"`
+	DetailId            string `xml:"detail_id,omitempty" json:"detail_id,omitempty"`
+	Status              string `xml:"status,omitempty" json:"status,omitempty"`
+	SendType            string `xml:"send_type,omitempty" json:"send_type,omitempty"`
+	TotalAmount         string `xml:"total_amount,omitempty" json:"total_amount,omitempty"`
+	Reason              string `xml:"reason,omitempty" json:"reason,omitempty"`
+	SendTime            string `xml:"send_time,omitempty" json:"send_time,omitempty"`
+	RefundTime          string `xml:"refund_time,omitempty" json:"refund_time,omitempty"`
+	RefundAmount        string `xml:"refund_amount,omitempty" json:"refund_amount,omitempty"`
+	Wishing             string `xml:"wishing,omitempty" json:"wishing,omitempty"`
+	Remark              string `xml:"remark,omitempty" json:"remark,omitempty"`
+	ActName             string `xml:"act_name,omitempty" json:"act_name,omitempty"`
+	Openid              string `xml:"openid,omitempty" json:"openid,omitempty"`
+	Amount              string `xml:"amount,omitempty" json:"amount,omitempty"`
+	RcvTime             string `xml:"rcv_time,omitempty" json:"rcv_time,omitempty"`
+	SenderName          string `xml:"sender_name,omitempty" json:"sender_name,omitempty"`
+	SenderHeaderMediaId string `xml:"sender_header_media_id,omitempty" json:"sender_header_media_id,omitempty"`
+}
@@ -43,6 +43,28 @@ func (w *Client) SetCountry(country Country) (client *Client) {
 	return w
 }
 
+// 设置备用API秘钥，用于API秘钥轮换期间的平滑过渡
+//
+//	请求签名始终使用 ApiKey，验签及解密时依次尝试 ApiKey、备用秘钥，任一通过即可
+//	轮换完成后，传空字符串移除备用秘钥
+func (w *Client) SetSecondaryApiKey(apiKey string) (client *Client) {
+	w.mu.Lock()
+	w.secondKey = apiKey
+	w.mu.Unlock()
+	return w
+}
+
+// apiKeys 返回验签可用的API秘钥，主秘钥在前
+func (w *Client) apiKeys() (keys []string) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	keys = []string{w.ApiKey}
+	if w.secondKey != util.NULL && w.secondKey != w.ApiKey {
+		keys = append(keys, w.secondKey)
+	}
+	return keys
+}
+
 // Deprecated
 // 推荐使用 AddCertPemFileContent() 或 AddCertPemFilePath() 或 AddCertPkcs12FileContent() 或 AddCertPkcs12FilePath()
 // 添加微信证书路径或内容[]byte
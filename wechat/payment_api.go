@@ -177,6 +177,20 @@ func DecryptRefundNotifyReqInfoToBodyMap(reqInfo, apiKey string) (resBm gopay.Bo
 	return
 }
 
+// DecryptRefundNotifyReqInfo 使用 client 的API秘钥解密微信退款异步通知的加密数据
+//
+//	注意：设置了备用秘钥（client.SetSecondaryApiKey()）时，主秘钥解密失败后会尝试备用秘钥
+//	reqInfo：gopay.ParseRefundNotify() 方法获取的加密数据 req_info
+//	文档：https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_16&index=10
+func (w *Client) DecryptRefundNotifyReqInfo(reqInfo string) (refundNotify *RefundNotify, err error) {
+	for _, apiKey := range w.apiKeys() {
+		if refundNotify, err = DecryptRefundNotifyReqInfo(reqInfo, apiKey); err == nil {
+			return refundNotify, nil
+		}
+	}
+	return nil, err
+}
+
 type NotifyResponse struct {
 	ReturnCode string `xml:"return_code"`
 	ReturnMsg  string `xml:"return_msg"`
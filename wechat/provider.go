@@ -0,0 +1,299 @@
+package wechat
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// ProviderName 微信支付V2在 gopay.RegisterProvider() 中注册的渠道名称
+const ProviderName = "wechat"
+
+func init() {
+	gopay.RegisterProvider(ProviderName, newProvider)
+}
+
+// provider 微信支付V2的 gopay.Provider 实现
+type provider struct {
+	client    *Client
+	notifyUrl string
+}
+
+// newProvider 根据配置创建微信支付V2 Provider
+//	config 参数：
+//	appid、mch_id、api_key：必填
+//	is_prod：是否是正式环境，true 或 false
+//	notify_url：默认异步通知地址，下单时 OrderRequest.NotifyUrl 为空则使用此值
+//	cert_pem、key_pem：apiclient_cert.pem、apiclient_key.pem 证书内容，申请退款时必填
+func newProvider(config gopay.BodyMap) (p gopay.Provider, err error) {
+	if err = config.CheckEmptyError("appid", "mch_id", "api_key"); err != nil {
+		return nil, err
+	}
+	var isProd bool
+	if s := config.GetString("is_prod"); s != util.NULL {
+		if isProd, err = strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("is_prod error：%w", err)
+		}
+	}
+	client := NewClient(config.GetString("appid"), config.GetString("mch_id"), config.GetString("api_key"), isProd)
+	if certPem, keyPem := config.GetString("cert_pem"), config.GetString("key_pem"); certPem != util.NULL || keyPem != util.NULL {
+		if err = client.AddCertPemFileContent([]byte(certPem), []byte(keyPem)); err != nil {
+			return nil, err
+		}
+	}
+	return &provider{client: client, notifyUrl: config.GetString("notify_url")}, nil
+}
+
+// CreateOrder 统一下单
+//	Scene 与 trade_type 对应关系：NATIVE、JSAPI、APP、H5（MWEB），不支持 PAGE
+//	PayInfo：NATIVE 返回 code_url，H5 返回 mweb_url，JSAPI、APP 返回 prepay_id
+func (p *provider) CreateOrder(ctx context.Context, req *gopay.OrderRequest) (rsp *gopay.OrderResponse, err error) {
+	var tradeType string
+	switch req.Scene {
+	case gopay.SceneNative:
+		tradeType = TradeType_Native
+	case gopay.SceneJSAPI:
+		tradeType = TradeType_JsApi
+	case gopay.SceneApp:
+		tradeType = TradeType_App
+	case gopay.SceneH5:
+		tradeType = TradeType_H5
+	default:
+		return nil, fmt.Errorf("wechat: scene [%s]：%w", req.Scene, gopay.ErrNotSupported)
+	}
+	notifyUrl := req.NotifyUrl
+	if notifyUrl == util.NULL {
+		notifyUrl = p.notifyUrl
+	}
+	bm := make(gopay.BodyMap)
+	for k, v := range req.Extra {
+		bm.Set(k, v)
+	}
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("body", req.Subject).
+		Set("out_trade_no", req.OutTradeNo).
+		Set("total_fee", req.Amount).
+		Set("spbill_create_ip", req.ClientIp).
+		Set("notify_url", notifyUrl).
+		Set("trade_type", tradeType)
+	if req.OpenId != util.NULL {
+		bm.Set("openid", req.OpenId)
+	}
+	_, res, err := p.client.UnifiedOrderWithResult(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := p.checkResponse(ctx, bm, res.Raw)
+	if err != nil {
+		return nil, err
+	}
+	rsp = &gopay.OrderResponse{OutTradeNo: req.OutTradeNo, Raw: raw}
+	switch tradeType {
+	case TradeType_Native:
+		rsp.PayInfo = raw.GetString("code_url")
+	case TradeType_H5:
+		rsp.PayInfo = raw.GetString("mweb_url")
+	default:
+		rsp.PayInfo = raw.GetString("prepay_id")
+	}
+	return rsp, nil
+}
+
+// QueryOrder 查询订单
+func (p *provider) QueryOrder(ctx context.Context, req *gopay.QueryRequest) (rsp *gopay.TradeResult, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32))
+	setTradeNo(bm, req.OutTradeNo, req.TradeNo)
+	_, res, err := p.client.QueryOrderWithResult(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := p.checkResponse(ctx, bm, res.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return toTradeResult(raw, tradeStatus(raw.GetString("trade_state"))), nil
+}
+
+// Refund 申请退款，微信受理成功后 Status 为 gopay.RefundStatusProcessing，退款结果通过 QueryRefund 查询
+func (p *provider) Refund(ctx context.Context, req *gopay.RefundRequest) (rsp *gopay.RefundResult, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_refund_no", req.OutRefundNo).
+		Set("total_fee", req.TotalAmount).
+		Set("refund_fee", req.RefundAmount)
+	if req.Reason != util.NULL {
+		bm.Set("refund_desc", req.Reason)
+	}
+	setTradeNo(bm, req.OutTradeNo, req.TradeNo)
+	_, res, err := p.client.RefundWithResult(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := p.checkResponse(ctx, bm, res.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return &gopay.RefundResult{
+		OutRefundNo: raw.GetString("out_refund_no"),
+		RefundNo:    raw.GetString("refund_id"),
+		Status:      gopay.RefundStatusProcessing,
+		Amount:      parseFee(raw.GetString("refund_fee")),
+		Raw:         raw,
+	}, nil
+}
+
+// QueryRefund 查询退款，OutRefundNo 为空时返回订单的第一笔退款
+func (p *provider) QueryRefund(ctx context.Context, req *gopay.RefundQueryRequest) (rsp *gopay.RefundResult, err error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32))
+	if req.OutRefundNo != util.NULL {
+		bm.Set("out_refund_no", req.OutRefundNo)
+	} else {
+		setTradeNo(bm, req.OutTradeNo, req.TradeNo)
+	}
+	_, res, err := p.client.QueryRefundWithResult(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := p.checkResponse(ctx, bm, res.Raw)
+	if err != nil {
+		return nil, err
+	}
+	rsp = &gopay.RefundResult{
+		OutRefundNo: raw.GetString("out_refund_no_0"),
+		RefundNo:    raw.GetString("refund_id_0"),
+		Amount:      parseFee(raw.GetString("refund_fee_0")),
+		Raw:         raw,
+	}
+	switch raw.GetString("refund_status_0") {
+	case RefundStatus_Success:
+		rsp.Status = gopay.RefundStatusSuccess
+	case RefundStatus_RefundClose, RefundStatus_Change:
+		rsp.Status = gopay.RefundStatusFail
+	default:
+		rsp.Status = gopay.RefundStatusProcessing
+	}
+	return rsp, nil
+}
+
+// Close 关闭订单，只支持商户订单号
+func (p *provider) Close(ctx context.Context, req *gopay.QueryRequest) (err error) {
+	if req.OutTradeNo == util.NULL {
+		return errors.New("wechat: close order requires out_trade_no")
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_trade_no", req.OutTradeNo)
+	_, res, err := p.client.CloseOrderWithResult(ctx, bm)
+	if err != nil {
+		return err
+	}
+	_, err = p.checkResponse(ctx, bm, res.Raw)
+	return err
+}
+
+// VerifyNotify 解析并验签支付结果通知，result_code 不为 SUCCESS 时 Status 为 gopay.TradeStatusNotPay
+//	验签失败或 return_code 不为 SUCCESS 时返回错误
+func (p *provider) VerifyNotify(req *http.Request) (notify *gopay.TradeResult, err error) {
+	bm, err := ParseNotifyToBodyMap(req)
+	if err != nil {
+		return nil, err
+	}
+	if bm.GetString("return_code") != gopay.SUCCESS {
+		return nil, fmt.Errorf("wechat: notify return_code = %s, return_msg = %s", bm.GetString("return_code"), bm.GetString("return_msg"))
+	}
+	signType := bm.GetString("sign_type")
+	if signType == util.NULL {
+		signType = SignType_MD5
+	}
+	ok, err := p.client.VerifySign(signType, bm)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("wechat: notify sign verify failed")
+	}
+	status := gopay.TradeStatusNotPay
+	if bm.GetString("result_code") == gopay.SUCCESS {
+		status = gopay.TradeStatusSuccess
+	}
+	return toTradeResult(bm, status), nil
+}
+
+// checkResponse 解析应答，校验 return_code、result_code，正式环境校验应答签名
+//	沙箱环境应答使用沙箱秘钥签名，不校验
+func (p *provider) checkResponse(ctx context.Context, reqBm gopay.BodyMap, bs []byte) (raw gopay.BodyMap, err error) {
+	raw = make(gopay.BodyMap)
+	if err = xml.Unmarshal(bs, &raw); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if raw.GetString("return_code") != gopay.SUCCESS {
+		return raw, fmt.Errorf("wechat: return_code = %s, return_msg = %s", raw.GetString("return_code"), raw.GetString("return_msg"))
+	}
+	if p.client.IsProd {
+		signType := reqBm.GetString("sign_type")
+		if st, ok := signTypeFromContext(ctx); ok {
+			signType = st
+		}
+		ok, err := p.client.VerifySign(signType, raw)
+		if err != nil {
+			return raw, err
+		}
+		if !ok {
+			return raw, errors.New("wechat: response sign verify failed")
+		}
+	}
+	if raw.GetString("result_code") != gopay.SUCCESS {
+		return raw, fmt.Errorf("wechat: err_code = %s, err_code_des = %s", raw.GetString("err_code"), raw.GetString("err_code_des"))
+	}
+	return raw, nil
+}
+
+// setTradeNo 优先使用商户订单号
+func setTradeNo(bm gopay.BodyMap, outTradeNo, tradeNo string) {
+	if outTradeNo != util.NULL {
+		bm.Set("out_trade_no", outTradeNo)
+		return
+	}
+	if tradeNo != util.NULL {
+		bm.Set("transaction_id", tradeNo)
+	}
+}
+
+func toTradeResult(bm gopay.BodyMap, status gopay.TradeStatus) *gopay.TradeResult {
+	return &gopay.TradeResult{
+		OutTradeNo: bm.GetString("out_trade_no"),
+		TradeNo:    bm.GetString("transaction_id"),
+		Status:     status,
+		Amount:     parseFee(bm.GetString("total_fee")),
+		Raw:        bm,
+	}
+}
+
+// tradeStatus 微信 trade_state 转换为通用交易状态
+func tradeStatus(tradeState string) gopay.TradeStatus {
+	switch tradeState {
+	case TradeState_Success:
+		return gopay.TradeStatusSuccess
+	case TradeState_Refund:
+		return gopay.TradeStatusRefund
+	case TradeState_NotPay, TradeState_UserPaying, TradeState_PayError:
+		return gopay.TradeStatusNotPay
+	case TradeState_Closed, TradeState_Revoked:
+		return gopay.TradeStatusClosed
+	default:
+		return gopay.TradeStatusUnknown
+	}
+}
+
+func parseFee(fee string) int64 {
+	n, _ := strconv.ParseInt(fee, 10, 64)
+	return n
+}
@@ -0,0 +1,191 @@
+package wechat
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+// newProviderTestServer 模拟微信支付V2接口：校验请求签名，按路径返回签名后的应答
+func newProviderTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := ioutil.ReadAll(r.Body)
+		req := make(gopay.BodyMap)
+		if err := xml.Unmarshal(bs, &req); err != nil {
+			t.Errorf("xml.Unmarshal(%s)：%v", bs, err)
+			return
+		}
+		if ok, _ := VerifySign(apiKey, SignType_MD5, req.Clone()); !ok || req.GetString("mch_id") != mchId {
+			_, _ = w.Write([]byte(GenerateXml(gopay.BodyMap{"return_code": gopay.FAIL, "return_msg": "签名错误"})))
+			return
+		}
+		rsp := make(gopay.BodyMap)
+		rsp.Set("return_code", gopay.SUCCESS).
+			Set("result_code", gopay.SUCCESS).
+			Set("appid", appId).
+			Set("mch_id", mchId).
+			Set("nonce_str", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS")
+		switch strings.TrimPrefix(r.URL.Path, "/") {
+		case unifiedOrder:
+			rsp.Set("trade_type", req.GetString("trade_type")).
+				Set("prepay_id", "wx201410272009395522657a690389285100").
+				Set("code_url", "weixin://wxpay/bizpayurl?pr=8SvyBeZ")
+		case orderQuery:
+			if req.GetString("out_trade_no") == "GOPAY_NOT_EXIST" {
+				rsp.Set("result_code", gopay.FAIL).Set("err_code", "ORDERNOTEXIST").Set("err_code_des", "此交易订单号不存在")
+				break
+			}
+			rsp.Set("out_trade_no", req.GetString("out_trade_no")).
+				Set("transaction_id", "4200001626202211012345678901").
+				Set("trade_state", TradeState_Success).
+				Set("total_fee", "101")
+		case refund:
+			rsp.Set("out_trade_no", req.GetString("out_trade_no")).
+				Set("out_refund_no", req.GetString("out_refund_no")).
+				Set("refund_id", "50000408942018111907145868882").
+				Set("refund_fee", req.GetString("refund_fee")).
+				Set("total_fee", req.GetString("total_fee"))
+		case refundQuery:
+			rsp.Set("out_refund_no_0", req.GetString("out_refund_no")).
+				Set("refund_id_0", "50000408942018111907145868882").
+				Set("refund_fee_0", "100").
+				Set("refund_status_0", RefundStatus_Success).
+				Set("refund_count", "1")
+		case closeOrder:
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rsp.Set("sign", GetReleaseSign(apiKey, SignType_MD5, rsp))
+		_, _ = w.Write([]byte(GenerateXml(rsp)))
+	}))
+}
+
+func TestProvider(t *testing.T) {
+	ts := newProviderTestServer(t)
+	defer ts.Close()
+
+	certPem, keyPem := newTestCertPem(t)
+	config := make(gopay.BodyMap)
+	config.Set("appid", appId).
+		Set("mch_id", mchId).
+		Set("api_key", apiKey).
+		Set("is_prod", true).
+		Set("notify_url", "https://www.fmm.ink/wechat/notify").
+		Set("cert_pem", string(certPem)).
+		Set("key_pem", string(keyPem))
+	p, err := gopay.NewProvider(ProviderName, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.(*provider).client.BaseURL = ts.URL + "/"
+	ctx := context.Background()
+
+	orderRsp, err := p.CreateOrder(ctx, &gopay.OrderRequest{
+		OutTradeNo: "GOPAY20221101001",
+		Amount:     101,
+		Subject:    "测试商品",
+		Scene:      gopay.SceneNative,
+		ClientIp:   "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orderRsp.PayInfo != "weixin://wxpay/bizpayurl?pr=8SvyBeZ" || orderRsp.OutTradeNo != "GOPAY20221101001" {
+		t.Errorf("CreateOrder() = %+v", orderRsp)
+	}
+	if _, err = p.CreateOrder(ctx, &gopay.OrderRequest{OutTradeNo: "GOPAY20221101001", Scene: gopay.ScenePage}); !errors.Is(err, gopay.ErrNotSupported) {
+		t.Errorf("CreateOrder(PAGE) error = %v", err)
+	}
+
+	queryRsp, err := p.QueryOrder(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY20221101001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queryRsp.Status != gopay.TradeStatusSuccess || queryRsp.Amount != 101 || queryRsp.TradeNo != "4200001626202211012345678901" {
+		t.Errorf("QueryOrder() = %+v", queryRsp)
+	}
+	if _, err = p.QueryOrder(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY_NOT_EXIST"}); err == nil || !strings.Contains(err.Error(), "ORDERNOTEXIST") {
+		t.Errorf("QueryOrder() of not exist order error = %v", err)
+	}
+
+	refundRsp, err := p.Refund(ctx, &gopay.RefundRequest{
+		OutTradeNo:   "GOPAY20221101001",
+		OutRefundNo:  "GOPAY20221101001R",
+		TotalAmount:  101,
+		RefundAmount: 100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refundRsp.Status != gopay.RefundStatusProcessing || refundRsp.Amount != 100 || refundRsp.OutRefundNo != "GOPAY20221101001R" {
+		t.Errorf("Refund() = %+v", refundRsp)
+	}
+
+	refundQueryRsp, err := p.QueryRefund(ctx, &gopay.RefundQueryRequest{OutRefundNo: "GOPAY20221101001R"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refundQueryRsp.Status != gopay.RefundStatusSuccess || refundQueryRsp.Amount != 100 || refundQueryRsp.OutRefundNo != "GOPAY20221101001R" {
+		t.Errorf("QueryRefund() = %+v", refundQueryRsp)
+	}
+
+	if err = p.Close(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY20221101001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 应答签名错误
+	p.(*provider).client.ApiKey = "other_api_key_other_api_key_0000"
+	if _, err = p.QueryOrder(ctx, &gopay.QueryRequest{OutTradeNo: "GOPAY20221101001"}); err == nil {
+		t.Error("QueryOrder() with wrong api key should return error")
+	}
+}
+
+func TestProvider_VerifyNotify(t *testing.T) {
+	config := make(gopay.BodyMap)
+	config.Set("appid", appId).
+		Set("mch_id", mchId).
+		Set("api_key", apiKey)
+	p, err := gopay.NewProvider(ProviderName, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notify := make(gopay.BodyMap)
+	notify.Set("return_code", gopay.SUCCESS).
+		Set("result_code", gopay.SUCCESS).
+		Set("appid", appId).
+		Set("mch_id", mchId).
+		Set("nonce_str", "5d2b6c2a8db53831f7eda20af46e531c").
+		Set("out_trade_no", "GOPAY20221101001").
+		Set("transaction_id", "4200001626202211012345678901").
+		Set("total_fee", "101").
+		Set("time_end", "20221101143522")
+	notify.Set("sign", GetReleaseSign(apiKey, SignType_MD5, notify))
+	newRequest := func(body string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/wechat/notify", strings.NewReader(body))
+	}
+
+	rsp, err := p.VerifyNotify(newRequest(GenerateXml(notify)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Status != gopay.TradeStatusSuccess || rsp.Amount != 101 || rsp.OutTradeNo != "GOPAY20221101001" {
+		t.Errorf("VerifyNotify() = %+v", rsp)
+	}
+	// 通知金额被篡改
+	notify.Set("total_fee", "1")
+	if _, err = p.VerifyNotify(newRequest(GenerateXml(notify))); err == nil {
+		t.Error("VerifyNotify() of notify with tampered total_fee should return error")
+	}
+
+	if _, err = gopay.NewProvider(ProviderName, make(gopay.BodyMap)); err == nil {
+		t.Error("NewProvider() with empty config should return error")
+	}
+}
@@ -24,23 +24,20 @@ func (w *Client) SendCashRed(ctx context.Context, bm gopay.BodyMap) (wxRsp *Send
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 	if bm.GetString("wxappid") == util.NULL {
 		bm.Set("wxappid", w.AppId)
 	}
 	if bm.GetString("mch_id") == util.NULL {
 		bm.Set("mch_id", w.MchId)
 	}
-	if bm.GetString("sign") == util.NULL {
-		sign := GetReleaseSign(w.ApiKey, SignType_MD5, bm)
-		bm.Set("sign", sign)
-	}
 
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	bs, header, err := w.doProdPostPure(ctx, bm, sendCashRed, tlsConfig)
+	bs, header, err := w.doProdPostPure(ctx, bm, sendCashRed, SignType_MD5, tlsConfig)
 	if err != nil {
 		return nil, header, err
 	}
@@ -60,6 +57,7 @@ func (w *Client) SendGroupCashRed(ctx context.Context, bm gopay.BodyMap) (wxRsp
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 
 	if bm.GetString("wxappid") == util.NULL {
 		bm.Set("wxappid", w.AppId)
@@ -67,17 +65,13 @@ func (w *Client) SendGroupCashRed(ctx context.Context, bm gopay.BodyMap) (wxRsp
 	if bm.GetString("mch_id") == util.NULL {
 		bm.Set("mch_id", w.MchId)
 	}
-	if bm.GetString("sign") == util.NULL {
-		sign := GetReleaseSign(w.ApiKey, SignType_MD5, bm)
-		bm.Set("sign", sign)
-	}
 
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	bs, header, err := w.doProdPostPure(ctx, bm, sendGroupCashRed, tlsConfig)
+	bs, header, err := w.doProdPostPure(ctx, bm, sendGroupCashRed, SignType_MD5, tlsConfig)
 	if err != nil {
 		return nil, header, err
 	}
@@ -97,6 +91,7 @@ func (w *Client) SendAppletRed(ctx context.Context, bm gopay.BodyMap) (wxRsp *Se
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 
 	if bm.GetString("wxappid") == util.NULL {
 		bm.Set("wxappid", w.AppId)
@@ -104,17 +99,13 @@ func (w *Client) SendAppletRed(ctx context.Context, bm gopay.BodyMap) (wxRsp *Se
 	if bm.GetString("mch_id") == util.NULL {
 		bm.Set("mch_id", w.MchId)
 	}
-	if bm.GetString("sign") == util.NULL {
-		sign := GetReleaseSign(w.ApiKey, SignType_MD5, bm)
-		bm.Set("sign", sign)
-	}
 
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	bs, header, err := w.doProdPostPure(ctx, bm, sendAppletRed, tlsConfig)
+	bs, header, err := w.doProdPostPure(ctx, bm, sendAppletRed, SignType_MD5, tlsConfig)
 	if err != nil {
 		return nil, header, err
 	}
@@ -134,6 +125,7 @@ func (w *Client) QueryRedRecord(ctx context.Context, bm gopay.BodyMap) (wxRsp *Q
 	if err != nil {
 		return nil, nil, err
 	}
+	bm = bm.Clone()
 
 	if bm.GetString("appid") == util.NULL {
 		bm.Set("appid", w.AppId)
@@ -141,17 +133,13 @@ func (w *Client) QueryRedRecord(ctx context.Context, bm gopay.BodyMap) (wxRsp *Q
 	if bm.GetString("mch_id") == util.NULL {
 		bm.Set("mch_id", w.MchId)
 	}
-	if bm.GetString("sign") == util.NULL {
-		sign := GetReleaseSign(w.ApiKey, SignType_MD5, bm)
-		bm.Set("sign", sign)
-	}
 
 	tlsConfig, err := w.addCertConfig(nil, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	bs, header, err := w.doProdPostPure(ctx, bm, getRedRecord, tlsConfig)
+	bs, header, err := w.doProdPostPure(ctx, bm, getRedRecord, SignType_MD5, tlsConfig)
 	if err != nil {
 		return nil, header, err
 	}
@@ -0,0 +1,254 @@
+package wechat
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+const (
+	// 付款码支付批量上报的 interface_url
+	micropayReportUrl = "https://api.mch.weixin.qq.com/pay/batchreport/micropay/total"
+	// 单次批量上报的最大交易条数
+	maxReportTrades = 50
+)
+
+// ReportTrade 付款码支付交易保障上报的单笔交易
+type ReportTrade struct {
+	OutTradeNo string `json:"out_trade_no"`
+	BeginTime  string `json:"begin_time"` // 交易开始时间，格式：yyyyMMddHHmmss
+	EndTime    string `json:"end_time"`   // 交易结束时间，格式：yyyyMMddHHmmss
+	State      string `json:"state"`      // 交易结果：OK、FAIL、CANCLE
+	ErrMsg     string `json:"err_msg"`    // 失败原因，state 为 OK 时为空
+}
+
+// Reporter 交易保障异步上报器
+//
+//	上报数据先进入缓冲队列，由后台协程按频率限制上报，不阻塞支付流程
+//	reporter.Submit() 提交的数据逐条调用 client.Report() 上报
+//	reporter.SubmitTrade() 提交的付款码交易按 user_ip 合并为 trades，批量调用 client.ReportMicropay() 上报
+//	队列已满或上报器已关闭时，新数据直接丢弃并计入 Dropped
+type Reporter struct {
+	client    *Client
+	queue     chan *reportItem
+	done      chan struct{}
+	interval  time.Duration
+	closed    bool
+	mu        sync.RWMutex
+	wg        sync.WaitGroup
+	submitted uint64
+	succeeded uint64
+	dropped   uint64
+	failed    uint64
+}
+
+// reportItem 上报队列中的数据，bm 与 trade 二选一
+type reportItem struct {
+	bm     gopay.BodyMap
+	userIp string
+	trade  *ReportTrade
+}
+
+// ReporterStat 交易保障上报统计，付款码交易按笔数统计
+type ReporterStat struct {
+	Submitted uint64 // 成功进入队列的条数
+	Succeeded uint64 // 上报成功的条数
+	Dropped   uint64 // 因队列已满或已关闭被丢弃的条数
+	Failed    uint64 // 上报失败的条数
+}
+
+// NewReporter 创建交易保障异步上报器
+//
+//	size：缓冲队列长度，<=0 时默认 1024
+//	interval：两次上报请求之间的最小间隔，用于限制上报频率，<=0 时不限频
+func (w *Client) NewReporter(size int, interval time.Duration) (r *Reporter) {
+	if size <= 0 {
+		size = 1024
+	}
+	r = &Reporter{
+		client:   w,
+		queue:    make(chan *reportItem, size),
+		done:     make(chan struct{}),
+		interval: interval,
+	}
+	r.wg.Add(1)
+	go r.loop()
+	return r
+}
+
+// Submit 提交一条交易保障上报数据，不会阻塞
+//
+//	bm 复制后入队，提交后调用方可继续修改或复用 bm
+//	返回参数 ok：是否成功进入上报队列
+func (r *Reporter) Submit(bm gopay.BodyMap) (ok bool) {
+	return r.submit(&reportItem{bm: bm.Clone()})
+}
+
+// SubmitTrade 提交一笔付款码支付交易，与队列中同一 userIp 的交易合并批量上报，不会阻塞
+//
+//	userIp：发起上报的商户机器IP
+//	返回参数 ok：是否成功进入上报队列
+func (r *Reporter) SubmitTrade(userIp string, trade *ReportTrade) (ok bool) {
+	if trade == nil {
+		return false
+	}
+	t := *trade
+	return r.submit(&reportItem{userIp: userIp, trade: &t})
+}
+
+func (r *Reporter) submit(item *reportItem) (ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		atomic.AddUint64(&r.dropped, 1)
+		return false
+	}
+	select {
+	case r.queue <- item:
+		atomic.AddUint64(&r.submitted, 1)
+		return true
+	default:
+		atomic.AddUint64(&r.dropped, 1)
+		return false
+	}
+}
+
+// Close 关闭上报器，不再等待频率限制，立即上报队列中的剩余数据并等待完成
+func (r *Reporter) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	close(r.done)
+	close(r.queue)
+	r.mu.Unlock()
+	r.wg.Wait()
+}
+
+// Stat 获取上报统计
+func (r *Reporter) Stat() (stat ReporterStat) {
+	return ReporterStat{
+		Submitted: atomic.LoadUint64(&r.submitted),
+		Succeeded: atomic.LoadUint64(&r.succeeded),
+		Dropped:   atomic.LoadUint64(&r.dropped),
+		Failed:    atomic.LoadUint64(&r.failed),
+	}
+}
+
+func (r *Reporter) loop() {
+	defer r.wg.Done()
+	var tick <-chan time.Time
+	if r.interval > 0 {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	// wait 按频率限制等待下一次上报，上报器关闭后不再等待
+	wait := func() {
+		if tick == nil {
+			return
+		}
+		select {
+		case <-tick:
+		case <-r.done:
+		}
+	}
+	for item := range r.queue {
+		var (
+			trades   = make(map[string][]*ReportTrade)
+			ips      []string
+			received = 1
+		)
+		// 合并队列中已有的数据，付款码交易按 user_ip 分组
+		for {
+			if item.trade != nil {
+				if _, ok := trades[item.userIp]; !ok {
+					ips = append(ips, item.userIp)
+				}
+				trades[item.userIp] = append(trades[item.userIp], item.trade)
+			} else {
+				r.report(item.bm)
+				wait()
+			}
+			if received >= maxReportTrades {
+				break
+			}
+			var ok bool
+			select {
+			case item, ok = <-r.queue:
+			default:
+			}
+			if !ok {
+				break
+			}
+			received++
+		}
+		for _, ip := range ips {
+			for ts := trades[ip]; len(ts) > 0; {
+				n := len(ts)
+				if n > maxReportTrades {
+					n = maxReportTrades
+				}
+				r.reportTrades(ip, ts[:n])
+				wait()
+				ts = ts[n:]
+			}
+		}
+	}
+}
+
+func (r *Reporter) report(bm gopay.BodyMap) {
+	defer func() {
+		if e := recover(); e != nil {
+			atomic.AddUint64(&r.failed, 1)
+			xlog.Errorf("Reporter.report panic: %+v", e)
+		}
+	}()
+	wxRsp, _, err := r.client.Report(bm)
+	r.count(wxRsp, err, 1)
+}
+
+func (r *Reporter) reportTrades(userIp string, trades []*ReportTrade) {
+	n := uint64(len(trades))
+	defer func() {
+		if e := recover(); e != nil {
+			atomic.AddUint64(&r.failed, n)
+			xlog.Errorf("Reporter.reportTrades panic: %+v", e)
+		}
+	}()
+	bs, err := json.Marshal(trades)
+	if err != nil {
+		r.count(nil, err, n)
+		return
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("interface_url", micropayReportUrl).
+		Set("user_ip", userIp).
+		Set("trades", string(bs))
+	wxRsp, _, err := r.client.ReportMicropay(bm)
+	r.count(wxRsp, err, n)
+}
+
+// count 统计上报结果，n 为本次上报包含的条数
+func (r *Reporter) count(wxRsp *ReportResponse, err error, n uint64) {
+	if err != nil {
+		atomic.AddUint64(&r.failed, n)
+		if r.client.DebugSwitch == gopay.DebugOn {
+			xlog.Errorf("Reporter.report: %+v", err)
+		}
+		return
+	}
+	if wxRsp.ReturnCode != gopay.SUCCESS || (wxRsp.ResultCode != "" && wxRsp.ResultCode != gopay.SUCCESS) {
+		atomic.AddUint64(&r.failed, n)
+		return
+	}
+	atomic.AddUint64(&r.succeeded, n)
+}
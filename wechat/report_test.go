@@ -0,0 +1,137 @@
+package wechat
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xlog"
+)
+
+func TestReporter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+
+	reporter := c.NewReporter(2, time.Millisecond)
+	for i := 0; i < 5; i++ {
+		bm := make(gopay.BodyMap)
+		bm.Set("nonce_str", util.GetRandomString(32)).
+			Set("interface_url", "https://api.mch.weixin.qq.com/pay/unifiedorder").
+			Set("execute_time", 1000).
+			Set("return_code", gopay.SUCCESS).
+			Set("return_msg", gopay.OK).
+			Set("result_code", gopay.SUCCESS).
+			Set("user_ip", "127.0.0.1")
+		reporter.Submit(bm)
+	}
+	reporter.Close()
+	if reporter.Submit(make(gopay.BodyMap)) {
+		t.Fatal("Submit after Close should be dropped")
+	}
+
+	stat := reporter.Stat()
+	xlog.Debugf("stat: %+v", stat)
+	if stat.Submitted+stat.Dropped != 6 {
+		t.Fatalf("Submitted + Dropped = %d, want 6", stat.Submitted+stat.Dropped)
+	}
+	if stat.Succeeded+stat.Failed != stat.Submitted {
+		t.Fatalf("Succeeded + Failed = %d, want %d", stat.Succeeded+stat.Failed, stat.Submitted)
+	}
+}
+
+func TestReporter_Batch(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []gopay.BodyMap
+		release  = make(chan struct{})
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := ioutil.ReadAll(r.Body)
+		bm := make(gopay.BodyMap)
+		_ = xml.Unmarshal(bs, &bm)
+		mu.Lock()
+		requests = append(requests, bm)
+		first := len(requests) == 1
+		mu.Unlock()
+		if first {
+			// 第一条上报完成前，后续数据在队列中积压
+			<-release
+		}
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+	// 频率限制远大于测试时长，Close 时不应再等待
+	reporter := c.NewReporter(10, time.Hour)
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("interface_url", "https://api.mch.weixin.qq.com/pay/unifiedorder").
+		Set("execute_time", 1000).
+		Set("return_code", gopay.SUCCESS).
+		Set("return_msg", gopay.OK).
+		Set("result_code", gopay.SUCCESS).
+		Set("user_ip", "127.0.0.1")
+	if !reporter.Submit(bm) {
+		t.Fatal("Submit() should succeed")
+	}
+	// 提交后修改 bm 不影响上报内容
+	bm.Set("user_ip", "10.0.0.1")
+	for i := 0; i < 5; i++ {
+		trade := &ReportTrade{
+			OutTradeNo: "GOPAY2022110100" + strconv.Itoa(i),
+			BeginTime:  "20221101143500",
+			EndTime:    "20221101143501",
+			State:      "OK",
+		}
+		if !reporter.SubmitTrade("127.0.0.1", trade) {
+			t.Fatal("SubmitTrade() should succeed")
+		}
+	}
+	close(release)
+
+	done := make(chan struct{})
+	go func() {
+		reporter.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() should not wait for rate limit interval")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(requests))
+	}
+	if requests[0].GetString("user_ip") != "127.0.0.1" {
+		t.Errorf("Submit() should clone bm, user_ip = %s", requests[0].GetString("user_ip"))
+	}
+	var trades []*ReportTrade
+	if err := json.Unmarshal([]byte(requests[1].GetString("trades")), &trades); err != nil {
+		t.Fatal(err)
+	}
+	if requests[1].GetString("interface_url") != micropayReportUrl || len(trades) != 5 || trades[4].OutTradeNo != "GOPAY20221101004" {
+		t.Errorf("micropay report = %v", requests[1])
+	}
+	if stat := reporter.Stat(); stat.Submitted != 6 || stat.Succeeded != 6 {
+		t.Errorf("Stat() = %+v", stat)
+	}
+}
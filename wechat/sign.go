@@ -47,6 +47,40 @@ func VerifySign(apiKey, signType string, bean interface{}) (ok bool, err error)
 	return GetReleaseSign(apiKey, signType, bm) == bodySign, nil
 }
 
+// VerifySign 使用 client 的API秘钥对微信同步返回参数或异步通知参数验签
+//
+//	注意：设置了备用秘钥（client.SetSecondaryApiKey()）时，主秘钥或备用秘钥任一验签通过即返回 ok
+//	signType：签名类型（调用API方法时填写的类型）
+//	bean：微信同步返回的结构体 wxRsp 或 异步通知解析的结构体 notifyReq，推荐通 BodyMap 验签
+//	返回参数ok：是否验签通过
+//	返回参数err：其他错误信息，不要根据 error 是否为空来判断验签正确与否，需再单独判断返回的 ok
+func (w *Client) VerifySign(signType string, bean interface{}) (ok bool, err error) {
+	if bean == nil {
+		return false, errors.New("bean is nil")
+	}
+	var bm gopay.BodyMap
+	if b, isBm := bean.(gopay.BodyMap); isBm {
+		bm = b.Clone()
+	} else {
+		bs, err := json.Marshal(bean)
+		if err != nil {
+			return false, fmt.Errorf("json.Marshal(%s)：%w", string(bs), err)
+		}
+		bm = make(gopay.BodyMap)
+		if err = json.Unmarshal(bs, &bm); err != nil {
+			return false, fmt.Errorf("json.Marshal(%s)：%w", string(bs), err)
+		}
+	}
+	bodySign := bm.GetString("sign")
+	bm.Remove("sign")
+	for _, apiKey := range w.apiKeys() {
+		if GetReleaseSign(apiKey, signType, bm) == bodySign {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // GetMiniPaySign JSAPI支付，统一下单获取支付参数后，再次计算出小程序用的paySign
 //	appId：APPID
 //	nonceStr：随即字符串
@@ -0,0 +1,37 @@
+package wechat
+
+import (
+	"testing"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+func TestClient_VerifySign(t *testing.T) {
+	oldKey := "GFDS8j98rewnmgl45wHTt980jg543abc"
+	c := NewClient(appId, mchId, apiKey, true)
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_trade_no", "GOPAY_TEST").
+		Set("return_code", gopay.SUCCESS)
+	bm.Set("sign", GetReleaseSign(oldKey, SignType_MD5, bm))
+
+	if ok, _ := c.VerifySign(SignType_MD5, bm); ok {
+		t.Fatal("sign by old key should fail without secondary key")
+	}
+	c.SetSecondaryApiKey(oldKey)
+	if ok, err := c.VerifySign(SignType_MD5, bm); !ok || err != nil {
+		t.Fatalf("VerifySign() = %v, %v", ok, err)
+	}
+	if bm.GetString("sign") == util.NULL {
+		t.Fatal("caller BodyMap sign was removed")
+	}
+
+	signBm := bm.Clone()
+	signBm.Remove("sign")
+	bm.Set("sign", GetReleaseSign(apiKey, SignType_MD5, signBm))
+	if ok, err := c.VerifySign(SignType_MD5, bm); !ok || err != nil {
+		t.Fatalf("VerifySign() = %v, %v", ok, err)
+	}
+}
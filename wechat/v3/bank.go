@@ -0,0 +1,174 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 获取对私银行卡号开户银行API
+//	accountNumber：银行卡号，需调用 client.V3EncryptText() 进行加密
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter11_2_1.shtml
+func (c *ClientV3) V3BankSearchBank(accountNumber string) (wxRsp *BankSearchBankRsp, err error) {
+	uri := v3BankSearchBank + "?account_number=" + url.QueryEscape(accountNumber)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BankSearchBankRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BankSearchBank)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询支持个人业务的银行列表API
+//	bm：offset、limit 分页参数
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter11_2_2.shtml
+func (c *ClientV3) V3BankPersonalList(bm gopay.BodyMap) (wxRsp *BankListRsp, err error) {
+	uri := v3BankPersonalList + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BankListRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BankList)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询支持对公业务的银行列表API
+//	bm：offset、limit 分页参数
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter11_2_3.shtml
+func (c *ClientV3) V3BankCorporateList(bm gopay.BodyMap) (wxRsp *BankListRsp, err error) {
+	uri := v3BankCorporateList + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BankListRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BankList)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询省份列表API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter11_2_4.shtml
+func (c *ClientV3) V3BankProvinces() (wxRsp *BankProvinceListRsp, err error) {
+	authorization, err := c.authorization(MethodGet, v3BankProvinces, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(v3BankProvinces, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BankProvinceListRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BankProvinceList)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询城市列表API
+//	provinceCode：省份编码，通过 client.V3BankProvinces() 获取
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter11_2_5.shtml
+func (c *ClientV3) V3BankCities(provinceCode int) (wxRsp *BankCityListRsp, err error) {
+	uri := fmt.Sprintf(v3BankCities, provinceCode)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BankCityListRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BankCityList)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询支行列表API
+//	bankAliasCode：银行别名编码，通过银行列表API获取
+//	bm：city_code、offset、limit
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter11_2_6.shtml
+func (c *ClientV3) V3BankBranches(bankAliasCode string, bm gopay.BodyMap) (wxRsp *BankBranchListRsp, err error) {
+	if err = bm.CheckEmptyError("city_code", "offset", "limit"); err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf(v3BankBranches, bankAliasCode) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BankBranchListRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BankBranchList)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
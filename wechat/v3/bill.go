@@ -1,17 +1,30 @@
 package wechat
 
 import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/util"
+	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
+// ErrBillHashMismatch 下载的账单文件与申请账单API返回的 hash_value 不一致
+var ErrBillHashMismatch = errors.New("wechat: bill file hash mismatch")
+
 // 申请交易账单API
 //	Code = 0 is success
 //	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_6.shtml
@@ -181,3 +194,92 @@ func (c *ClientV3) V3BillDownLoadBill(downloadUrl string) (fileBytes []byte, err
 	}
 	return bs, nil
 }
+
+// 下载账单API，账单文件以流的方式写入 w，适用于大文件账单
+//	bill：申请交易账单、申请资金账单API返回的 wxRsp.Response
+//	申请账单时 tar_type 为 GZIP 的压缩账单，自动解压后写入 w
+//	bill.HashValue 不为空时，按 bill.HashType（SHA1、SHA256）校验解压后的账单文件，校验失败返回 ErrBillHashMismatch
+//	注意：校验失败时账单内容已写入 w，请丢弃 w 中的内容
+//	注意：请求经过 client.Use() 添加的中间件，不限制整体下载时长，请通过 ctx 设置超时时间
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_8.shtml
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter4_1_8.shtml
+func (c *ClientV3) V3BillDownLoadBillTo(ctx context.Context, w io.Writer, bill *TradeBill) (n int64, err error) {
+	if bill == nil || bill.DownloadUrl == gopay.NULL {
+		return 0, errors.New("invalid download url")
+	}
+	u, err := url.Parse(bill.DownloadUrl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid download url: %w", err)
+	}
+	var h hash.Hash
+	if bill.HashValue != gopay.NULL {
+		switch strings.ToUpper(bill.HashType) {
+		case "SHA1", gopay.NULL:
+			h = sha1.New()
+		case "SHA256":
+			h = sha256.New()
+		default:
+			return 0, fmt.Errorf("unsupported hash_type: %s", bill.HashType)
+		}
+	}
+	authorization, err := c.authorization(MethodGet, u.RequestURI(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Wechat_V3_Url: %s", bill.DownloadUrl)
+		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
+	}
+	// 应答Body经 pr 流式解压、校验后写入 w
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var copyErr error
+		n, copyErr = copyBill(w, pr, h)
+		pr.CloseWithError(copyErr)
+		done <- copyErr
+	}()
+	req := &Request{
+		Method: MethodGet,
+		URL:    bill.DownloadUrl,
+		Header: make(http.Header),
+		Writer: pw,
+	}
+	req.Header.Add(HeaderAuthorization, authorization)
+	req.Header.Add("Accept", "*/*")
+	res, bs, err := c.handler()(ctx, req)
+	pw.CloseWithError(err)
+	copyErr := <-done
+	if err != nil {
+		return n, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return n, fmt.Errorf("download bill, status code: %d, body: %s", res.StatusCode, string(bs))
+	}
+	if copyErr != nil {
+		return n, copyErr
+	}
+	if h != nil {
+		if sum := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(sum, bill.HashValue) {
+			return n, fmt.Errorf("%w: %s(%s), want %s", ErrBillHashMismatch, strings.ToUpper(bill.HashType), sum, bill.HashValue)
+		}
+	}
+	return n, nil
+}
+
+// copyBill 将账单文件写入 w，GZIP 压缩的账单自动解压，h 不为空时同时计算解压后文件的摘要
+func copyBill(w io.Writer, r io.Reader, h hash.Hash) (n int64, err error) {
+	var body io.Reader = bufio.NewReader(r)
+	if magic, _ := body.(*bufio.Reader).Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(body)
+		if err != nil {
+			return 0, fmt.Errorf("gzip.NewReader：%w", err)
+		}
+		defer gr.Close()
+		body = gr
+	}
+	if h != nil {
+		w = io.MultiWriter(w, h)
+	}
+	return io.Copy(w, body)
+}
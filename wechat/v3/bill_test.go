@@ -0,0 +1,80 @@
+package wechat
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestV3BillDownLoadBillTo(t *testing.T) {
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("交易时间,公众账号ID,商户号\n`2021-06-01 10:00:00,`wx2421b1c4370ec43b,`1230000109\n")
+	gz := new(bytes.Buffer)
+	gw := gzip.NewWriter(gz)
+	_, _ = gw.Write(content)
+	_ = gw.Close()
+
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get(HeaderAuthorization)
+		if r.URL.Query().Get("slow") != "" {
+			time.Sleep(300 * time.Millisecond)
+		}
+		if r.URL.Query().Get("tartype") == "gzip" {
+			w.Write(gz.Bytes())
+			return
+		}
+		w.Write(content)
+	}))
+	defer ts.Close()
+
+	var requests int
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, []byte, error) {
+			requests++
+			return next(ctx, req)
+		}
+	})
+
+	sum := sha1.Sum(content)
+	for _, downloadUrl := range []string{ts.URL + "/v3/billdownload/file?token=6XIv5TUPto", ts.URL + "/v3/billdownload/file?token=6XIv5TUPto&tartype=gzip"} {
+		buf := new(bytes.Buffer)
+		n, err := c.V3BillDownLoadBillTo(context.Background(), buf, &TradeBill{HashType: "SHA1", HashValue: hex.EncodeToString(sum[:]), DownloadUrl: downloadUrl})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(content)) || !bytes.Equal(buf.Bytes(), content) {
+			t.Fatalf("downloaded bill = %q, want %q", buf.String(), string(content))
+		}
+		if !strings.HasPrefix(authorization, Authorization) {
+			t.Fatalf("Authorization = %s", authorization)
+		}
+	}
+
+	_, err = c.V3BillDownLoadBillTo(context.Background(), new(bytes.Buffer), &TradeBill{HashType: "SHA1", HashValue: "invalid", DownloadUrl: ts.URL + "/v3/billdownload/file"})
+	if !errors.Is(err, ErrBillHashMismatch) {
+		t.Fatalf("V3BillDownLoadBillTo() error = %v, want ErrBillHashMismatch", err)
+	}
+	// 下载请求经过中间件链
+	if requests != 3 {
+		t.Fatalf("middleware requests = %d, want 3", requests)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.V3BillDownLoadBillTo(ctx, new(bytes.Buffer), &TradeBill{DownloadUrl: ts.URL + "/v3/billdownload/file?slow=1"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("V3BillDownLoadBillTo() error = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,150 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 发放品牌红包API
+//	bm：brand_id、out_batch_no、batch_name、batch_remark、total_amount、total_num、detail_list 等
+//	Code = 0 is success
+//	接口路径：POST /v3/brand-redpacket/brand-merchant-batches
+func (c *ClientV3) V3BrandRedpacketSend(bm gopay.BodyMap) (wxRsp *BrandRedpacketSendRsp, err error) {
+	if err = bm.CheckEmptyError("brand_id", "out_batch_no", "batch_name", "total_amount", "total_num", "detail_list"); err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3BrandRedpacketSend, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3BrandRedpacketSend, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BrandRedpacketSendRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandRedpacketSend)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过微信批次单号查询品牌红包批次单API
+//	bm：need_query_detail、detail_status、offset、limit 等查询参数，可为 nil
+//	Code = 0 is success
+//	接口路径：GET /v3/brand-redpacket/brand-merchant-batches/{batch_no}
+func (c *ClientV3) V3BrandRedpacketBatch(batchNo string, bm gopay.BodyMap) (wxRsp *BrandRedpacketBatchRsp, err error) {
+	uri := fmt.Sprintf(v3BrandRedpacketBatch, batchNo)
+	if len(bm) > 0 {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BrandRedpacketBatchRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandRedpacketBatch)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过商家批次单号查询品牌红包批次单API
+//	bm：need_query_detail、detail_status、offset、limit 等查询参数，可为 nil
+//	Code = 0 is success
+//	接口路径：GET /v3/brand-redpacket/brand-merchant-out-batches/{out_batch_no}
+func (c *ClientV3) V3BrandRedpacketOutBatch(outBatchNo string, bm gopay.BodyMap) (wxRsp *BrandRedpacketBatchRsp, err error) {
+	uri := fmt.Sprintf(v3BrandRedpacketOutBatch, outBatchNo)
+	if len(bm) > 0 {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BrandRedpacketBatchRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandRedpacketBatch)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过微信明细单号查询品牌红包明细单API
+//	Code = 0 is success
+//	接口路径：GET /v3/brand-redpacket/brand-merchant-batches/{batch_no}/details/{detail_no}
+func (c *ClientV3) V3BrandRedpacketDetail(batchNo, detailNo string) (wxRsp *BrandRedpacketDetailRsp, err error) {
+	uri := fmt.Sprintf(v3BrandRedpacketDetail, batchNo, detailNo)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BrandRedpacketDetailRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandRedpacketDetail)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过商家明细单号查询品牌红包明细单API
+//	Code = 0 is success
+//	接口路径：GET /v3/brand-redpacket/brand-merchant-out-batches/{out_batch_no}/out-details/{out_detail_no}
+func (c *ClientV3) V3BrandRedpacketOutDetail(outBatchNo, outDetailNo string) (wxRsp *BrandRedpacketDetailRsp, err error) {
+	uri := fmt.Sprintf(v3BrandRedpacketOutDetail, outBatchNo, outDetailNo)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BrandRedpacketDetailRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandRedpacketDetail)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
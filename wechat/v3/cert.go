@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -171,13 +172,239 @@ func (c *ClientV3) SetPlatformCert(wxPublicKeyContent []byte, wxSerialNo string)
 	if err != nil {
 		xlog.Errorf("SetPlatformCert(%s),err:%+v", wxPublicKeyContent, err)
 	}
+	c.mu.Lock()
 	if pubKey != nil {
 		c.wxPublicKey = pubKey
 	}
 	c.wxSerialNo = wxSerialNo
+	c.mu.Unlock()
 	return c
 }
 
+// 自动获取微信平台证书，按证书序列号缓存，并定时刷新
+//	interval：刷新间隔，小于等于0时默认12小时（官方建议间隔小于12小时）
+//	注意：开启后自动开启同步请求验签，验签时按应答 Wechatpay-Serial 匹配证书，遇到未缓存的证书序列号时会立即刷新一次
+//	注意：敏感信息加密使用最新启用的证书（即：证书启用时间较晚的证书）
+//	注意：不再使用时，请调用 client.StopRefreshPlatformCerts() 停止定时刷新
+//	注意：国密模式（NewClientV3SM）不支持，返回 ErrSMModeUnsupported，请使用 client.SetPlatformCertSM()
+//	注意：设置 client.SetCertStore() 后，优先使用存储中未超过 interval 的证书，下载的证书会写回存储
+func (c *ClientV3) AutoRefreshPlatformCerts(interval time.Duration) (err error) {
+	if c.smPrivateKey != nil {
+		return fmt.Errorf("AutoRefreshPlatformCerts()：%w, please use client.SetPlatformCertSM()", ErrSMModeUnsupported)
+	}
+	if interval <= 0 {
+		interval = 12 * time.Hour
+	}
+	if err = c.syncPlatformCerts(interval); err != nil {
+		return err
+	}
+	stop := make(chan struct{})
+	c.mu.Lock()
+	if c.refreshStop != nil {
+		close(c.refreshStop)
+	}
+	c.refreshStop = stop
+	c.mu.Unlock()
+	c.AutoVerifySign()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := c.syncPlatformCerts(interval); err != nil {
+					xlog.Errorf("syncPlatformCerts(),err:%+v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// 停止定时刷新微信平台证书，已缓存的证书继续使用
+func (c *ClientV3) StopRefreshPlatformCerts() {
+	c.mu.Lock()
+	if c.refreshStop != nil {
+		close(c.refreshStop)
+		c.refreshStop = nil
+	}
+	c.mu.Unlock()
+}
+
+// 设置平台证书存储，多实例部署时共享已刷新的平台证书
+//	store：NewMemoryCertStore()、NewFileCertStore()、NewRedisCertStore() 或自行实现的 CertStore
+//	注意：需配合 client.AutoRefreshPlatformCerts() 使用
+func (c *ClientV3) SetCertStore(store CertStore) (client *ClientV3) {
+	c.mu.Lock()
+	c.certStore = store
+	c.mu.Unlock()
+	return c
+}
+
+// syncPlatformCerts 存储中的证书未超过 maxAge 时直接使用，否则重新下载
+func (c *ClientV3) syncPlatformCerts(maxAge time.Duration) (err error) {
+	stored := c.loadStoredCerts()
+	if stored != nil && time.Since(stored.UpdatedAt) < maxAge {
+		return c.setPlatformCerts(stored.Certs)
+	}
+	return c.refreshPlatformCerts()
+}
+
+// loadStoredCerts 读取存储中的平台证书，未设置存储或读取失败时返回 nil
+func (c *ClientV3) loadStoredCerts() (stored *StoredPlatformCerts) {
+	c.mu.RLock()
+	store := c.certStore
+	c.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+	stored, err := store.Load(c.Mchid)
+	if err != nil {
+		xlog.Errorf("CertStore.Load(%s),err:%+v", c.Mchid, err)
+		return nil
+	}
+	if stored == nil || len(stored.Certs) == 0 {
+		return nil
+	}
+	return stored
+}
+
+// refreshPlatformCerts 下载并缓存微信平台证书，设置了证书存储时同时写入存储
+func (c *ClientV3) refreshPlatformCerts() (err error) {
+	certs, err := c.GetPlatformCerts()
+	if err != nil {
+		return err
+	}
+	if certs.Code != Success {
+		return fmt.Errorf("GetPlatformCerts(),code:%d,error:%s", certs.Code, certs.Error)
+	}
+	if err = c.setPlatformCerts(certs.Certs); err != nil {
+		return err
+	}
+	c.mu.RLock()
+	store := c.certStore
+	c.mu.RUnlock()
+	if store != nil {
+		if err = store.Save(c.Mchid, &StoredPlatformCerts{Certs: certs.Certs, UpdatedAt: time.Now()}); err != nil {
+			xlog.Errorf("CertStore.Save(%s),err:%+v", c.Mchid, err)
+		}
+	}
+	return nil
+}
+
+// setPlatformCerts 缓存平台证书，并将最新启用的证书设为加密敏感信息使用的证书
+func (c *ClientV3) setPlatformCerts(items []*PlatformCertItem) (err error) {
+	var (
+		certs  = make(map[string]*rsa.PublicKey, len(items))
+		latest *PlatformCertItem
+	)
+	for _, v := range items {
+		pubKey, err := xpem.DecodePublicKey([]byte(v.PublicKey))
+		if err != nil {
+			return err
+		}
+		certs[v.SerialNo] = pubKey
+		if latest == nil || certEffectiveTime(v).After(certEffectiveTime(latest)) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return errors.New("platform certs is empty")
+	}
+	c.mu.Lock()
+	c.certs = certs
+	c.wxPublicKey = certs[latest.SerialNo]
+	c.wxSerialNo = latest.SerialNo
+	c.refreshedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// platformPublicKey 按证书序列号获取平台证书公钥，未开启证书缓存时返回 client.SetPlatformCert() 设置的公钥
+func (c *ClientV3) platformPublicKey(serialNo string) (pubKey *rsa.PublicKey) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.certs == nil || serialNo == c.wxSerialNo {
+		return c.wxPublicKey
+	}
+	return c.certs[serialNo]
+}
+
+// platformSerialNo 加密敏感信息使用的平台证书序列号
+func (c *ClientV3) platformSerialNo() (serialNo string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.wxSerialNo
+}
+
+// WithPlatformSerial 返回固定使用指定平台证书的 client，请求头 Wechatpay-Serial 及敏感信息加密均使用该证书
+//	serialNo：client.V3EncryptTextWithSerial() 返回的证书序列号
+//	注意：返回的 client 不会定时刷新平台证书，仅用于本次请求，不需要调用 StopRefreshPlatformCerts()
+func (c *ClientV3) WithPlatformSerial(serialNo string) (client *ClientV3, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	pubKey := c.wxPublicKey
+	if serialNo != c.wxSerialNo {
+		pubKey = c.certs[serialNo]
+	}
+	if serialNo == util.NULL || pubKey == nil {
+		return nil, fmt.Errorf("platform cert [%s] not found", serialNo)
+	}
+	return &ClientV3{
+		Mchid:         c.Mchid,
+		SerialNo:      c.SerialNo,
+		apiV3Key:      c.apiV3Key,
+		wxSerialNo:    serialNo,
+		autoSign:      c.autoSign,
+		privateKey:    c.privateKey,
+		wxPublicKey:   pubKey,
+		DebugSwitch:   c.DebugSwitch,
+		smPrivateKey:  c.smPrivateKey,
+		wxSMPublicKey: c.wxSMPublicKey,
+		sm4Key:        c.sm4Key,
+		certs:         c.certs, // 刷新证书时整体替换，不会修改已有的 map，可直接共享
+		refreshedAt:   c.refreshedAt,
+		certStore:     c.certStore,
+		middlewares:   c.middlewares,
+		returnV3Error: c.returnV3Error,
+	}, nil
+}
+
+// refreshUnknownCert 开启证书自动刷新后，遇到未缓存的证书序列号时刷新证书，1分钟内最多刷新一次
+//	设置了证书存储时，优先使用其他实例已写入存储的证书
+func (c *ClientV3) refreshUnknownCert(serialNo string) (pubKey *rsa.PublicKey) {
+	c.mu.RLock()
+	enabled := c.refreshStop != nil && time.Since(c.refreshedAt) > time.Minute
+	c.mu.RUnlock()
+	if !enabled {
+		return nil
+	}
+	if stored := c.loadStoredCerts(); stored != nil {
+		for _, v := range stored.Certs {
+			if v.SerialNo == serialNo {
+				if err := c.setPlatformCerts(stored.Certs); err != nil {
+					xlog.Errorf("setPlatformCerts(),err:%+v", err)
+					break
+				}
+				return c.platformPublicKey(serialNo)
+			}
+		}
+	}
+	if err := c.refreshPlatformCerts(); err != nil {
+		xlog.Errorf("refreshPlatformCerts(),err:%+v", err)
+		return nil
+	}
+	return c.platformPublicKey(serialNo)
+}
+
+func certEffectiveTime(item *PlatformCertItem) time.Time {
+	t, _ := time.Parse(time.RFC3339, item.EffectiveTime)
+	return t
+}
+
 // 解密加密的证书
 func (c *ClientV3) DecryptCerts(ciphertext, nonce, additional string) (wxCerts string, err error) {
 	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
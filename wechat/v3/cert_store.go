@@ -0,0 +1,149 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CertStore 微信平台证书存储
+//	多实例部署时，通过 client.SetCertStore() 设置共享存储（如文件、Redis），各实例共用已刷新的平台证书，避免每个实例各自下载
+//	已提供实现：NewMemoryCertStore()、NewFileCertStore()、NewRedisCertStore()
+type CertStore interface {
+	// Load 读取商户的平台证书，未保存过时返回 nil, nil
+	Load(mchid string) (certs *StoredPlatformCerts, err error)
+	// Save 保存商户的平台证书
+	Save(mchid string, certs *StoredPlatformCerts) (err error)
+}
+
+// StoredPlatformCerts 存储的平台证书
+type StoredPlatformCerts struct {
+	Certs     []*PlatformCertItem `json:"certs"`      // 平台证书列表
+	UpdatedAt time.Time           `json:"updated_at"` // 证书下载时间
+}
+
+// MemoryCertStore 内存存储，仅在同一进程内的多个 ClientV3 之间共享
+type MemoryCertStore struct {
+	certs map[string]*StoredPlatformCerts
+	mu    sync.RWMutex
+}
+
+// NewMemoryCertStore 初始化内存平台证书存储
+func NewMemoryCertStore() *MemoryCertStore {
+	return &MemoryCertStore{certs: make(map[string]*StoredPlatformCerts)}
+}
+
+func (s *MemoryCertStore) Load(mchid string) (certs *StoredPlatformCerts, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.certs[mchid], nil
+}
+
+func (s *MemoryCertStore) Save(mchid string, certs *StoredPlatformCerts) (err error) {
+	s.mu.Lock()
+	s.certs[mchid] = certs
+	s.mu.Unlock()
+	return nil
+}
+
+// FileCertStore 文件存储，证书保存为 dir 目录下的 {mchid}.json，适用于共享磁盘的多实例部署
+type FileCertStore struct {
+	dir string
+}
+
+// NewFileCertStore 初始化文件平台证书存储
+//	dir：证书保存目录，不存在时自动创建
+func NewFileCertStore(dir string) (store *FileCertStore, err error) {
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileCertStore{dir: dir}, nil
+}
+
+func (s *FileCertStore) Load(mchid string) (certs *StoredPlatformCerts, err error) {
+	bs, err := ioutil.ReadFile(filepath.Join(s.dir, mchid+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	certs = new(StoredPlatformCerts)
+	if err = json.Unmarshal(bs, certs); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return certs, nil
+}
+
+func (s *FileCertStore) Save(mchid string, certs *StoredPlatformCerts) (err error) {
+	bs, err := json.Marshal(certs)
+	if err != nil {
+		return fmt.Errorf("json.Marshal：%w", err)
+	}
+	// 先写临时文件再重命名，避免其他实例读到写了一半的文件
+	tmp, err := ioutil.TempFile(s.dir, mchid+".json.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(bs); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, mchid+".json"))
+}
+
+// RedisClient Redis 客户端最小接口，gopay 不依赖具体的 Redis 库，由调用方适配
+//	例如 go-redis：Get 返回 redis.Nil 时应返回 "", nil
+type RedisClient interface {
+	Get(key string) (value string, err error)
+	Set(key, value string, expiration time.Duration) (err error)
+}
+
+// RedisCertStore Redis 存储，证书以 JSON 保存在 {keyPrefix}{mchid}
+type RedisCertStore struct {
+	client     RedisClient
+	keyPrefix  string
+	expiration time.Duration
+}
+
+// NewRedisCertStore 初始化 Redis 平台证书存储
+//	keyPrefix：key 前缀，为空时默认 gopay:wechat:v3:certs:
+//	expiration：过期时间，0 表示不过期
+func NewRedisCertStore(client RedisClient, keyPrefix string, expiration time.Duration) *RedisCertStore {
+	if keyPrefix == "" {
+		keyPrefix = "gopay:wechat:v3:certs:"
+	}
+	return &RedisCertStore{client: client, keyPrefix: keyPrefix, expiration: expiration}
+}
+
+func (s *RedisCertStore) Load(mchid string) (certs *StoredPlatformCerts, err error) {
+	value, err := s.client.Get(s.keyPrefix + mchid)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, nil
+	}
+	certs = new(StoredPlatformCerts)
+	if err = json.Unmarshal([]byte(value), certs); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", value, err)
+	}
+	return certs, nil
+}
+
+func (s *RedisCertStore) Save(mchid string, certs *StoredPlatformCerts) (err error) {
+	bs, err := json.Marshal(certs)
+	if err != nil {
+		return fmt.Errorf("json.Marshal：%w", err)
+	}
+	return s.client.Set(s.keyPrefix+mchid, string(bs), s.expiration)
+}
@@ -0,0 +1,139 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestSetPlatformCerts(t *testing.T) {
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.setPlatformCerts([]*PlatformCertItem{
+		{SerialNo: "OLD_SERIAL_NO", EffectiveTime: "2021-04-27T16:55:23+08:00", PublicKey: WxPublicKeyContent},
+		{SerialNo: WxPublicKeySerialNo, EffectiveTime: "2021-10-27T16:55:23+08:00", PublicKey: WxPublicKeyContent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sn := c.platformSerialNo(); sn != WxPublicKeySerialNo {
+		t.Fatalf("platformSerialNo() = %s, want %s", sn, WxPublicKeySerialNo)
+	}
+	if c.platformPublicKey("OLD_SERIAL_NO") == nil {
+		t.Fatal("cert OLD_SERIAL_NO should be cached")
+	}
+	if c.platformPublicKey("UNKNOWN_SERIAL_NO") != nil {
+		t.Fatal("unknown serial should not match any cert")
+	}
+
+	c.AutoVerifySign()
+	err = c.verifySyncSign(&SignInfo{HeaderSerial: "UNKNOWN_SERIAL_NO"})
+	var verifyErr *VerifySignError
+	if !errors.As(err, &verifyErr) || !errors.Is(err, ErrPlatformCertNotFound) || verifyErr.SerialNo != "UNKNOWN_SERIAL_NO" {
+		t.Fatalf("verifySyncSign() error = %v, want *VerifySignError", err)
+	}
+	c.DisableAutoVerifySign()
+	if err = c.verifySyncSign(&SignInfo{HeaderSerial: "UNKNOWN_SERIAL_NO"}); err != nil {
+		t.Fatalf("verifySyncSign() after DisableAutoVerifySign() error = %v", err)
+	}
+}
+
+func TestWithPlatformSerial(t *testing.T) {
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.setPlatformCerts([]*PlatformCertItem{
+		{SerialNo: WxPublicKeySerialNo, EffectiveTime: "2021-10-27T16:55:23+08:00", PublicKey: WxPublicKeyContent},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var serials []string
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) (*http.Response, []byte, error) {
+			serials = append(serials, r.Header.Get(HeaderSerial))
+			bs := []byte(`{}`)
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(string(bs)))}, bs, nil
+		}
+	})
+
+	_, serialNo, err := c.V3EncryptTextWithSerial("张三")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serialNo != WxPublicKeySerialNo {
+		t.Fatalf("V3EncryptTextWithSerial() serialNo = %s, want %s", serialNo, WxPublicKeySerialNo)
+	}
+	// 加密后、请求前平台证书已轮换
+	if err = c.setPlatformCerts([]*PlatformCertItem{
+		{SerialNo: WxPublicKeySerialNo, EffectiveTime: "2021-10-27T16:55:23+08:00", PublicKey: WxPublicKeyContent},
+		{SerialNo: "NEW_SERIAL_NO", EffectiveTime: "2022-10-27T16:55:23+08:00", PublicKey: WxPublicKeyContent},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	pinned, err := c.WithPlatformSerial(serialNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pinned.V3TransactionNative(gopay.BodyMap{"out_trade_no": "GOPAY_TEST"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.V3TransactionNative(gopay.BodyMap{"out_trade_no": "GOPAY_TEST"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(serials) != 2 || serials[0] != WxPublicKeySerialNo || serials[1] != "NEW_SERIAL_NO" {
+		t.Fatalf("request %s = %v, want [%s NEW_SERIAL_NO]", HeaderSerial, serials, WxPublicKeySerialNo)
+	}
+	if _, sn, _ := pinned.V3EncryptTextWithSerial("张三"); sn != WxPublicKeySerialNo {
+		t.Fatalf("pinned V3EncryptTextWithSerial() serialNo = %s, want %s", sn, WxPublicKeySerialNo)
+	}
+	if _, err = c.WithPlatformSerial("UNKNOWN_SERIAL_NO"); err == nil {
+		t.Fatal("WithPlatformSerial() of unknown serial should return error")
+	}
+}
+
+func TestCertStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopay-certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileStore, err := NewFileCertStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := &StoredPlatformCerts{
+		Certs:     []*PlatformCertItem{{SerialNo: WxPublicKeySerialNo, EffectiveTime: "2021-10-27T16:55:23+08:00", PublicKey: WxPublicKeyContent}},
+		UpdatedAt: time.Now(),
+	}
+	for _, store := range []CertStore{NewMemoryCertStore(), fileStore} {
+		if certs, err := store.Load(MchId); err != nil || certs != nil {
+			t.Fatalf("Load() before Save() = %v, %v", certs, err)
+		}
+		if err = store.Save(MchId, stored); err != nil {
+			t.Fatal(err)
+		}
+
+		// 存储中的证书未过期时，直接使用存储中的证书，不再下载
+		c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = c.SetCertStore(store).AutoRefreshPlatformCerts(time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		c.StopRefreshPlatformCerts()
+		if sn := c.platformSerialNo(); sn != WxPublicKeySerialNo {
+			t.Fatalf("platformSerialNo() = %s, want %s", sn, WxPublicKeySerialNo)
+		}
+	}
+}
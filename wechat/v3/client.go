@@ -1,11 +1,14 @@
 package wechat
 
 import (
+	"context"
 	"crypto/rsa"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/cedarwu/gopay"
-	"github.com/cedarwu/gopay/pkg/xhttp"
+	"github.com/cedarwu/gopay/pkg/sm2"
 	"github.com/cedarwu/gopay/pkg/xlog"
 	"github.com/cedarwu/gopay/pkg/xpem"
 )
@@ -20,6 +23,18 @@ type ClientV3 struct {
 	privateKey  *rsa.PrivateKey
 	wxPublicKey *rsa.PublicKey
 	DebugSwitch gopay.DebugSwitch
+
+	smPrivateKey  *sm2.PrivateKey // 国密模式商户私钥，通过 NewClientV3SM() 初始化
+	wxSMPublicKey *sm2.PublicKey  // 国密模式平台证书公钥
+	sm4Key        []byte          // 国密模式回调解密密钥
+
+	certs         map[string]*rsa.PublicKey // 平台证书缓存：证书序列号 => 公钥
+	refreshedAt   time.Time                 // 平台证书最近一次刷新时间
+	refreshStop   chan struct{}
+	certStore     CertStore // 平台证书存储，多实例共享
+	middlewares   []Middleware
+	returnV3Error bool
+	mu            sync.RWMutex
 }
 
 // NewClientV3 初始化微信客户端 V3
@@ -44,209 +59,100 @@ func NewClientV3(mchid, serialNo, apiV3Key, privateKey string) (client *ClientV3
 
 // AutoVerifySign 开启请求完自动验签功能（默认不开启，推荐开启）
 func (c *ClientV3) AutoVerifySign() {
-	if c.wxPublicKey != nil && c.wxSerialNo != "" {
+	c.mu.Lock()
+	if (c.wxPublicKey != nil || c.wxSMPublicKey != nil) && c.wxSerialNo != "" {
 		c.autoSign = true
 	}
+	c.mu.Unlock()
+}
+
+// ReturnV3Error 开启后，非2xx应答不再返回 Code、Error，改为返回 *V3Error
+//	可通过 errors.As(err, &v3Err) 获取 错误码、错误描述、HTTP状态码 及 Request-ID
+func (c *ClientV3) ReturnV3Error() {
+	c.mu.Lock()
+	c.returnV3Error = true
+	c.mu.Unlock()
+}
+
+// DisableAutoVerifySign 关闭请求完自动验签功能，仅建议调试时使用
+func (c *ClientV3) DisableAutoVerifySign() {
+	c.mu.Lock()
+	c.autoSign = false
+	c.mu.Unlock()
 }
 
 func (c *ClientV3) doProdPostWithHeader(headerMap map[string]string, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
-		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
-	}
-	for k, v := range headerMap {
-		httpClient.Header.Add(k, v)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, nil, errs[0]
-	}
-	si = &SignInfo{
-		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
-		HeaderNonce:     res.Header.Get(HeaderNonce),
-		HeaderSignature: res.Header.Get(HeaderSignature),
-		HeaderSerial:    res.Header.Get(HeaderSerial),
-		SignBody:        string(bs),
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("Wechat_Headers: %#v", res.Header)
-		xlog.Debugf("Wechat_SignInfo: %#v", si)
-	}
-	return res, si, bs, nil
+	return c.doProd(MethodPost, headerMap, bm, path, authorization, false)
 }
 
 func (c *ClientV3) doProdPost(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
-		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, nil, errs[0]
-	}
-	si = &SignInfo{
-		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
-		HeaderNonce:     res.Header.Get(HeaderNonce),
-		HeaderSignature: res.Header.Get(HeaderSignature),
-		HeaderSerial:    res.Header.Get(HeaderSerial),
-		SignBody:        string(bs),
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("Wechat_Headers: %#v", res.Header)
-		xlog.Debugf("Wechat_SignInfo: %#v", si)
-	}
-	return res, si, bs, nil
+	return c.doProd(MethodPost, nil, bm, path, authorization, false)
 }
 
 func (c *ClientV3) doProdGet(uri, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + uri
-	httpClient := xhttp.NewClient()
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_V3_Url: %s", url)
-		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Get(url).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, nil, errs[0]
-	}
-	si = &SignInfo{
-		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
-		HeaderNonce:     res.Header.Get(HeaderNonce),
-		HeaderSignature: res.Header.Get(HeaderSignature),
-		HeaderSerial:    res.Header.Get(HeaderSerial),
-		SignBody:        string(bs),
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("Wechat_Headers: %#v", res.Header)
-		xlog.Debugf("Wechat_SignInfo: %#v", si)
-	}
-	return res, si, bs, nil
+	return c.doProd(MethodGet, nil, nil, uri, authorization, false)
 }
 
 func (c *ClientV3) doProdPut(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
-		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Put(url).SendBodyMap(bm).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, nil, errs[0]
-	}
-	si = &SignInfo{
-		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
-		HeaderNonce:     res.Header.Get(HeaderNonce),
-		HeaderSignature: res.Header.Get(HeaderSignature),
-		HeaderSerial:    res.Header.Get(HeaderSerial),
-		SignBody:        string(bs),
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("Wechat_Headers: %#v", res.Header)
-		xlog.Debugf("Wechat_SignInfo: %#v", si)
-	}
-	return res, si, bs, nil
+	return c.doProd(MethodPut, nil, bm, path, authorization, false)
 }
 
 func (c *ClientV3) doProdDelete(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
-		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Delete(url).SendBodyMap(bm).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, nil, errs[0]
-	}
-	si = &SignInfo{
-		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
-		HeaderNonce:     res.Header.Get(HeaderNonce),
-		HeaderSignature: res.Header.Get(HeaderSignature),
-		HeaderSerial:    res.Header.Get(HeaderSerial),
-		SignBody:        string(bs),
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("Wechat_Headers: %#v", res.Header)
-		xlog.Debugf("Wechat_SignInfo: %#v", si)
-	}
-	return res, si, bs, nil
+	return c.doProd(MethodDelete, nil, bm, path, authorization, false)
 }
 
 func (c *ClientV3) doProdPostFile(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.GetString("meta"))
-		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeMultipartFormData).Post(url).SendMultipartBodyMap(bm).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, nil, errs[0]
-	}
-	si = &SignInfo{
-		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
-		HeaderNonce:     res.Header.Get(HeaderNonce),
-		HeaderSignature: res.Header.Get(HeaderSignature),
-		HeaderSerial:    res.Header.Get(HeaderSerial),
-		SignBody:        string(bs),
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("Wechat_Headers: %#v", res.Header)
-		xlog.Debugf("Wechat_SignInfo: %#v", si)
-	}
-	return res, si, bs, nil
+	return c.doProd(MethodPost, nil, bm, path, authorization, true)
 }
 
 func (c *ClientV3) doProdPatch(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
+	return c.doProd(MethodPATCH, nil, bm, path, authorization, false)
+}
+
+// doProd 组装已签名的请求，经过中间件链发送，并解析应答签名信息
+func (c *ClientV3) doProd(method string, headerMap map[string]string, bm gopay.BodyMap, path, authorization string, multipart bool) (res *http.Response, si *SignInfo, bs []byte, err error) {
+	req := &Request{
+		Method:    method,
+		URL:       v3BaseUrlCh + path,
+		Header:    make(http.Header),
+		Body:      bm,
+		Multipart: multipart,
+	}
 	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
+		switch {
+		case method == MethodGet:
+			xlog.Debugf("Wechat_V3_Url: %s", req.URL)
+		case multipart:
+			xlog.Debugf("Wechat_V3_RequestBody: %s", bm.GetString("meta"))
+		default:
+			xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
+		}
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
 	}
-	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Patch(url).SendBodyMap(bm).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, nil, errs[0]
+	for k, v := range headerMap {
+		req.Header.Add(k, v)
+	}
+	req.Header.Add(HeaderAuthorization, authorization)
+	req.Header.Add(HeaderSerial, c.platformSerialNo())
+	req.Header.Add("Accept", "*/*")
+	res, bs, err = c.handler()(context.Background(), req)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	si = &SignInfo{
 		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
 		HeaderNonce:     res.Header.Get(HeaderNonce),
 		HeaderSignature: res.Header.Get(HeaderSignature),
 		HeaderSerial:    res.Header.Get(HeaderSerial),
+		HeaderRequestId: res.Header.Get(HeaderRequestId),
 		SignBody:        string(bs),
 	}
+	c.mu.RLock()
+	returnV3Error := c.returnV3Error
+	c.mu.RUnlock()
+	if returnV3Error && (res.StatusCode < 200 || res.StatusCode >= 300) {
+		return nil, nil, nil, NewV3Error(res.StatusCode, string(bs), si)
+	}
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_Response: %d > %s", res.StatusCode, string(bs))
 		xlog.Debugf("Wechat_Headers: %#v", res.Header)
@@ -2,8 +2,10 @@ package wechat
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/cedarwu/gopay"
 	"github.com/cedarwu/gopay/pkg/util"
@@ -147,6 +149,31 @@ func (c *ClientV3) V3ComplaintUploadImage(fileName, fileSha256 string, img *util
 	return wxRsp, c.verifySyncSign(si)
 }
 
+// 下载投诉单图片API
+//	mediaUrl：查询投诉单详情API返回的 complaint_media_list 中的图片URL
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter10_2_18.shtml
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter10_2_18.shtml
+func (c *ClientV3) V3ComplaintImageDownload(mediaUrl string) (fileBytes []byte, err error) {
+	u, err := url.Parse(mediaUrl)
+	if err != nil || u.Path == gopay.NULL {
+		return nil, errors.New("invalid media url")
+	}
+	uri := u.RequestURI()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, _, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New(string(bs))
+	}
+	return bs, nil
+}
+
 // 查询投诉单列表API
 //	Code = 0 is success
 //	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter10_2_11.shtml
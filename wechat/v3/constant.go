@@ -15,8 +15,13 @@ const (
 	HeaderNonce     = "Wechatpay-Nonce"
 	HeaderSignature = "Wechatpay-Signature"
 	HeaderSerial    = "Wechatpay-Serial"
+	HeaderRequestId = "Request-ID"
 
-	Authorization = "WECHATPAY2-SHA256-RSA2048"
+	Authorization   = "WECHATPAY2-SHA256-RSA2048"
+	AuthorizationSM = "WECHATPAY2-SM2-WITH-SM3" // 国密
+
+	AlgorithmAESGCM = "AEAD_AES_256_GCM" // 回调及证书加密算法
+	AlgorithmSM4GCM = "AEAD_SM4_GCM"     // 回调及证书加密算法（国密）
 
 	v3BaseUrlCh = "https://api.mch.weixin.qq.com" // 中国国内
 
@@ -62,9 +67,13 @@ const (
 	v3SubFundFlowBill       = "/v3/bill/sub-merchant-fundflowbill" // 申请单个子商户资金账单 GET
 
 	// 提现
-	v3Withdraw       = "/v3/ecommerce/fund/withdraw"    // 特约商户余额提 POST
-	v3WithdrawStatus = "/v3/ecommerce/fund/withdraw/%s" // withdraw_id 查询特约商户提现状态 GET
-	v3               = "/v3/merchant/fund/withdraw/bill-type/{bill_type}"
+	v3Withdraw                   = "/v3/ecommerce/fund/withdraw"                   // 特约商户余额提现 POST
+	v3WithdrawStatus             = "/v3/ecommerce/fund/withdraw/%s"                // withdraw_id 查询特约商户提现状态 GET
+	v3WithdrawStatusByOutReqNo   = "/v3/ecommerce/fund/withdraw/out-request-no/%s" // out_request_no 查询特约商户提现状态 GET
+	v3MerchantWithdraw           = "/v3/merchant/fund/withdraw"                    // 电商平台提现 POST
+	v3MerchantWithdrawStatus     = "/v3/merchant/fund/withdraw/withdraw-id/%s"     // withdraw_id 电商平台查询提现状态 GET
+	v3MerchantWithdrawStatusByNo = "/v3/merchant/fund/withdraw/out-request-no/%s"  // out_request_no 电商平台查询提现状态 GET
+	v3WithdrawDownloadErrBill    = "/v3/merchant/fund/withdraw/bill-type/%s"       // bill_type 按日下载提现异常文件 GET
 
 	// 微信支付分（免确认模式）
 	v3ScoreDirectComplete = "/payscore/serviceorder/direct-complete" // 创单结单合并 POST
@@ -146,7 +155,7 @@ const (
 	v3GoldPlanBillManage   = "/v3/goldplan/merchants/changecustompagestatus"          // 商家小票管理 POST
 	v3GoldPlanFilterManage = "/v3/goldplan/merchants/set-advertising-industry-filter" // 同业过滤标签管理 POST
 	v3GoldPlanOpenAdShow   = "/v3/goldplan/merchants/open-advertising-show"           // 开通广告展示 PATCH
-	v3GoldPlanCloseAdShow  = "/v3/goldplan/merchants/close-advertising-show"          // 关闭广告展示 PATCH
+	v3GoldPlanCloseAdShow  = "/v3/goldplan/merchants/close-advertising-show"          // 关闭广告展示 POST
 
 	// 消费者投诉2.0
 	v3ComplaintList               = "/v3/merchant-service/complaints-v2"                         // 查询投诉单列表 GET
@@ -188,9 +197,10 @@ const (
 	v3TransferDetailReceiptQuery  = "/v3/transfer-detail/electronic-receipts"                       // 查询转账明细电子回单受理结果 GET
 
 	// 余额
-	v3MerchantBalance    = "/v3/merchant/fund/balance/%s"       // account_type 查询账户实时余额 GET
-	v3MerchantDayBalance = "/v3/merchant/fund/dayendbalance/%s" // account_type 查询账户日终余额 GET
-	v3EcommerceBalance   = "/v3/ecommerce/fund/balance/%s"      // sub_mchid 查询特约商户账户实时余额 GET
+	v3MerchantBalance     = "/v3/merchant/fund/balance/%s"        // account_type 查询账户实时余额 GET
+	v3MerchantDayBalance  = "/v3/merchant/fund/dayendbalance/%s"  // account_type 查询账户日终余额 GET
+	v3EcommerceBalance    = "/v3/ecommerce/fund/balance/%s"       // sub_mchid 查询特约商户账户实时余额 GET
+	v3EcommerceDayBalance = "/v3/ecommerce/fund/enddaybalance/%s" // sub_mchid 查询特约商户账户日终余额 GET
 
 	// 来账识别API
 	v3MerchantIncomeRecord  = "/v3/merchantfund/merchant/income-records" // 商户银行来账查询 GET
@@ -203,6 +213,72 @@ const (
 	v3Apply4SubModifySettlement    = "/v3/apply4sub/sub_merchants/%s/modify-settlement" // sub_mchid 修改结算账号 POST
 	v3Apply4SubQuerySettlement     = "/v3/apply4sub/sub_merchants/%s/settlement"        // sub_mchid 查询结算账户 GET
 
+	// 微工卡（服务商）
+	v3PayrollCardToken            = "/v3/payroll-card/tokens"                              // 生成授权token POST
+	v3PayrollCardRelation         = "/v3/payroll-card/relations/%s"                        // openid 查询微工卡授权关系 GET
+	v3PayrollCardPreOrder         = "/v3/payroll-card/authentications/pre-order"           // 微工卡核身预下单 POST
+	v3PayrollCardAuthResult       = "/v3/payroll-card/authentications/%s"                  // authenticate_number 获取核身结果 GET
+	v3PayrollCardAuthList         = "/v3/payroll-card/authentications"                     // 查询核身记录 GET
+	v3PayrollCardPreOrderWithAuth = "/v3/payroll-card/authentications/pre-order-with-auth" // 微工卡核身预下单（流程中完成授权） POST
+	v3PayrollCardTransferBatch    = "/v3/payroll-card/transfer-batches"                    // 发起批量转账 POST
+
+	// 电商收付通-二级商户进件
+	v3EcommerceApply                = "/v3/ecommerce/applyments/"                  // 二级商户进件 POST
+	v3EcommerceApplyQueryById       = "/v3/ecommerce/applyments/%s"                // applyment_id 通过申请单ID查询申请状态 GET
+	v3EcommerceApplyQueryByOutReqNo = "/v3/ecommerce/applyments/out-request-no/%s" // out_request_no 通过业务申请编号查询申请状态 GET
+
+	// 电商收付通-分账
+	v3EcommerceProfitShareOrder          = "/v3/ecommerce/profitsharing/orders"           // 请求分账 POST
+	v3EcommerceProfitShareQuery          = "/v3/ecommerce/profitsharing/orders"           // 查询分账结果 GET
+	v3EcommerceProfitShareReturn         = "/v3/ecommerce/profitsharing/returnorders"     // 请求分账回退 POST
+	v3EcommerceProfitShareReturnResult   = "/v3/ecommerce/profitsharing/returnorders"     // 查询分账回退结果 GET
+	v3EcommerceProfitShareFinish         = "/v3/ecommerce/profitsharing/finish-order"     // 完结分账 POST
+	v3EcommerceProfitShareAddReceiver    = "/v3/ecommerce/profitsharing/receivers/add"    // 添加分账接收方 POST
+	v3EcommerceProfitShareDeleteReceiver = "/v3/ecommerce/profitsharing/receivers/delete" // 删除分账接收方 POST
+
+	// 微信支付分停车服务
+	v3VehicleParkingServiceFind = "/v3/vehicle/parking/services/find"        // 查询车牌服务开通信息 GET
+	v3VehicleParkingCreate      = "/v3/vehicle/parking/parkings"             // 创建停车入场 POST
+	v3VehicleTransactionParking = "/v3/vehicle/transactions/parking"         // 扣费受理 POST
+	v3VehicleTransactionQuery   = "/v3/vehicle/transactions/out-trade-no/%s" // out_trade_no 查询订单 GET
+
+	// 电子发票（公共API）
+	v3FapiaoCardTemplate = "/v3/new-tax-control-fapiao/card-template"                       // 创建电子发票卡券模板 POST
+	v3FapiaoTitleUrl     = "/v3/new-tax-control-fapiao/user-title/title-url"                // 获取抬头填写链接 GET
+	v3FapiaoUserTitle    = "/v3/new-tax-control-fapiao/user-title"                          // 获取用户填写的抬头 GET
+	v3FapiaoBaseInfo     = "/v3/new-tax-control-fapiao/merchant/base-information"           // 查询商户配置的开票信息 GET
+	v3FapiaoTaxCodes     = "/v3/new-tax-control-fapiao/merchant/tax-codes"                  // 获取商品和服务税收分类对照表 GET
+	v3FapiaoIssue        = "/v3/new-tax-control-fapiao/fapiao-applications"                 // 开具电子发票 POST
+	v3FapiaoQuery        = "/v3/new-tax-control-fapiao/fapiao-applications/%s"              // fapiao_apply_id 查询电子发票 GET
+	v3FapiaoReverse      = "/v3/new-tax-control-fapiao/fapiao-applications/%s/reverse"      // fapiao_apply_id 冲红电子发票 POST
+	v3FapiaoFileDownInfo = "/v3/new-tax-control-fapiao/fapiao-applications/%s/fapiao-files" // fapiao_apply_id 获取发票下载信息 GET
+
+	// 电子小票
+	v3ShoppingReceiptUpload = "/v3/marketing/shopping-receipt/shoppingreceipts" // 上传电子小票 POST
+
+	// 银行组件
+	v3BankSearchBank    = "/v3/capital/capitallhh/banks/search-banks-by-bank-account" // 获取对私银行卡号开户银行 GET
+	v3BankPersonalList  = "/v3/capital/capitallhh/banks/personal-banking"             // 查询支持个人业务的银行列表 GET
+	v3BankCorporateList = "/v3/capital/capitallhh/banks/corporate-banking"            // 查询支持对公业务的银行列表 GET
+	v3BankProvinces     = "/v3/capital/capitallhh/areas/provinces"                    // 查询省份列表 GET
+	v3BankCities        = "/v3/capital/capitallhh/areas/provinces/%d/cities"          // province_code 查询城市列表 GET
+	v3BankBranches      = "/v3/capital/capitallhh/banks/%s/branches"                  // bank_alias_code 查询支行列表 GET
+
+	// 品牌红包
+	v3BrandRedpacketSend      = "/v3/brand-redpacket/brand-merchant-batches"                       // 发放品牌红包 POST
+	v3BrandRedpacketBatch     = "/v3/brand-redpacket/brand-merchant-batches/%s"                    // batch_no 查询批次单 GET
+	v3BrandRedpacketOutBatch  = "/v3/brand-redpacket/brand-merchant-out-batches/%s"                // out_batch_no 通过商家批次单号查询批次单 GET
+	v3BrandRedpacketDetail    = "/v3/brand-redpacket/brand-merchant-batches/%s/details/%s"         // batch_no、detail_no 查询明细单 GET
+	v3BrandRedpacketOutDetail = "/v3/brand-redpacket/brand-merchant-out-batches/%s/out-details/%s" // out_batch_no、out_detail_no 通过商家明细单号查询明细单 GET
+
+	// 委托代扣
+	v3PapayPresign           = "/v3/papay/sign/contracts/pre-entrust-sign/%s"                       // app、jsapi、h5、mini-program 预签约 POST
+	v3PapayContractQuery     = "/v3/papay/sign/contracts/plan-id/%d/out-contract-code/%s"           // plan_id、out_contract_code 查询签约关系 GET
+	v3PapayContractQueryById = "/v3/papay/sign/contracts/contract-id/%s"                            // contract_id 通过签约协议号查询签约关系 GET
+	v3PapayContractTerminate = "/v3/papay/sign/contracts/plan-id/%d/out-contract-code/%s/terminate" // plan_id、out_contract_code 解约 POST
+	v3PapayPayApply          = "/v3/papay/pay/transactions/apply"                                   // 申请扣款 POST
+	v3PapayPayQuery          = "/v3/papay/pay/transactions/out-trade-no/%s"                         // out_trade_no 查询扣款订单 GET
+
 	// 特约商户进件申请单状态
 	ApplyStateEditing       = "APPLYMENT_STATE_EDITTING"        // 编辑中
 	ApplyStateAuditing      = "APPLYMENT_STATE_AUDITING"        // 审核中
@@ -234,4 +310,31 @@ const (
 	TradeStateRevoked  = "REVOKED"    // 已撤销（付款码支付）
 	TradeStatePaying   = "USERPAYING" // 用户支付中（付款码支付）
 	TradeStatePayError = "PAYERROR"   // 支付失败(其他原因，如银行返回失败)
+
+	// v3 退款状态
+	RefundStatusSuccess    = "SUCCESS"    // 退款成功
+	RefundStatusClosed     = "CLOSED"     // 退款关闭
+	RefundStatusProcessing = "PROCESSING" // 退款处理中
+	RefundStatusAbnormal   = "ABNORMAL"   // 退款异常
+
+	// v3 异步通知事件类型
+	EventTypeTransactionSuccess = "TRANSACTION.SUCCESS" // 支付成功
+	EventTypeRefundSuccess      = "REFUND.SUCCESS"      // 退款成功
+	EventTypeRefundAbnormal     = "REFUND.ABNORMAL"     // 退款异常
+	EventTypeRefundClosed       = "REFUND.CLOSED"       // 退款关闭
+
+	// 先享卡 异步通知事件类型，解密结果为 DiscountCardQuery
+	EventTypeDiscountCardUserAccepted   = "DISCOUNT_CARD.USER_ACCEPTED"   // 用户领取先享卡
+	EventTypeDiscountCardAgreementEnded = "DISCOUNT_CARD.AGREEMENT_ENDED" // 先享卡守约状态变化
+	EventTypeDiscountCardUserPaid       = "DISCOUNT_CARD.USER_PAID"       // 先享卡扣费状态变化
+
+	// 委托代扣 异步通知事件类型，签约、解约解密结果为 V3DecryptPapayContractResult，扣款结果为 TRANSACTION.SUCCESS
+	EventTypePapaySign      = "PAPAY.SIGN"      // 签约成功
+	EventTypePapayTerminate = "PAPAY.TERMINATE" // 解约成功
+
+	// 委托代扣 预签约方式
+	PapayPresignApp         = "app"          // APP
+	PapayPresignJsapi       = "jsapi"        // 公众号
+	PapayPresignH5          = "h5"           // H5
+	PapayPresignMiniProgram = "mini-program" // 小程序
 )
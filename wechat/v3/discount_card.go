@@ -9,6 +9,7 @@ import (
 )
 
 // 预受理领卡请求API
+//	领卡、守约状态变化、扣费状态变化通知解密：client.DecipherNotifyResource(notifyReq, new(wechat.DiscountCardQuery))
 //	Code = 0 is success
 //	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter6_3_1.shtml
 func (c *ClientV3) V3DiscountCardApply(bm gopay.BodyMap) (wxRsp *DiscountCardApplyRsp, err error) {
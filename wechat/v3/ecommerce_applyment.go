@@ -0,0 +1,77 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 二级商户进件API
+//	注意：本接口会提交身份证、联系人、结算账户等敏感信息，需调用 client.V3EncryptText() 进行加密
+//	注意：图片类字段需先调用 client.V3MediaUploadImage() 上传，传入返回的 media_id
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/applyments/chapter3_1.shtml
+func (c *ClientV3) V3EcommerceApply(bm gopay.BodyMap) (*EcommerceApplyRsp, error) {
+	if err := bm.CheckEmptyError("out_request_no", "organization_type", "id_doc_type", "need_account_info", "contact_info", "sales_scene_info"); err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3EcommerceApply, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3EcommerceApply, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp := &EcommerceApplyRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceApply)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过申请单ID查询二级商户进件申请状态API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/applyments/chapter3_2.shtml
+func (c *ClientV3) V3EcommerceApplyQueryById(applyId string) (*EcommerceApplyQueryRsp, error) {
+	uri := fmt.Sprintf(v3EcommerceApplyQueryById, applyId)
+	return c.ecommerceApplyQuery(uri)
+}
+
+// 通过业务申请编号查询二级商户进件申请状态API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/applyments/chapter3_2.shtml
+func (c *ClientV3) V3EcommerceApplyQueryByOutReqNo(outRequestNo string) (*EcommerceApplyQueryRsp, error) {
+	uri := fmt.Sprintf(v3EcommerceApplyQueryByOutReqNo, outRequestNo)
+	return c.ecommerceApplyQuery(uri)
+}
+
+func (c *ClientV3) ecommerceApplyQuery(uri string) (*EcommerceApplyQueryRsp, error) {
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp := &EcommerceApplyQueryRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceApplyQuery)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
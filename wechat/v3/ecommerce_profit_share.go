@@ -0,0 +1,196 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 请求分账API
+//	注意：分账接收方 receiver_name 需调用 client.V3EncryptText() 进行加密
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/profitsharing/chapter3_1.shtml
+func (c *ClientV3) V3EcommerceProfitShareOrder(bm gopay.BodyMap) (wxRsp *EcommerceProfitShareOrderRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3EcommerceProfitShareOrder, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3EcommerceProfitShareOrder, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EcommerceProfitShareOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceProfitShareOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询分账结果API
+//	bm：sub_mchid、transaction_id、out_order_no
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/profitsharing/chapter3_2.shtml
+func (c *ClientV3) V3EcommerceProfitShareQuery(bm gopay.BodyMap) (wxRsp *EcommerceProfitShareOrderRsp, err error) {
+	if err = bm.CheckEmptyError("sub_mchid", "transaction_id", "out_order_no"); err != nil {
+		return nil, err
+	}
+	uri := v3EcommerceProfitShareQuery + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EcommerceProfitShareOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceProfitShareOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 请求分账回退API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/profitsharing/chapter3_3.shtml
+func (c *ClientV3) V3EcommerceProfitShareReturn(bm gopay.BodyMap) (wxRsp *EcommerceProfitShareReturnRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3EcommerceProfitShareReturn, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3EcommerceProfitShareReturn, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EcommerceProfitShareReturnRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceProfitShareReturn)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询分账回退结果API
+//	bm：sub_mchid、out_return_no，以及 order_id 或 out_order_no
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/profitsharing/chapter3_4.shtml
+func (c *ClientV3) V3EcommerceProfitShareReturnResult(bm gopay.BodyMap) (wxRsp *EcommerceProfitShareReturnRsp, err error) {
+	if err = bm.CheckEmptyError("sub_mchid", "out_return_no"); err != nil {
+		return nil, err
+	}
+	uri := v3EcommerceProfitShareReturnResult + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EcommerceProfitShareReturnRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceProfitShareReturn)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 完结分账API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/profitsharing/chapter3_5.shtml
+func (c *ClientV3) V3EcommerceProfitShareFinish(bm gopay.BodyMap) (wxRsp *EcommerceProfitShareFinishRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3EcommerceProfitShareFinish, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3EcommerceProfitShareFinish, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EcommerceProfitShareFinishRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceProfitShareFinish)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 添加分账接收方API
+//	注意：name 需调用 client.V3EncryptText() 进行加密
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/profitsharing/chapter3_7.shtml
+func (c *ClientV3) V3EcommerceProfitShareAddReceiver(bm gopay.BodyMap) (wxRsp *EcommerceProfitShareReceiverRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3EcommerceProfitShareAddReceiver, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3EcommerceProfitShareAddReceiver, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EcommerceProfitShareReceiverRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceProfitShareReceiver)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 删除分账接收方API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/profitsharing/chapter3_8.shtml
+func (c *ClientV3) V3EcommerceProfitShareDeleteReceiver(bm gopay.BodyMap) (wxRsp *EcommerceProfitShareReceiverRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3EcommerceProfitShareDeleteReceiver, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3EcommerceProfitShareDeleteReceiver, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EcommerceProfitShareReceiverRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceProfitShareReceiver)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
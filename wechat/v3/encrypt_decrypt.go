@@ -16,19 +16,42 @@ import (
 )
 
 // 敏感信息加密
+//	注意：国密模式 client 返回 ErrSMModeUnsupported
+//	注意：开启 client.AutoRefreshPlatformCerts() 后，加密与发送请求之间平台证书可能已更新，请使用 client.V3EncryptTextWithSerial()
 func (c *ClientV3) V3EncryptText(text string) (cipherText string, err error) {
-	if c.wxPublicKey == nil || c.wxSerialNo == "" {
-		return util.NULL, errors.New("WxPublicKey or WxSerialNo is null")
-	}
-	cipherByte, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, c.wxPublicKey, []byte(text), nil)
+	cipherText, _, err = c.V3EncryptTextWithSerial(text)
+	return cipherText, err
+}
+
+// 敏感信息加密，并返回加密使用的平台证书序列号
+//	请求时使用 client.WithPlatformSerial(serialNo) 返回的 client，保证请求头 Wechatpay-Serial 与加密使用的证书一致
+//	注意：国密模式 client 返回 ErrSMModeUnsupported
+func (c *ClientV3) V3EncryptTextWithSerial(text string) (cipherText, serialNo string, err error) {
+	if c.smPrivateKey != nil {
+		return util.NULL, util.NULL, ErrSMModeUnsupported
+	}
+	c.mu.RLock()
+	wxPublicKey, wxSerialNo := c.wxPublicKey, c.wxSerialNo
+	c.mu.RUnlock()
+	if wxPublicKey == nil || wxSerialNo == "" {
+		return util.NULL, util.NULL, errors.New("WxPublicKey or WxSerialNo is null")
+	}
+	cipherByte, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, wxPublicKey, []byte(text), nil)
 	if err != nil {
-		return "", fmt.Errorf("rsa.EncryptOAEP：%w", err)
+		return "", "", fmt.Errorf("rsa.EncryptOAEP：%w", err)
 	}
-	return base64.StdEncoding.EncodeToString(cipherByte), nil
+	return base64.StdEncoding.EncodeToString(cipherByte), wxSerialNo, nil
 }
 
 // 敏感信息解密
+//	注意：国密模式 client 返回 ErrSMModeUnsupported
 func (c *ClientV3) V3DecryptText(cipherText string) (text string, err error) {
+	if c.smPrivateKey != nil {
+		return util.NULL, ErrSMModeUnsupported
+	}
+	if c.privateKey == nil {
+		return util.NULL, errors.New("privateKey can't be nil")
+	}
 	cipherByte, _ := base64.StdEncoding.DecodeString(cipherText)
 	textByte, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, c.privateKey, cipherByte, nil)
 	if err != nil {
@@ -0,0 +1,36 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// V3Error 微信V3 接口非2xx应答的错误信息
+//	通过 client.ReturnV3Error() 开启后由接口直接返回，也可通过 NewV3Error() 由 Rsp 的 Code、Error、SignInfo 生成
+//	文档说明：https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay2_0.shtml
+type V3Error struct {
+	StatusCode int             `json:"-"`                // HTTP状态码
+	RequestId  string          `json:"-"`                // 应答 Request-ID，向微信支付反馈问题时提供
+	Code       string          `json:"code"`             // 详细错误码，如 PARAM_ERROR、ORDERNOTEXIST
+	Message    string          `json:"message"`          // 错误描述
+	Detail     json.RawMessage `json:"detail,omitempty"` // 错误详情，如参数错误时的字段、位置
+	Body       string          `json:"-"`                // 原始应答Body
+}
+
+func (e *V3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("wechat v3 error: status=%d, request_id=%s, body=%s", e.StatusCode, e.RequestId, e.Body)
+	}
+	return fmt.Sprintf("wechat v3 error: status=%d, request_id=%s, code=%s, message=%s", e.StatusCode, e.RequestId, e.Code, e.Message)
+}
+
+// NewV3Error 解析非2xx应答的错误信息，Body 不是 JSON 时 Code、Message 为空
+//	示例：if wxRsp.Code != wechat.Success { err = wechat.NewV3Error(wxRsp.Code, wxRsp.Error, wxRsp.SignInfo) }
+func NewV3Error(statusCode int, body string, si *SignInfo) (e *V3Error) {
+	e = &V3Error{StatusCode: statusCode, Body: body}
+	_ = json.Unmarshal([]byte(body), e)
+	if si != nil {
+		e.RequestId = si.HeaderRequestId
+	}
+	return e
+}
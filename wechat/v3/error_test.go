@@ -0,0 +1,43 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestV3Error(t *testing.T) {
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `{"code":"PARAM_ERROR","message":"参数错误","detail":{"field":"/amount/total","location":"body"}}`
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) (*http.Response, []byte, error) {
+			header := make(http.Header)
+			header.Set(HeaderRequestId, "08F78BB5AF0610D302189F0C20A0E8F6012800-0")
+			return &http.Response{StatusCode: http.StatusBadRequest, Header: header, Body: ioutil.NopCloser(strings.NewReader(body))}, []byte(body), nil
+		}
+	})
+
+	// 默认返回 Code、Error
+	wxRsp, err := c.V3TransactionNative(gopay.BodyMap{"out_trade_no": "GOPAY_TEST"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v3Err := NewV3Error(wxRsp.Code, wxRsp.Error, wxRsp.SignInfo)
+	if v3Err.StatusCode != http.StatusBadRequest || v3Err.Code != "PARAM_ERROR" || v3Err.RequestId == "" || len(v3Err.Detail) == 0 {
+		t.Fatalf("NewV3Error() = %+v", v3Err)
+	}
+
+	c.ReturnV3Error()
+	_, err = c.V3TransactionNative(gopay.BodyMap{"out_trade_no": "GOPAY_TEST"})
+	if !errors.As(err, &v3Err) || v3Err.Code != "PARAM_ERROR" || v3Err.Message != "参数错误" || v3Err.RequestId != "08F78BB5AF0610D302189F0C20A0E8F6012800-0" {
+		t.Fatalf("V3TransactionNative() error = %v, want *V3Error", err)
+	}
+}
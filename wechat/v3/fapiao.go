@@ -0,0 +1,275 @@
+package wechat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 创建电子发票卡券模板API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_1.shtml
+func (c *ClientV3) V3FapiaoCardTemplate(bm gopay.BodyMap) (wxRsp *FapiaoCardTemplateRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3FapiaoCardTemplate, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3FapiaoCardTemplate, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoCardTemplateRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoCardTemplate)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 获取抬头填写链接API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_4.shtml
+func (c *ClientV3) V3FapiaoTitleUrl(bm gopay.BodyMap) (wxRsp *FapiaoTitleUrlRsp, err error) {
+	if err = bm.CheckEmptyError("fapiao_apply_id", "appid", "openid", "total_amount", "source"); err != nil {
+		return nil, err
+	}
+	uri := v3FapiaoTitleUrl + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoTitleUrlRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoTitleUrl)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 获取用户填写的抬头API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_5.shtml
+func (c *ClientV3) V3FapiaoUserTitle(bm gopay.BodyMap) (wxRsp *FapiaoUserTitleRsp, err error) {
+	if err = bm.CheckEmptyError("fapiao_apply_id", "scene"); err != nil {
+		return nil, err
+	}
+	uri := v3FapiaoUserTitle + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoUserTitleRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoUserTitle)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询商户配置的开票信息API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_6.shtml
+func (c *ClientV3) V3FapiaoBaseInfo() (wxRsp *FapiaoBaseInfoRsp, err error) {
+	authorization, err := c.authorization(MethodGet, v3FapiaoBaseInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(v3FapiaoBaseInfo, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoBaseInfoRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoBaseInfo)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 获取商品和服务税收分类对照表API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_7.shtml
+func (c *ClientV3) V3FapiaoTaxCodes(offset, limit int) (wxRsp *FapiaoTaxCodesRsp, err error) {
+	if limit == 0 {
+		limit = 20
+	}
+	uri := v3FapiaoTaxCodes + "?offset=" + util.Int2String(offset) + "&limit=" + util.Int2String(limit)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoTaxCodesRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoTaxCodes)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 开具电子发票API
+//	注意：开票为异步处理，受理成功后请通过 client.V3FapiaoQuery() 或开票结果通知获取开票结果
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_8.shtml
+func (c *ClientV3) V3FapiaoIssue(bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3FapiaoIssue, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3FapiaoIssue, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusAccepted {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询电子发票API
+//	fapiaoId：商户发票单号，为空时返回该开票申请单下的全部发票
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_9.shtml
+func (c *ClientV3) V3FapiaoQuery(fapiaoApplyId, fapiaoId string) (wxRsp *FapiaoQueryRsp, err error) {
+	uri := fmt.Sprintf(v3FapiaoQuery, fapiaoApplyId)
+	if fapiaoId != gopay.NULL {
+		uri += "?fapiao_id=" + fapiaoId
+	}
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoQueryRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoQuery)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 冲红电子发票API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_10.shtml
+func (c *ClientV3) V3FapiaoReverse(fapiaoApplyId string, bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	uri := fmt.Sprintf(v3FapiaoReverse, fapiaoApplyId)
+	authorization, err := c.authorization(MethodPost, uri, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusAccepted {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 获取发票下载信息API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_12.shtml
+func (c *ClientV3) V3FapiaoFileDownInfo(fapiaoApplyId, fapiaoId string) (wxRsp *FapiaoFileDownInfoRsp, err error) {
+	uri := fmt.Sprintf(v3FapiaoFileDownInfo, fapiaoApplyId)
+	if fapiaoId != gopay.NULL {
+		uri += "?fapiao_id=" + fapiaoId
+	}
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoFileDownInfoRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoFileDownInfo)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 下载发票文件API
+//	downloadUrl：获取发票下载信息API返回的 download_url
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_8_13.shtml
+func (c *ClientV3) V3FapiaoFileDownload(downloadUrl string) (fileBytes []byte, err error) {
+	u, err := url.Parse(downloadUrl)
+	if err != nil || u.Path == gopay.NULL {
+		return nil, errors.New("invalid download url")
+	}
+	uri := u.RequestURI()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, _, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New(string(bs))
+	}
+	return bs, nil
+}
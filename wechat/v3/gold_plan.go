@@ -0,0 +1,127 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 点金计划管理API
+//	bm：sub_mchid、operation_type（OPEN：打开点金计划，CLOSE：关闭点金计划）
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter8_5_1.shtml
+func (c *ClientV3) V3GoldPlanManage(bm gopay.BodyMap) (wxRsp *GoldPlanManageRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3GoldPlanManage, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3GoldPlanManage, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &GoldPlanManageRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(GoldPlanManage)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 商家小票管理API
+//	bm：sub_mchid、operation_type（OPEN：打开商家小票，CLOSE：关闭商家小票）
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter8_5_2.shtml
+func (c *ClientV3) V3GoldPlanBillManage(bm gopay.BodyMap) (wxRsp *GoldPlanManageRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3GoldPlanBillManage, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3GoldPlanBillManage, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &GoldPlanManageRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(GoldPlanManage)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 同业过滤标签管理API
+//	bm：sub_mchid、advertising_industry_filters
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter8_5_3.shtml
+func (c *ClientV3) V3GoldPlanFilterManage(bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3GoldPlanFilterManage, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3GoldPlanFilterManage, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 开通广告展示API
+//	bm：sub_mchid、advertising_industry_filters
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter8_5_4.shtml
+func (c *ClientV3) V3GoldPlanOpenAdShow(bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	authorization, err := c.authorization(MethodPATCH, v3GoldPlanOpenAdShow, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPatch(bm, v3GoldPlanOpenAdShow, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 关闭广告展示API
+//	bm：sub_mchid
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter8_5_5.shtml
+func (c *ClientV3) V3GoldPlanCloseAdShow(bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3GoldPlanCloseAdShow, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3GoldPlanCloseAdShow, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
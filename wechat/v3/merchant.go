@@ -35,6 +35,33 @@ func (c *ClientV3) V3EcommerceBalance(subMchid string) (*EcommerceBalanceRsp, er
 	return wxRsp, c.verifySyncSign(si)
 }
 
+// 查询特约商户账户日终余额API
+//	date示例值：2019-08-17
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/amount/chapter3_2.shtml
+func (c *ClientV3) V3EcommerceDayBalance(subMchid, date string) (*EcommerceBalanceRsp, error) {
+	uri := fmt.Sprintf(v3EcommerceDayBalance, subMchid) + "?date=" + date
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp := &EcommerceBalanceRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(EcommerceBalance)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
 // 查询账户实时余额API
 //	Code = 0 is success
 // 	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/pay/transfer/chapter5_1.shtml
@@ -0,0 +1,142 @@
+package wechat
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/xhttp"
+)
+
+// Request 微信V3出站请求（已签名）
+type Request struct {
+	Method    string        // MethodGet、MethodPost、MethodPut、MethodDelete、MethodPATCH
+	URL       string        // 完整请求地址，GET 请求包含 query 参数
+	Header    http.Header   // 请求Header，包含 Authorization、Wechatpay-Serial
+	Body      gopay.BodyMap // 请求Body，GET 请求为 nil
+	Multipart bool          // 是否为 multipart/form-data 文件上传
+	Writer    io.Writer     // 不为空时（如下载账单），2xx 应答Body以流的方式写入 Writer，返回的 bs 为空
+}
+
+// Handler 发送请求并返回原始响应，err 仅表示网络等请求层面的错误
+type Handler func(ctx context.Context, req *Request) (res *http.Response, bs []byte, err error)
+
+// Middleware 请求中间件，可用于重试、统计耗时、记录请求、注入Header等
+//
+//	client.Use(func(next wechat.Handler) wechat.Handler {
+//		return func(ctx context.Context, req *wechat.Request) (*http.Response, []byte, error) {
+//			start := time.Now()
+//			res, bs, err := next(ctx, req)
+//			xlog.Infof("%s %s cost: %s", req.Method, req.URL, time.Since(start))
+//			return res, bs, err
+//		}
+//	})
+//
+//	注意：请求已签名，中间件修改 Method、URL、Body 会导致签名校验失败，可修改或新增非签名Header
+type Middleware func(next Handler) Handler
+
+// Use 添加请求中间件，多个中间件按添加顺序由外向内执行
+func (c *ClientV3) Use(middlewares ...Middleware) (client *ClientV3) {
+	c.mu.Lock()
+	c.middlewares = append(c.middlewares, middlewares...)
+	c.mu.Unlock()
+	return c
+}
+
+// handler 组装中间件链
+func (c *ClientV3) handler() (h Handler) {
+	c.mu.RLock()
+	mws := c.middlewares
+	c.mu.RUnlock()
+	h = c.send
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// send 实际发送HTTP请求
+func (c *ClientV3) send(ctx context.Context, req *Request) (res *http.Response, bs []byte, err error) {
+	if req.Writer != nil {
+		return c.sendStream(ctx, req)
+	}
+	httpClient := xhttp.NewClientFromHttpClient(ctx, nil)
+	for k, vs := range req.Header {
+		for _, v := range vs {
+			httpClient.Header.Add(k, v)
+		}
+	}
+	if req.Multipart {
+		httpClient.Type(xhttp.TypeMultipartFormData).Post(req.URL).SendMultipartBodyMap(req.Body)
+	} else {
+		httpClient.Type(xhttp.TypeJSON)
+		switch req.Method {
+		case MethodGet:
+			httpClient.Get(req.URL)
+		case MethodPut:
+			httpClient.Put(req.URL).SendBodyMap(req.Body)
+		case MethodDelete:
+			httpClient.Delete(req.URL).SendBodyMap(req.Body)
+		case MethodPATCH:
+			httpClient.Patch(req.URL).SendBodyMap(req.Body)
+		default:
+			httpClient.Post(req.URL).SendBodyMap(req.Body)
+		}
+	}
+	res, bs, errs := httpClient.EndBytes()
+	if len(errs) > 0 {
+		return nil, nil, errs[0]
+	}
+	return res, bs, nil
+}
+
+// sendStream 发送请求，2xx 应答Body以流的方式写入 req.Writer
+//
+//	文件可能较大，不限制整体下载时长，超时由 ctx 控制
+func (c *ClientV3) sendStream(ctx context.Context, req *Request) (res *http.Response, bs []byte, err error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header = req.Header.Clone()
+	httpClient := xhttp.NewClientFromHttpClient(ctx, nil).HttpClient
+	httpClient.Timeout = 0
+	if res, err = httpClient.Do(httpReq); err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		bs, err = ioutil.ReadAll(io.LimitReader(res.Body, int64(1<<20)))
+		return res, bs, err
+	}
+	if _, err = io.Copy(req.Writer, res.Body); err != nil {
+		return nil, nil, err
+	}
+	return res, nil, nil
+}
+
+// IdempotencyMiddleware 幂等中间件，创建类请求（POST）发送前，按 keys 顺序取请求参数中第一个非空的幂等键，调用 hook
+//	keys：幂等键字段名，为空时默认 out_trade_no、out_refund_no、out_batch_no、out_request_no、out_order_no
+//	示例：client.Use(wechat.IdempotencyMiddleware(hook))
+func IdempotencyMiddleware(hook gopay.IdempotencyHook, keys ...string) Middleware {
+	if len(keys) == 0 {
+		keys = []string{"out_trade_no", "out_refund_no", "out_batch_no", "out_request_no", "out_order_no"}
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (res *http.Response, bs []byte, err error) {
+			if req.Method == MethodPost && req.Body != nil {
+				for _, k := range keys {
+					if v := req.Body.GetString(k); v != "" {
+						if err = hook(ctx, k, v, req.Body); err != nil {
+							return nil, nil, err
+						}
+						break
+					}
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestClientV3_Use(t *testing.T) {
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		order []string
+		req   *Request
+	)
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) (*http.Response, []byte, error) {
+			order = append(order, "outer")
+			r.Header.Set("X-Request-Source", "gopay")
+			return next(ctx, r)
+		}
+	}, func(next Handler) Handler {
+		// 不调用 next，直接返回，模拟录制回放
+		return func(ctx context.Context, r *Request) (*http.Response, []byte, error) {
+			order = append(order, "inner")
+			req = r
+			bs := []byte(`{"code_url":"weixin://wxpay/bizpayurl?pr=p4lpSuKzz"}`)
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(string(bs)))}, bs, nil
+		}
+	})
+
+	wxRsp, err := c.V3TransactionNative(gopay.BodyMap{"out_trade_no": "GOPAY_TEST"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wxRsp.Code != Success || wxRsp.Response.CodeUrl == "" {
+		t.Fatalf("unexpected response: %+v", wxRsp)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Fatalf("middleware order = %v", order)
+	}
+	if req.Method != MethodPost || req.URL != v3BaseUrlCh+v3ApiNative || req.Body.GetString("out_trade_no") != "GOPAY_TEST" {
+		t.Fatalf("unexpected request: %s %s %s", req.Method, req.URL, req.Body.JsonBody())
+	}
+	if !strings.HasPrefix(req.Header.Get(HeaderAuthorization), Authorization) || req.Header.Get("X-Request-Source") != "gopay" {
+		t.Fatalf("unexpected request header: %#v", req.Header)
+	}
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := make(map[string]string)
+	c.Use(IdempotencyMiddleware(func(ctx context.Context, key, value string, bm gopay.BodyMap) error {
+		if _, ok := saved[value]; ok {
+			return errors.New("duplicate " + key + ": " + value)
+		}
+		saved[value] = bm.JsonBody()
+		return nil
+	}), func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) (*http.Response, []byte, error) {
+			bs := []byte(`{"code_url":"weixin://wxpay/bizpayurl?pr=p4lpSuKzz"}`)
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(string(bs)))}, bs, nil
+		}
+	})
+
+	outTradeNo := gopay.NewIdGenerator("GOPAY").Next()
+	if _, err = c.V3TransactionNative(gopay.BodyMap{"out_trade_no": outTradeNo}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := saved[outTradeNo]; !ok {
+		t.Fatalf("hook was not called with out_trade_no %s", outTradeNo)
+	}
+	if _, err = c.V3TransactionNative(gopay.BodyMap{"out_trade_no": outTradeNo}); err == nil {
+		t.Fatal("hook error should abort the request")
+	}
+}
@@ -748,6 +748,294 @@ type PartnershipsListRsp struct {
 	Error    string            `json:"-"`
 }
 
+// 生成授权token Rsp
+type PayrollCardTokenRsp struct {
+	Code     int               `json:"-"`
+	SignInfo *SignInfo         `json:"-"`
+	Response *PayrollCardToken `json:"response,omitempty"`
+	Error    string            `json:"-"`
+}
+
+// 查询微工卡授权关系 Rsp
+type PayrollCardRelationRsp struct {
+	Code     int                  `json:"-"`
+	SignInfo *SignInfo            `json:"-"`
+	Response *PayrollCardRelation `json:"response,omitempty"`
+	Error    string               `json:"-"`
+}
+
+// 微工卡核身预下单 Rsp
+type PayrollCardPreOrderRsp struct {
+	Code     int                  `json:"-"`
+	SignInfo *SignInfo            `json:"-"`
+	Response *PayrollCardPreOrder `json:"response,omitempty"`
+	Error    string               `json:"-"`
+}
+
+// 获取核身结果 Rsp
+type PayrollCardAuthResultRsp struct {
+	Code     int                    `json:"-"`
+	SignInfo *SignInfo              `json:"-"`
+	Response *PayrollCardAuthResult `json:"response,omitempty"`
+	Error    string                 `json:"-"`
+}
+
+// 查询核身记录 Rsp
+type PayrollCardAuthListRsp struct {
+	Code     int                  `json:"-"`
+	SignInfo *SignInfo            `json:"-"`
+	Response *PayrollCardAuthList `json:"response,omitempty"`
+	Error    string               `json:"-"`
+}
+
+// 微工卡发起批量转账 Rsp
+type PayrollCardTransferBatchRsp struct {
+	Code     int                       `json:"-"`
+	SignInfo *SignInfo                 `json:"-"`
+	Response *PayrollCardTransferBatch `json:"response,omitempty"`
+	Error    string                    `json:"-"`
+}
+
+// 二级商户进件 Rsp
+type EcommerceApplyRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *EcommerceApply `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 查询二级商户进件申请状态 Rsp
+type EcommerceApplyQueryRsp struct {
+	Code     int                  `json:"-"`
+	SignInfo *SignInfo            `json:"-"`
+	Response *EcommerceApplyQuery `json:"response,omitempty"`
+	Error    string               `json:"-"`
+}
+
+// 电商收付通 请求分账、查询分账结果 Rsp
+type EcommerceProfitShareOrderRsp struct {
+	Code     int                        `json:"-"`
+	SignInfo *SignInfo                  `json:"-"`
+	Response *EcommerceProfitShareOrder `json:"response,omitempty"`
+	Error    string                     `json:"-"`
+}
+
+// 电商收付通 请求分账回退、查询分账回退结果 Rsp
+type EcommerceProfitShareReturnRsp struct {
+	Code     int                         `json:"-"`
+	SignInfo *SignInfo                   `json:"-"`
+	Response *EcommerceProfitShareReturn `json:"response,omitempty"`
+	Error    string                      `json:"-"`
+}
+
+// 电商收付通 完结分账 Rsp
+type EcommerceProfitShareFinishRsp struct {
+	Code     int                         `json:"-"`
+	SignInfo *SignInfo                   `json:"-"`
+	Response *EcommerceProfitShareFinish `json:"response,omitempty"`
+	Error    string                      `json:"-"`
+}
+
+// 电商收付通 添加、删除分账接收方 Rsp
+type EcommerceProfitShareReceiverRsp struct {
+	Code     int                           `json:"-"`
+	SignInfo *SignInfo                     `json:"-"`
+	Response *EcommerceProfitShareReceiver `json:"response,omitempty"`
+	Error    string                        `json:"-"`
+}
+
+// 提现 Rsp
+type WithdrawRsp struct {
+	Code     int       `json:"-"`
+	SignInfo *SignInfo `json:"-"`
+	Response *Withdraw `json:"response,omitempty"`
+	Error    string    `json:"-"`
+}
+
+// 查询提现状态 Rsp
+type WithdrawStatusRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *WithdrawStatus `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 查询车牌服务开通信息 Rsp
+type VehicleParkingServiceRsp struct {
+	Code     int                    `json:"-"`
+	SignInfo *SignInfo              `json:"-"`
+	Response *VehicleParkingService `json:"response,omitempty"`
+	Error    string                 `json:"-"`
+}
+
+// 创建停车入场 Rsp
+type VehicleParkingRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *VehicleParking `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 停车扣费受理、查询订单 Rsp
+type VehicleTransactionRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *VehicleTransaction `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 点金计划管理、商家小票管理 Rsp
+type GoldPlanManageRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *GoldPlanManage `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 创建电子发票卡券模板 Rsp
+type FapiaoCardTemplateRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *FapiaoCardTemplate `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 获取抬头填写链接 Rsp
+type FapiaoTitleUrlRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *FapiaoTitleUrl `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 获取用户填写的抬头 Rsp
+type FapiaoUserTitleRsp struct {
+	Code     int              `json:"-"`
+	SignInfo *SignInfo        `json:"-"`
+	Response *FapiaoUserTitle `json:"response,omitempty"`
+	Error    string           `json:"-"`
+}
+
+// 查询商户配置的开票信息 Rsp
+type FapiaoBaseInfoRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *FapiaoBaseInfo `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 获取商品和服务税收分类对照表 Rsp
+type FapiaoTaxCodesRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *FapiaoTaxCodes `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 查询电子发票 Rsp
+type FapiaoQueryRsp struct {
+	Code     int          `json:"-"`
+	SignInfo *SignInfo    `json:"-"`
+	Response *FapiaoQuery `json:"response,omitempty"`
+	Error    string       `json:"-"`
+}
+
+// 获取发票下载信息 Rsp
+type FapiaoFileDownInfoRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *FapiaoFileDownInfo `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 上传电子小票 Rsp
+type ShoppingReceiptUploadRsp struct {
+	Code     int                    `json:"-"`
+	SignInfo *SignInfo              `json:"-"`
+	Response *ShoppingReceiptUpload `json:"response,omitempty"`
+	Error    string                 `json:"-"`
+}
+
+// 获取对私银行卡号开户银行 Rsp
+type BankSearchBankRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *BankSearchBank `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 查询支持个人/对公业务的银行列表 Rsp
+type BankListRsp struct {
+	Code     int       `json:"-"`
+	SignInfo *SignInfo `json:"-"`
+	Response *BankList `json:"response,omitempty"`
+	Error    string    `json:"-"`
+}
+
+// 查询省份列表 Rsp
+type BankProvinceListRsp struct {
+	Code     int               `json:"-"`
+	SignInfo *SignInfo         `json:"-"`
+	Response *BankProvinceList `json:"response,omitempty"`
+	Error    string            `json:"-"`
+}
+
+// 查询城市列表 Rsp
+type BankCityListRsp struct {
+	Code     int           `json:"-"`
+	SignInfo *SignInfo     `json:"-"`
+	Response *BankCityList `json:"response,omitempty"`
+	Error    string        `json:"-"`
+}
+
+// 查询支行列表 Rsp
+type BankBranchListRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *BankBranchList `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 发放品牌红包 Rsp
+type BrandRedpacketSendRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *BrandRedpacketSend `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 查询品牌红包批次单 Rsp
+type BrandRedpacketBatchRsp struct {
+	Code     int                  `json:"-"`
+	SignInfo *SignInfo            `json:"-"`
+	Response *BrandRedpacketBatch `json:"response,omitempty"`
+	Error    string               `json:"-"`
+}
+
+// 查询品牌红包明细单 Rsp
+type BrandRedpacketDetailRsp struct {
+	Code     int                   `json:"-"`
+	SignInfo *SignInfo             `json:"-"`
+	Response *BrandRedpacketDetail `json:"response,omitempty"`
+	Error    string                `json:"-"`
+}
+
+// 委托代扣预签约 Rsp
+type PapayPresignRsp struct {
+	Code     int           `json:"-"`
+	SignInfo *SignInfo     `json:"-"`
+	Response *PapayPresign `json:"response,omitempty"`
+	Error    string        `json:"-"`
+}
+
+// 委托代扣查询签约关系 Rsp
+type PapayContractRsp struct {
+	Code     int            `json:"-"`
+	SignInfo *SignInfo      `json:"-"`
+	Response *PapayContract `json:"response,omitempty"`
+	Error    string         `json:"-"`
+}
+
 // ==================================分割==================================
 
 type JSAPIPayParams struct {
@@ -785,6 +1073,7 @@ type SignInfo struct {
 	HeaderNonce     string `json:"Wechatpay-Nonce"`
 	HeaderSignature string `json:"Wechatpay-Signature"`
 	HeaderSerial    string `json:"Wechatpay-Serial"`
+	HeaderRequestId string `json:"Request-ID"`
 	SignBody        string `json:"sign_body"`
 }
 
@@ -968,13 +1257,19 @@ type RefundQueryResponse struct {
 }
 
 type RefundQueryAmount struct {
-	Total            int    `json:"total"`             // 订单总金额，单位为分
-	Refund           int    `json:"refund"`            // 退款金额，币种的最小单位，只能为整数，不能超过原订单支付金额。
-	PayerTotal       int    `json:"payer_total"`       // 用户支付金额，单位为分
-	PayerRefund      int    `json:"payer_refund"`      // 用户退款金额，不包含所有优惠券金额
-	SettlementRefund int    `json:"settlement_refund"` // 应结退款金额，去掉非充值代金券退款金额后的退款金额，单位为分
-	DiscountRefund   int    `json:"discount_refund"`   // 优惠退款金额
-	Currency         string `json:"currency"`          // CNY：人民币，境内商户号仅支持人民币
+	Total            int           `json:"total"`             // 订单总金额，单位为分
+	Refund           int           `json:"refund"`            // 退款金额，币种的最小单位，只能为整数，不能超过原订单支付金额。
+	PayerTotal       int           `json:"payer_total"`       // 用户支付金额，单位为分
+	PayerRefund      int           `json:"payer_refund"`      // 用户退款金额，不包含所有优惠券金额
+	SettlementRefund int           `json:"settlement_refund"` // 应结退款金额，去掉非充值代金券退款金额后的退款金额，单位为分
+	DiscountRefund   int           `json:"discount_refund"`   // 优惠退款金额
+	Currency         string        `json:"currency"`          // CNY：人民币，境内商户号仅支持人民币
+	From             []*RefundFrom `json:"from,omitempty"`    // 退款出资账户及金额
+}
+
+type RefundFrom struct {
+	Account string `json:"account"` // 出资账户类型，AVAILABLE：可用余额，UNAVAILABLE：不可用余额
+	Amount  int    `json:"amount"`  // 对应账户出资金额，单位为分
 }
 
 type RefundQueryPromotionDetail struct {
@@ -2122,3 +2417,453 @@ type Partnerships struct {
 	CreateTime     string          `json:"create_time"`     // 创建时间
 	UpdateTime     string          `json:"update_time"`     // 更新时间
 }
+
+type PayrollCardToken struct {
+	Openid    string `json:"openid"`     // 用户在商户appid下的唯一标识
+	Mchid     string `json:"mchid"`      // 服务商商户号
+	SubMchid  string `json:"sub_mchid"`  // 特约商户号
+	Appid     string `json:"appid"`      // 服务商appid
+	SubAppid  string `json:"sub_appid"`  // 特约商户appid
+	Token     string `json:"token"`      // 授权token
+	ExpiresIn int    `json:"expires_in"` // token有效时间，单位秒
+}
+
+type PayrollCardRelation struct {
+	Openid          string `json:"openid"`           // 用户在商户appid下的唯一标识
+	Mchid           string `json:"mchid"`            // 服务商商户号
+	SubMchid        string `json:"sub_mchid"`        // 特约商户号
+	Appid           string `json:"appid"`            // 服务商appid
+	SubAppid        string `json:"sub_appid"`        // 特约商户appid
+	AuthorizeState  string `json:"authorize_state"`  // 授权状态，UNAUTHORIZED：未授权，AUTHORIZED：已授权，DEAUTHORIZED：已取消授权
+	AuthorizeTime   string `json:"authorize_time"`   // 授权时间
+	DeauthorizeTime string `json:"deauthorize_time"` // 取消授权时间
+}
+
+type PayrollCardPreOrder struct {
+	AuthenticateNumber string `json:"authenticate_number"` // 商家核身单号
+	Openid             string `json:"openid"`              // 用户在商户appid下的唯一标识
+	Mchid              string `json:"mchid"`               // 服务商商户号
+	SubMchid           string `json:"sub_mchid"`           // 特约商户号
+	Token              string `json:"token"`               // 授权token，拉起核身时使用
+	ExpiresIn          int    `json:"expires_in"`          // token有效时间，单位秒
+}
+
+type PayrollCardAuthResult struct {
+	Mchid                    string `json:"mchid"`                      // 服务商商户号
+	SubMchid                 string `json:"sub_mchid"`                  // 特约商户号
+	Openid                   string `json:"openid"`                     // 用户在商户appid下的唯一标识
+	AuthenticateScene        string `json:"authenticate_scene"`         // 核身渠道，FROM_MINI_APP：来自小程序，FROM_HARDWARE：来自硬件设备
+	AuthenticateSource       string `json:"authenticate_source"`        // 核身渠道标识
+	ProjectName              string `json:"project_name"`               // 项目名称
+	EmployerName             string `json:"employer_name"`              // 用工单位名称
+	AuthenticateState        string `json:"authenticate_state"`         // 核身状态，AUTHENTICATE_PROCESSING：核身中，AUTHENTICATE_SUCCESS：核身成功，AUTHENTICATE_FAILED：核身失败
+	AuthenticateTime         string `json:"authenticate_time"`          // 核身时间
+	AuthenticateNumber       string `json:"authenticate_number"`        // 商家核身单号
+	AuthenticateFailedReason string `json:"authenticate_failed_reason"` // 核身失败原因
+}
+
+type PayrollCardAuthList struct {
+	Data       []*PayrollCardAuthResult `json:"data,omitempty"` // 核身记录列表
+	TotalCount int                      `json:"total_count"`    // 总记录条数
+	Offset     int                      `json:"offset"`         // 分页页码
+	Limit      int                      `json:"limit"`          // 分页大小
+}
+
+type PayrollCardTransferBatch struct {
+	OutBatchNo  string `json:"out_batch_no"` // 商家批次单号
+	BatchId     string `json:"batch_id"`     // 微信批次单号
+	CreateTime  string `json:"create_time"`  // 批次创建时间
+	BatchStatus string `json:"batch_status"` // 批次状态
+}
+
+type EcommerceApply struct {
+	ApplymentId  int64  `json:"applyment_id"`   // 微信支付申请单号
+	OutRequestNo string `json:"out_request_no"` // 业务申请编号
+}
+
+type EcommerceApplyQuery struct {
+	ApplymentState     string                      `json:"applyment_state"`      // 申请状态
+	ApplymentStateDesc string                      `json:"applyment_state_desc"` // 申请状态描述
+	SignState          string                      `json:"sign_state"`           // 电子签约状态，UNSIGNED：未签约，SIGNED：已签约，NOT_SIGNABLE：不可签约
+	SignUrl            string                      `json:"sign_url"`             // 签约链接
+	SubMchid           string                      `json:"sub_mchid"`            // 电商平台二级商户号
+	AccountValidation  *EcommerceAccountValidation `json:"account_validation"`   // 汇款账户验证信息
+	AuditDetail        []*EcommerceAuditDetail     `json:"audit_detail"`         // 驳回原因详情
+	LegalValidationUrl string                      `json:"legal_validation_url"` // 法人验证链接
+	OutRequestNo       string                      `json:"out_request_no"`       // 业务申请编号
+	ApplymentId        int64                       `json:"applyment_id"`         // 微信支付申请单号
+}
+
+type EcommerceAccountValidation struct {
+	AccountName              string `json:"account_name"`               // 付款户名，加密字段，需调用 client.V3DecryptText() 解密
+	AccountNo                string `json:"account_no"`                 // 付款卡号，加密字段，需调用 client.V3DecryptText() 解密
+	PayAmount                int    `json:"pay_amount"`                 // 汇款金额，单位为分
+	DestinationAccountNumber string `json:"destination_account_number"` // 收款卡号
+	DestinationAccountName   string `json:"destination_account_name"`   // 收款户名
+	DestinationAccountBank   string `json:"destination_account_bank"`   // 开户银行
+	City                     string `json:"city"`                       // 省市信息
+	Remark                   string `json:"remark"`                     // 备注信息
+	Deadline                 string `json:"deadline"`                   // 汇款截止时间
+}
+
+type EcommerceAuditDetail struct {
+	ParamName    string `json:"param_name"`    // 参数名称
+	RejectReason string `json:"reject_reason"` // 驳回原因
+}
+
+type EcommerceProfitShareOrder struct {
+	SubMchid      string                               `json:"sub_mchid"`      // 二级商户号
+	TransactionId string                               `json:"transaction_id"` // 微信订单号
+	OutOrderNo    string                               `json:"out_order_no"`   // 商户分账单号
+	OrderId       string                               `json:"order_id"`       // 微信分账单号
+	Status        string                               `json:"status"`         // 分账单状态，PROCESSING：处理中，FINISHED：处理完成
+	Receivers     []*EcommerceProfitShareOrderReceiver `json:"receivers"`      // 分账接收方列表
+}
+
+type EcommerceProfitShareOrderReceiver struct {
+	ReceiverMchid   string `json:"receiver_mchid"`   // 分账接收商户号
+	ReceiverAccount string `json:"receiver_account"` // 分账接收方账号
+	Type            string `json:"type"`             // 分账接收方类型，MERCHANT_ID：商户，PERSONAL_OPENID：个人
+	Amount          int    `json:"amount"`           // 分账金额，单位为分
+	Description     string `json:"description"`      // 分账描述
+	Result          string `json:"result"`           // 分账结果，PENDING：待分账，SUCCESS：分账成功，CLOSED：分账失败已关闭
+	FinishTime      string `json:"finish_time"`      // 分账完成时间
+	FailReason      string `json:"fail_reason"`      // 分账失败原因
+	DetailId        string `json:"detail_id"`        // 分账明细单号
+}
+
+type EcommerceProfitShareReturn struct {
+	SubMchid    string `json:"sub_mchid"`     // 二级商户号
+	OrderId     string `json:"order_id"`      // 微信分账单号
+	OutOrderNo  string `json:"out_order_no"`  // 商户分账单号
+	OutReturnNo string `json:"out_return_no"` // 商户回退单号
+	ReturnMchid string `json:"return_mchid"`  // 回退商户号
+	Amount      int    `json:"amount"`        // 回退金额，单位为分
+	ReturnNo    string `json:"return_no"`     // 微信回退单号
+	Result      string `json:"result"`        // 回退结果，PROCESSING：处理中，SUCCESS：已成功，FAILED：已失败
+	FailReason  string `json:"fail_reason"`   // 失败原因
+	FinishTime  string `json:"finish_time"`   // 完成时间
+}
+
+type EcommerceProfitShareFinish struct {
+	SubMchid      string `json:"sub_mchid"`      // 二级商户号
+	TransactionId string `json:"transaction_id"` // 微信订单号
+	OutOrderNo    string `json:"out_order_no"`   // 商户分账单号
+	OrderId       string `json:"order_id"`       // 微信分账单号
+}
+
+type EcommerceProfitShareReceiver struct {
+	Type    string `json:"type"`    // 接收方类型
+	Account string `json:"account"` // 接收方账号
+}
+
+type Withdraw struct {
+	SubMchid     string `json:"sub_mchid,omitempty"` // 二级商户号，电商平台提现时为空
+	WithdrawId   string `json:"withdraw_id"`         // 微信支付提现单号
+	OutRequestNo string `json:"out_request_no"`      // 商户提现单号
+}
+
+type WithdrawStatus struct {
+	SubMchid      string `json:"sub_mchid,omitempty"`      // 二级商户号，电商平台提现时为空
+	SpMchid       string `json:"sp_mchid,omitempty"`       // 电商平台商户号
+	Status        string `json:"status"`                   // 提现单状态，CREATE_SUCCESS：受理成功，SUCCESS：提现成功，FAIL：提现失败，REFUND：提现退票，CLOSE：关单，INIT：业务单已创建
+	WithdrawId    string `json:"withdraw_id"`              // 微信支付提现单号
+	OutRequestNo  string `json:"out_request_no"`           // 商户提现单号
+	Amount        int    `json:"amount"`                   // 提现金额，单位为分
+	CreateTime    string `json:"create_time"`              // 发起提现时间
+	UpdateTime    string `json:"update_time"`              // 提现状态更新时间
+	Reason        string `json:"reason"`                   // 失败原因
+	Remark        string `json:"remark"`                   // 提现备注
+	BankMemo      string `json:"bank_memo"`                // 银行附言
+	AccountType   string `json:"account_type"`             // 出款账户类型，BASIC：基本账户，OPERATION：运营账户，FEES：手续费账户
+	AccountNumber string `json:"account_number,omitempty"` // 入账银行账号后四位
+	AccountBank   string `json:"account_bank,omitempty"`   // 入账银行
+	BankName      string `json:"bank_name,omitempty"`      // 入账银行全称（含支行）
+	Solution      string `json:"solution,omitempty"`       // 提现失败解决方案
+}
+
+type VehicleParkingService struct {
+	PlateNumber     string `json:"plate_number"`      // 车牌号
+	PlateColor      string `json:"plate_color"`       // 车牌颜色
+	ServiceOpenTime string `json:"service_open_time"` // 车牌服务开通时间
+	Openid          string `json:"openid"`            // 用户在商户appid下的唯一标识
+	ServiceState    string `json:"service_state"`     // 车牌服务开通状态，NORMAL：正常服务，PAUSE：暂停服务，OUT_SERVICE：未开通
+}
+
+type VehicleParking struct {
+	Id           string `json:"id"`             // 停车入场id
+	OutParkingNo string `json:"out_parking_no"` // 商户入场id
+	PlateNumber  string `json:"plate_number"`   // 车牌号
+	PlateColor   string `json:"plate_color"`    // 车牌颜色
+	StartTime    string `json:"start_time"`     // 入场时间
+	ParkingName  string `json:"parking_name"`   // 停车场名称
+	FreeDuration int    `json:"free_duration"`  // 免费时长，单位秒
+	State        string `json:"state"`          // 停车入场状态，NORMAL：正常状态，BLOCKED：不可用状态
+	BlockReason  string `json:"block_reason"`   // 不可用状态描述
+}
+
+type VehicleTransaction struct {
+	Appid                 string              `json:"appid"`                   // 应用ID
+	SubAppid              string              `json:"sub_appid"`               // 子商户应用ID
+	SpMchid               string              `json:"sp_mchid"`                // 商户号
+	SubMchid              string              `json:"sub_mchid"`               // 子商户号
+	Description           string              `json:"description"`             // 服务描述
+	CreateTime            string              `json:"create_time"`             // 订单创建时间
+	OutTradeNo            string              `json:"out_trade_no"`            // 商户订单号
+	TransactionId         string              `json:"transaction_id"`          // 微信支付订单号
+	TradeState            string              `json:"trade_state"`             // 交易状态，SUCCESS：支付成功，ACCEPTED：已接收，等待扣款，PAY_FAIL：支付失败，REFUND：转入退款
+	TradeStateDescription string              `json:"trade_state_description"` // 交易状态描述
+	SuccessTime           string              `json:"success_time"`            // 支付完成时间
+	BankType              string              `json:"bank_type"`               // 付款银行
+	UserRepaid            string              `json:"user_repaid"`             // 用户是否已还款，Y：已还款，N：未还款
+	Attach                string              `json:"attach"`                  // 附加数据
+	TradeScene            string              `json:"trade_scene"`             // 交易场景，PARKING：车场停车场景
+	ParkingInfo           *VehicleParkingInfo `json:"parking_info"`            // 停车场景信息
+	Payer                 *VehiclePayer       `json:"payer"`                   // 支付者信息
+	Amount                *Amount             `json:"amount"`                  // 订单金额信息
+	PromotionDetail       []*PromotionDetail  `json:"promotion_detail"`        // 优惠功能
+}
+
+type VehicleParkingInfo struct {
+	ParkingId        string `json:"parking_id"`        // 停车入场id
+	PlateNumber      string `json:"plate_number"`      // 车牌号
+	PlateColor       string `json:"plate_color"`       // 车牌颜色
+	StartTime        string `json:"start_time"`        // 入场时间
+	EndTime          string `json:"end_time"`          // 出场时间
+	ParkingName      string `json:"parking_name"`      // 停车场名称
+	ChargingDuration int    `json:"charging_duration"` // 计费时长，单位秒
+	DeviceId         string `json:"device_id"`         // 停车场设备id
+}
+
+type VehiclePayer struct {
+	Openid    string `json:"openid"`     // 用户在appid下的唯一标识
+	SubOpenid string `json:"sub_openid"` // 用户在sub_appid下的唯一标识
+}
+
+type GoldPlanManage struct {
+	SubMchid string `json:"sub_mchid"` // 特约商户号
+}
+
+type FapiaoCardTemplate struct {
+	CardAppid string `json:"card_appid"` // 创建卡券模板时使用的appid
+	CardId    string `json:"card_id"`    // 卡券模板ID
+}
+
+type FapiaoTitleUrl struct {
+	MiniprogramAppid    string `json:"miniprogram_appid"`     // 抬头填写小程序appid
+	MiniprogramPath     string `json:"miniprogram_path"`      // 抬头填写小程序页面路径
+	MiniprogramUserName string `json:"miniprogram_user_name"` // 抬头填写小程序原始id
+}
+
+type FapiaoUserTitle struct {
+	Type        string `json:"type"`         // 购买方类型，INDIVIDUAL：个人，ORGANIZATION：单位
+	Name        string `json:"name"`         // 购买方名称
+	TaxpayerId  string `json:"taxpayer_id"`  // 纳税人识别号
+	Address     string `json:"address"`      // 地址
+	Telephone   string `json:"telephone"`    // 电话
+	BankName    string `json:"bank_name"`    // 开户银行
+	BankAccount string `json:"bank_account"` // 银行账号
+	Phone       string `json:"phone"`        // 手机号，加密字段，需调用 client.V3DecryptText() 解密
+	Email       string `json:"email"`        // 邮箱，加密字段，需调用 client.V3DecryptText() 解密
+}
+
+type FapiaoBaseInfo struct {
+	SellerName  string `json:"seller_name"`  // 销售方名称
+	TaxpayerId  string `json:"taxpayer_id"`  // 销售方纳税人识别号
+	Address     string `json:"address"`      // 销售方地址
+	Telephone   string `json:"telephone"`    // 销售方电话
+	BankName    string `json:"bank_name"`    // 销售方开户银行
+	BankAccount string `json:"bank_account"` // 销售方银行账号
+}
+
+type FapiaoTaxCodes struct {
+	Data       []*FapiaoTaxCode `json:"data"`        // 税收分类编码列表
+	TotalCount int              `json:"total_count"` // 总数量
+	Offset     int              `json:"offset"`      // 分页开始位置
+	Limit      int              `json:"limit"`       // 分页大小
+}
+
+type FapiaoTaxCode struct {
+	TaxCode       string `json:"tax_code"`       // 税收分类编码
+	GoodsCategory string `json:"goods_category"` // 商品和服务分类
+	GoodsName     string `json:"goods_name"`     // 商品和服务名称
+}
+
+type FapiaoQuery struct {
+	TotalCount        int                  `json:"total_count"`        // 发票数量
+	FapiaoInformation []*FapiaoInformation `json:"fapiao_information"` // 发票信息列表
+}
+
+type FapiaoInformation struct {
+	FapiaoId        string                 `json:"fapiao_id"`        // 商户发票单号
+	Status          string                 `json:"status"`           // 发票状态，ISSUE_ACCEPTED：开票已受理，ISSUED：已开具，REVERSE_ACCEPTED：冲红已受理，REVERSED：已冲红
+	BlueFapiao      *FapiaoDetail          `json:"blue_fapiao"`      // 蓝字发票信息
+	RedFapiao       *FapiaoDetail          `json:"red_fapiao"`       // 红字发票信息
+	CardInformation *FapiaoCardInformation `json:"card_information"` // 电子发票卡券信息
+	TotalAmount     int                    `json:"total_amount"`     // 总价税合计，单位为分
+	TaxAmount       int                    `json:"tax_amount"`       // 总税额，单位为分
+	Amount          int                    `json:"amount"`           // 总金额，单位为分
+	Remark          string                 `json:"remark"`           // 备注信息
+}
+
+type FapiaoDetail struct {
+	FapiaoCode string `json:"fapiao_code"` // 发票代码
+	FapiaoNo   string `json:"fapiao_no"`   // 发票号码
+	CheckCode  string `json:"check_code"`  // 校验码
+	Password   string `json:"password"`    // 密码
+	FapiaoTime string `json:"fapiao_time"` // 开票时间
+}
+
+type FapiaoCardInformation struct {
+	CardAppid  string `json:"card_appid"`  // 电子发票卡券模板所属的appid
+	CardOpenid string `json:"card_openid"` // 电子发票卡券所属的openid
+	CardId     string `json:"card_id"`     // 电子发票卡券模板ID
+	CardCode   string `json:"card_code"`   // 电子发票卡券ID
+	CardStatus string `json:"card_status"` // 电子发票卡券状态
+}
+
+type FapiaoFileDownInfo struct {
+	FapiaoDownloadInfoList []*FapiaoDownloadInfo `json:"fapiao_download_info_list"` // 发票下载信息列表
+}
+
+type FapiaoDownloadInfo struct {
+	FapiaoId    string `json:"fapiao_id"`    // 商户发票单号
+	DownloadUrl string `json:"download_url"` // 发票文件下载地址，通过 client.V3FapiaoFileDownload() 下载
+	Status      string `json:"status"`       // 发票状态
+}
+
+type ShoppingReceiptUpload struct {
+	StockId          string `json:"stock_id"`          // 批次号
+	OutTradeNo       string `json:"out_trade_no"`      // 商户订单号
+	TransactionId    string `json:"transaction_id"`    // 微信支付订单号
+	TransactionMchid string `json:"transaction_mchid"` // 微信支付订单的商户号
+	Openid           string `json:"openid"`            // 用户在appid下的唯一标识
+	UploadTime       string `json:"upload_time"`       // 上传时间
+}
+
+type BankSearchBank struct {
+	TotalCount int         `json:"total_count"` // 查询数据总条数
+	Data       []*BankInfo `json:"data"`        // 银行列表
+}
+
+type BankInfo struct {
+	BankAlias       string `json:"bank_alias"`        // 银行别名
+	BankAliasCode   string `json:"bank_alias_code"`   // 银行别名编码
+	AccountBank     string `json:"account_bank"`      // 开户银行
+	AccountBankCode int    `json:"account_bank_code"` // 开户银行编码
+	NeedBankBranch  bool   `json:"need_bank_branch"`  // 是否需要填写支行
+}
+
+type BankList struct {
+	TotalCount int         `json:"total_count"` // 查询数据总条数
+	Count      int         `json:"count"`       // 本次查询数据条数
+	Data       []*BankInfo `json:"data"`        // 银行列表
+	Offset     int         `json:"offset"`      // 本次查询偏移量
+	Links      *BankLinks  `json:"links"`       // 分页链接
+}
+
+type BankLinks struct {
+	Next string `json:"next"` // 下一页链接
+	Prev string `json:"prev"` // 上一页链接
+	Self string `json:"self"` // 当前链接
+}
+
+type BankProvinceList struct {
+	TotalCount int             `json:"total_count"` // 查询数据总条数
+	Data       []*BankProvince `json:"data"`        // 省份列表
+}
+
+type BankProvince struct {
+	ProvinceName string `json:"province_name"` // 省份名称
+	ProvinceCode int    `json:"province_code"` // 省份编码
+}
+
+type BankCityList struct {
+	TotalCount int         `json:"total_count"` // 查询数据总条数
+	Data       []*BankCity `json:"data"`        // 城市列表
+}
+
+type BankCity struct {
+	CityName string `json:"city_name"` // 城市名称
+	CityCode int    `json:"city_code"` // 城市编码
+}
+
+type BankBranchList struct {
+	TotalCount      int           `json:"total_count"`       // 查询数据总条数
+	Count           int           `json:"count"`             // 本次查询数据条数
+	Data            []*BankBranch `json:"data"`              // 支行列表
+	Offset          int           `json:"offset"`            // 本次查询偏移量
+	Links           *BankLinks    `json:"links"`             // 分页链接
+	AccountBank     string        `json:"account_bank"`      // 开户银行
+	AccountBankCode int           `json:"account_bank_code"` // 开户银行编码
+	BankAlias       string        `json:"bank_alias"`        // 银行别名
+	BankAliasCode   string        `json:"bank_alias_code"`   // 银行别名编码
+}
+
+type BankBranch struct {
+	BankBranchName string `json:"bank_branch_name"` // 开户银行支行名称
+	BankBranchId   string `json:"bank_branch_id"`   // 开户银行支行联行号
+}
+
+type BrandRedpacketSend struct {
+	OutBatchNo string `json:"out_batch_no"` // 商家批次单号
+	BatchNo    string `json:"batch_no"`     // 微信批次单号
+	CreateTime string `json:"create_time"`  // 批次创建时间
+}
+
+type BrandRedpacketBatch struct {
+	BrandId       int                     `json:"brand_id"`                 // 品牌ID
+	BatchNo       string                  `json:"batch_no"`                 // 微信批次单号
+	OutBatchNo    string                  `json:"out_batch_no"`             // 商家批次单号
+	BatchName     string                  `json:"batch_name"`               // 批次名称
+	BatchRemark   string                  `json:"batch_remark"`             // 批次备注
+	BatchStatus   string                  `json:"batch_status"`             // 批次状态：ACCEPTED、PROCESSING、FINISHED、CLOSED
+	CloseReason   string                  `json:"close_reason,omitempty"`   // 批次关闭原因
+	TotalAmount   int                     `json:"total_amount"`             // 红包总金额，单位为分
+	TotalNum      int                     `json:"total_num"`                // 红包总笔数
+	SuccessAmount int                     `json:"success_amount,omitempty"` // 发放成功的金额，单位为分
+	SuccessNum    int                     `json:"success_num,omitempty"`    // 发放成功的笔数
+	FailAmount    int                     `json:"fail_amount,omitempty"`    // 发放失败的金额，单位为分
+	FailNum       int                     `json:"fail_num,omitempty"`       // 发放失败的笔数
+	CreateTime    string                  `json:"create_time,omitempty"`    // 批次创建时间
+	UpdateTime    string                  `json:"update_time,omitempty"`    // 批次最近一次状态变更的时间
+	DetailList    []*BrandRedpacketDetail `json:"detail_list,omitempty"`    // 红包明细单列表，need_query_detail 为 true 时返回
+	Offset        int                     `json:"offset,omitempty"`         // 该次请求明细单的起始位置
+	Limit         int                     `json:"limit,omitempty"`          // 该次请求可返回的最大明细单条数
+}
+
+type BrandRedpacketDetail struct {
+	BrandId      int    `json:"brand_id,omitempty"`     // 品牌ID
+	BatchNo      string `json:"batch_no,omitempty"`     // 微信批次单号
+	OutBatchNo   string `json:"out_batch_no,omitempty"` // 商家批次单号
+	DetailNo     string `json:"detail_no"`              // 微信明细单号
+	OutDetailNo  string `json:"out_detail_no"`          // 商家明细单号
+	DetailStatus string `json:"detail_status"`          // 明细状态：PROCESSING、SUCCESS、FAIL
+	Amount       int    `json:"amount,omitempty"`       // 红包金额，单位为分
+	Openid       string `json:"openid,omitempty"`       // 用户在品牌 appid 下的唯一标识
+	FailReason   string `json:"fail_reason,omitempty"`  // 发放失败原因
+	SendTime     string `json:"send_time,omitempty"`    // 红包发放时间
+	UpdateTime   string `json:"update_time,omitempty"`  // 明细最后一次状态变更的时间
+}
+
+type PapayPresign struct {
+	PreEntrustwebId string `json:"pre_entrustweb_id,omitempty"` // 预签约ID，APP、JSAPI、小程序拉起签约时使用
+	RedirectUrl     string `json:"redirect_url,omitempty"`      // 签约跳转链接，H5签约时使用
+}
+
+type PapayContract struct {
+	Mchid                     string `json:"mchid"`                                 // 商户号
+	Appid                     string `json:"appid"`                                 // 应用ID
+	ContractId                string `json:"contract_id"`                           // 委托代扣协议号
+	PlanId                    int    `json:"plan_id"`                               // 模板ID
+	OutContractCode           string `json:"out_contract_code"`                     // 商户侧签约协议号
+	ContractDisplayAccount    string `json:"contract_display_account"`              // 签约用户名称
+	ContractState             string `json:"contract_state"`                        // 签约状态：ADDED 已签约，TERMINATED 已解约
+	Openid                    string `json:"openid"`                                // 用户在商户appid下的唯一标识
+	ContractSignedTime        string `json:"contract_signed_time"`                  // 签约时间
+	ContractExpiredTime       string `json:"contract_expired_time"`                 // 协议到期时间
+	ContractTerminatedTime    string `json:"contract_terminated_time,omitempty"`    // 解约时间
+	ContractTerminationMode   string `json:"contract_termination_mode,omitempty"`   // 解约方式
+	ContractTerminationRemark string `json:"contract_termination_remark,omitempty"` // 解约备注
+	RequestSerial             int64  `json:"request_serial,omitempty"`              // 请求序列号
+}
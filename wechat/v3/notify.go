@@ -1,6 +1,7 @@
 package wechat
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/aes"
 	"github.com/cedarwu/gopay/pkg/xlog"
 )
 
@@ -129,6 +131,46 @@ type Receiver struct {
 	Description string `json:"description"` // 分账/回退描述
 }
 
+// 停车入场状态变更通知解密结果，通过 client.DecipherNotifyResource() 或 wechat.V3DecryptNotifyResource() 解密
+type V3DecryptParkingInResult struct {
+	SpMchid          string `json:"sp_mchid"`
+	SubMchid         string `json:"sub_mchid"`
+	ParkingId        string `json:"parking_id"`
+	OutParkingNo     string `json:"out_parking_no"`
+	PlateNumber      string `json:"plate_number"`
+	PlateColor       string `json:"plate_color"`
+	StartTime        string `json:"start_time"`
+	ParkingName      string `json:"parking_name"`
+	FreeDuration     int    `json:"free_duration"`
+	ParkingState     string `json:"parking_state"`
+	BlockedStateDesc string `json:"blocked_state_description"`
+	StateUpdateTime  string `json:"state_update_time"`
+}
+
+// 投诉通知解密结果，通过 client.DecipherNotifyResource() 或 wechat.V3DecryptNotifyResource() 解密
+//	ActionType：CREATE_COMPLAINT：用户提交投诉，CONTINUE_COMPLAINT：用户继续投诉，USER_RESPONSE：用户新留言，RESPONSE_BY_PLATFORM：平台新留言，SELLER_REFUND：商户发起全额退款，MERCHANT_RESPONSE：商户新回复，MERCHANT_CONFIRM_COMPLETE：商户反馈处理完成
+type V3DecryptComplaintResult struct {
+	ComplaintId string `json:"complaint_id"`
+	ActionType  string `json:"action_type"`
+}
+
+// 委托代扣签约、解约通知解密结果，通过 client.DecipherNotifyResource() 或 wechat.V3DecryptNotifyResource() 解密
+type V3DecryptPapayContractResult struct {
+	Mchid                   string `json:"mchid"`
+	Appid                   string `json:"appid"`
+	ContractId              string `json:"contract_id"`
+	PlanId                  int    `json:"plan_id"`
+	OutContractCode         string `json:"out_contract_code"`
+	ContractDisplayAccount  string `json:"contract_display_account"`
+	ContractState           string `json:"contract_state"`
+	Openid                  string `json:"openid"`
+	ContractSignedTime      string `json:"contract_signed_time"`
+	ContractExpiredTime     string `json:"contract_expired_time"`
+	ContractTerminatedTime  string `json:"contract_terminated_time,omitempty"`
+	ContractTerminationMode string `json:"contract_termination_mode,omitempty"`
+	RequestSerial           int64  `json:"request_serial,omitempty"`
+}
+
 type V3NotifyReq struct {
 	Id           string    `json:"id"`
 	CreateTime   string    `json:"create_time"`
@@ -165,6 +207,16 @@ func V3ParseNotify(req *http.Request) (notifyReq *V3NotifyReq, err error) {
 	return notifyReq, nil
 }
 
+// 是否为退款结果通知，event_type 为 REFUND.SUCCESS、REFUND.ABNORMAL、REFUND.CLOSED 时返回 true
+//	退款结果通知可通过 notifyReq.DecryptRefundCipherText() 解密
+func (v *V3NotifyReq) IsRefundEvent() bool {
+	switch v.EventType {
+	case EventTypeRefundSuccess, EventTypeRefundAbnormal, EventTypeRefundClosed:
+		return true
+	}
+	return false
+}
+
 // 异步通知验签
 //	wxPubKeyContent 是通过client.GetPlatformCerts()接口向微信获取的微信平台公钥证书内容
 func (v *V3NotifyReq) VerifySign(wxPkContent string) (err error) {
@@ -174,6 +226,45 @@ func (v *V3NotifyReq) VerifySign(wxPkContent string) (err error) {
 	return errors.New("verify notify sign, bug SignInfo is nil")
 }
 
+// 使用 client 的平台证书对回调验签，并解密回调中的加密信息到 result
+//	result：接收解密结果的结构体指针，如 *V3DecryptResult、*V3DecryptRefundResult、*V3DecryptProfitShareResult 等
+//	注意：请预先通过 client.SetPlatformCert() 或 client.AutoRefreshPlatformCerts() 设置平台证书，验签失败时返回 *VerifySignError
+func (c *ClientV3) DecipherNotifyResource(notifyReq *V3NotifyReq, result interface{}) (err error) {
+	if notifyReq == nil || notifyReq.SignInfo == nil {
+		return errors.New("verify notify sign, bug SignInfo is nil")
+	}
+	if err = c.verifySignInfo(notifyReq.SignInfo); err != nil {
+		return err
+	}
+	if notifyReq.Resource == nil {
+		return errors.New("notify data Resource is nil")
+	}
+	if notifyReq.Resource.Algorithm == AlgorithmSM4GCM {
+		c.mu.RLock()
+		sm4Key := c.sm4Key
+		c.mu.RUnlock()
+		return V3DecryptNotifyResourceSM4(notifyReq.Resource, sm4Key, result)
+	}
+	return V3DecryptNotifyResource(notifyReq.Resource, string(c.apiV3Key), result)
+}
+
+// 解密回调中的加密信息到 result
+//	result：接收解密结果的结构体指针
+func V3DecryptNotifyResource(resource *Resource, apiV3Key string, result interface{}) (err error) {
+	if resource == nil {
+		return errors.New("notify data Resource is nil")
+	}
+	cipherBytes, _ := base64.StdEncoding.DecodeString(resource.Ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(resource.Nonce), []byte(resource.AssociatedData), []byte(apiV3Key))
+	if err != nil {
+		return fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
+	}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	}
+	return nil
+}
+
 // 解密 普通支付 回调中的加密信息
 func (v *V3NotifyReq) DecryptCipherText(apiV3Key string) (result *V3DecryptResult, err error) {
 	if v.Resource != nil {
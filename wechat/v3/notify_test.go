@@ -0,0 +1,70 @@
+package wechat
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cedarwu/gopay/pkg/aes"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+func TestDecipherNotifyResource(t *testing.T) {
+	apiV3Key := util.GetRandomString(32)
+	c, err := NewClientV3(MchId, SerialNo, apiV3Key, privatePKCS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetPlatformCert([]byte(publicPKCS1), "PLATFORM_SERIAL_NO")
+
+	// 模拟微信回调：加密 resource，并用平台私钥签名
+	nonce, cipherBytes, err := aes.GCMEncrypt([]byte(`{"out_refund_no":"GOPAY_REFUND","refund_status":"SUCCESS"}`), []byte("refund"), []byte(apiV3Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(&V3NotifyReq{
+		Id:           "EV-2018022511223320873",
+		EventType:    EventTypeRefundSuccess,
+		ResourceType: "encrypt-resource",
+		Resource: &Resource{
+			Algorithm:      "AEAD_AES_256_GCM",
+			Ciphertext:     base64.StdEncoding.EncodeToString(cipherBytes),
+			AssociatedData: "refund",
+			Nonce:          string(nonce),
+		},
+	})
+	ts, nonceStr := "1622606240", util.GetRandomString(32)
+	sign, err := c.rsaSign(ts + "\n" + nonceStr + "\n" + string(body) + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("POST", "/notify", bytes.NewReader(body))
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderNonce, nonceStr)
+	req.Header.Set(HeaderSignature, sign)
+	req.Header.Set(HeaderSerial, "PLATFORM_SERIAL_NO")
+
+	notifyReq, err := V3ParseNotify(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !notifyReq.IsRefundEvent() {
+		t.Fatalf("IsRefundEvent() = false, event_type = %s", notifyReq.EventType)
+	}
+	result := new(V3DecryptRefundResult)
+	if err = c.DecipherNotifyResource(notifyReq, result); err != nil {
+		t.Fatal(err)
+	}
+	if result.OutRefundNo != "GOPAY_REFUND" || result.RefundStatus != RefundStatusSuccess {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	notifyReq.SignInfo.SignBody += " "
+	var verifyErr *VerifySignError
+	if err = c.DecipherNotifyResource(notifyReq, result); !errors.As(err, &verifyErr) {
+		t.Fatalf("DecipherNotifyResource() error = %v, want *VerifySignError", err)
+	}
+}
@@ -0,0 +1,171 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 委托代扣预签约API
+//	presignType：PapayPresignApp、PapayPresignJsapi、PapayPresignH5、PapayPresignMiniProgram
+//	bm：appid、plan_id、out_contract_code、contract_display_account、notify_url 等
+//	签约结果通过 PAPAY.SIGN 事件通知，解密结果为 V3DecryptPapayContractResult
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/index.shtml
+func (c *ClientV3) V3PapayPresign(presignType string, bm gopay.BodyMap) (wxRsp *PapayPresignRsp, err error) {
+	if err = bm.CheckEmptyError("appid", "plan_id", "out_contract_code", "contract_display_account"); err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf(v3PapayPresign, presignType)
+	authorization, err := c.authorization(MethodPost, uri, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PapayPresignRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PapayPresign)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过商户侧签约协议号查询签约关系API
+//	bm：appid 等查询参数
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/index.shtml
+func (c *ClientV3) V3PapayContractQuery(planId int, outContractCode string, bm gopay.BodyMap) (wxRsp *PapayContractRsp, err error) {
+	uri := fmt.Sprintf(v3PapayContractQuery, planId, outContractCode) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PapayContractRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PapayContract)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过委托代扣协议号查询签约关系API
+//	bm：appid 等查询参数
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/index.shtml
+func (c *ClientV3) V3PapayContractQueryById(contractId string, bm gopay.BodyMap) (wxRsp *PapayContractRsp, err error) {
+	uri := fmt.Sprintf(v3PapayContractQueryById, contractId) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PapayContractRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PapayContract)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 委托代扣解约API
+//	bm：appid、contract_termination_remark 等
+//	解约结果通过 PAPAY.TERMINATE 事件通知
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/index.shtml
+func (c *ClientV3) V3PapayContractTerminate(planId int, outContractCode string, bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	uri := fmt.Sprintf(v3PapayContractTerminate, planId, outContractCode)
+	authorization, err := c.authorization(MethodPost, uri, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 委托代扣申请扣款API
+//	bm：appid、out_trade_no、description、contract_id、notify_url、amount 等
+//	扣款结果通过 TRANSACTION.SUCCESS 事件通知，也可通过 client.V3PapayPayQuery() 查询
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/index.shtml
+func (c *ClientV3) V3PapayPayApply(bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	if err = bm.CheckEmptyError("appid", "out_trade_no", "description", "contract_id", "amount"); err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3PapayPayApply, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3PapayPayApply, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 委托代扣查询扣款订单API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/index.shtml
+func (c *ClientV3) V3PapayPayQuery(outTradeNo string) (wxRsp *QueryOrderRsp, err error) {
+	uri := fmt.Sprintf(v3PapayPayQuery, outTradeNo) + "?mchid=" + c.Mchid
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &QueryOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(QueryOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
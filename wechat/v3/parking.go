@@ -0,0 +1,117 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 查询车牌服务开通信息API
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter8_8_1.shtml
+func (c *ClientV3) V3VehicleParkingServiceFind(bm gopay.BodyMap) (wxRsp *VehicleParkingServiceRsp, err error) {
+	if err = bm.CheckEmptyError("appid", "plate_number", "plate_color", "openid"); err != nil {
+		return nil, err
+	}
+	uri := v3VehicleParkingServiceFind + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &VehicleParkingServiceRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(VehicleParkingService)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 创建停车入场API
+//	入场状态变更通知解密：client.DecipherNotifyResource(notifyReq, new(wechat.V3DecryptParkingInResult))
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter8_8_2.shtml
+func (c *ClientV3) V3VehicleParkingCreate(bm gopay.BodyMap) (wxRsp *VehicleParkingRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3VehicleParkingCreate, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3VehicleParkingCreate, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &VehicleParkingRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(VehicleParking)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 停车扣费受理API
+//	订单支付结果通知解密：client.DecipherNotifyResource(notifyReq, new(wechat.VehicleTransaction))
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter8_8_3.shtml
+func (c *ClientV3) V3VehicleTransactionParking(bm gopay.BodyMap) (wxRsp *VehicleTransactionRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3VehicleTransactionParking, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3VehicleTransactionParking, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &VehicleTransactionRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(VehicleTransaction)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 停车查询订单API
+//	bm：sub_mchid（服务商模式）
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter8_8_4.shtml
+func (c *ClientV3) V3VehicleTransactionQuery(outTradeNo string, bm gopay.BodyMap) (wxRsp *VehicleTransactionRsp, err error) {
+	uri := fmt.Sprintf(v3VehicleTransactionQuery, outTradeNo) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &VehicleTransactionRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(VehicleTransaction)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
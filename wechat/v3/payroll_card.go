@@ -0,0 +1,195 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 生成授权token API
+//	注意：入参 user_name、id_card_number 需调用 client.V3EncryptText() 进行加密
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_1_1.shtml
+func (c *ClientV3) V3PayrollCardToken(bm gopay.BodyMap) (wxRsp *PayrollCardTokenRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3PayrollCardToken, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3PayrollCardToken, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PayrollCardTokenRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PayrollCardToken)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询微工卡授权关系API
+//	bm：sub_mchid、appid、sub_appid 等查询参数
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_1_2.shtml
+func (c *ClientV3) V3PayrollCardRelation(openid string, bm gopay.BodyMap) (wxRsp *PayrollCardRelationRsp, err error) {
+	uri := fmt.Sprintf(v3PayrollCardRelation, openid) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PayrollCardRelationRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PayrollCardRelation)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 微工卡核身预下单API
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_1_3.shtml
+func (c *ClientV3) V3PayrollCardPreOrder(bm gopay.BodyMap) (wxRsp *PayrollCardPreOrderRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3PayrollCardPreOrder, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3PayrollCardPreOrder, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PayrollCardPreOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PayrollCardPreOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 获取核身结果API
+//	bm：sub_mchid 等查询参数
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_1_4.shtml
+func (c *ClientV3) V3PayrollCardAuthResult(authenticateNumber string, bm gopay.BodyMap) (wxRsp *PayrollCardAuthResultRsp, err error) {
+	uri := fmt.Sprintf(v3PayrollCardAuthResult, authenticateNumber) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PayrollCardAuthResultRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PayrollCardAuthResult)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询核身记录API
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_1_5.shtml
+func (c *ClientV3) V3PayrollCardAuthList(bm gopay.BodyMap) (wxRsp *PayrollCardAuthListRsp, err error) {
+	if err = bm.CheckEmptyError("openid", "sub_mchid", "authenticate_date"); err != nil {
+		return nil, err
+	}
+	uri := v3PayrollCardAuthList + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PayrollCardAuthListRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PayrollCardAuthList)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 微工卡核身预下单（流程中完成授权）API
+//	注意：入参 user_name、id_card_number 需调用 client.V3EncryptText() 进行加密
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_1_6.shtml
+func (c *ClientV3) V3PayrollCardPreOrderWithAuth(bm gopay.BodyMap) (wxRsp *PayrollCardPreOrderRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3PayrollCardPreOrderWithAuth, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3PayrollCardPreOrderWithAuth, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PayrollCardPreOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PayrollCardPreOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 微工卡发起批量转账API
+//	注意：入参明细中的 user_name 需调用 client.V3EncryptText() 进行加密
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_1_8.shtml
+func (c *ClientV3) V3PayrollCardTransferBatch(bm gopay.BodyMap) (wxRsp *PayrollCardTransferBatchRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3PayrollCardTransferBatch, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3PayrollCardTransferBatch, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PayrollCardTransferBatchRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PayrollCardTransferBatch)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
@@ -9,6 +9,7 @@ import (
 )
 
 // 申请退款API
+//	服务商模式需在 bm 中传入 sub_mchid
 //	Code = 0 is success
 //	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_9.shtml
 //	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter4_1_9.shtml
@@ -36,9 +37,9 @@ func (c *ClientV3) V3Refund(bm gopay.BodyMap) (wxRsp *RefundRsp, err error) {
 }
 
 // 查询单笔退款API
+//	服务商模式需传 sub_mchid，请使用 client.V3PartnerRefundQuery()
 //	Code = 0 is success
 //	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_10.shtml
-//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter4_1_10.shtml
 func (c *ClientV3) V3RefundQuery(outRefundNo string) (wxRsp *RefundQueryRsp, err error) {
 	uri := fmt.Sprintf(v3DomesticRefundQuery, outRefundNo)
 	authorization, err := c.authorization(MethodGet, uri, nil)
@@ -62,3 +63,30 @@ func (c *ClientV3) V3RefundQuery(outRefundNo string) (wxRsp *RefundQueryRsp, err
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
+
+// 查询单笔退款API（服务商）
+//	Code = 0 is success
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter4_1_10.shtml
+func (c *ClientV3) V3PartnerRefundQuery(subMchid, outRefundNo string) (wxRsp *RefundQueryRsp, err error) {
+	uri := fmt.Sprintf(v3DomesticRefundQuery, outRefundNo) + "?sub_mchid=" + subMchid
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp = &RefundQueryRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(RefundQueryResponse)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
@@ -0,0 +1,44 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+// 上传电子小票API
+//	meta：transaction_id、transaction_mchid、out_trade_no、openid、sha256、upload_time 等，仅 meta 参与签名
+//	img：电子小票图片，sha256 为图片文件的SHA256值
+//	注意：图片不能超过2MB
+//	Code = 0 is success
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_8_1.shtml
+func (c *ClientV3) V3ShoppingReceiptUpload(meta gopay.BodyMap, img *util.File) (wxRsp *ShoppingReceiptUploadRsp, err error) {
+	if err = meta.CheckEmptyError("transaction_id", "transaction_mchid", "sha256", "upload_time"); err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3ShoppingReceiptUpload, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("meta", meta).SetFormFile("file", img)
+	res, si, bs, err := c.doProdPostFile(bm, v3ShoppingReceiptUpload, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &ShoppingReceiptUploadRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(ShoppingReceiptUpload)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
@@ -36,6 +36,7 @@ func V3VerifySign(timestamp, nonce, signBody, sign, wxPubKeyContent string) (err
 
 // PaySignOfJSAPI 获取 JSAPI paySign
 //	文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_4.shtml
+//	注意：国密模式 client 返回 ErrSMModeUnsupported
 func (c *ClientV3) PaySignOfJSAPI(appid, prepayid string) (jsapi *JSAPIPayParams, err error) {
 	ts := util.Int642String(time.Now().Unix())
 	nonceStr := util.GetRandomString(32)
@@ -60,6 +61,7 @@ func (c *ClientV3) PaySignOfJSAPI(appid, prepayid string) (jsapi *JSAPIPayParams
 
 // PaySignOfApp 获取 App sign
 //	文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_2_4.shtml
+//	注意：国密模式 client 返回 ErrSMModeUnsupported
 func (c *ClientV3) PaySignOfApp(appid, prepayid string) (app *AppPayParams, err error) {
 	ts := util.Int642String(time.Now().Unix())
 	nonceStr := util.GetRandomString(32)
@@ -84,6 +86,7 @@ func (c *ClientV3) PaySignOfApp(appid, prepayid string) (app *AppPayParams, err
 
 // PaySignOfApplet 获取 小程序 paySign
 //	文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_4.shtml
+//	注意：国密模式 client 返回 ErrSMModeUnsupported
 func (c *ClientV3) PaySignOfApplet(appid, prepayid string) (applet *AppletParams, err error) {
 	jsapi, err := c.PaySignOfJSAPI(appid, prepayid)
 	if err != nil {
@@ -115,6 +118,13 @@ func (c *ClientV3) authorization(method, path string, bm gopay.BodyMap) (string,
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_SignString:\n%s", _str)
 	}
+	if c.smPrivateKey != nil {
+		sign, err := c.sm2Sign(_str)
+		if err != nil {
+			return "", err
+		}
+		return AuthorizationSM + ` mchid="` + c.Mchid + `",nonce_str="` + nonceStr + `",timestamp="` + ts + `",serial_no="` + c.SerialNo + `",signature="` + sign + `"`, nil
+	}
 	sign, err := c.rsaSign(_str)
 	if err != nil {
 		return "", err
@@ -123,6 +133,9 @@ func (c *ClientV3) authorization(method, path string, bm gopay.BodyMap) (string,
 }
 
 func (c *ClientV3) rsaSign(str string) (string, error) {
+	if c.smPrivateKey != nil {
+		return "", ErrSMModeUnsupported
+	}
 	if c.privateKey == nil {
 		return "", errors.New("privateKey can't be nil")
 	}
@@ -135,21 +148,59 @@ func (c *ClientV3) rsaSign(str string) (string, error) {
 	return base64.StdEncoding.EncodeToString(result), nil
 }
 
+// ErrPlatformCertNotFound 应答的 Wechatpay-Serial 未匹配到平台证书
+var ErrPlatformCertNotFound = errors.New("platform cert not found")
+
+// VerifySignError 同步应答验签失败
+//	可通过 errors.As(err, &verifyErr) 判断，通过 errors.Is(err, ErrPlatformCertNotFound) 判断是否为证书未匹配
+type VerifySignError struct {
+	SerialNo  string // 应答 Wechatpay-Serial
+	Timestamp string // 应答 Wechatpay-Timestamp
+	Nonce     string // 应答 Wechatpay-Nonce
+	Err       error
+}
+
+func (e *VerifySignError) Error() string {
+	return fmt.Sprintf("verify sign failed: serial_no=%s, %v", e.SerialNo, e.Err)
+}
+
+func (e *VerifySignError) Unwrap() error {
+	return e.Err
+}
+
 // 自动同步请求验签
+//	验签失败时返回 *VerifySignError
 func (c *ClientV3) verifySyncSign(si *SignInfo) (err error) {
-	if c.autoSign && c.wxPublicKey != nil {
+	c.mu.RLock()
+	autoSign := c.autoSign && (c.wxPublicKey != nil || c.wxSMPublicKey != nil)
+	c.mu.RUnlock()
+	if autoSign {
 		if si != nil {
-			str := si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n"
-			signBytes, _ := base64.StdEncoding.DecodeString(si.HeaderSignature)
-
-			h := sha256.New()
-			h.Write([]byte(str))
-			if err = rsa.VerifyPKCS1v15(c.wxPublicKey, crypto.SHA256, h.Sum(nil), signBytes); err != nil {
-				return fmt.Errorf("verify sign failed: %+v", err)
-			}
-			return nil
+			return c.verifySignInfo(si)
 		}
 		return errors.New("auto verify sign, bug SignInfo is nil")
 	}
 	return nil
 }
+
+// verifySignInfo 使用 Wechatpay-Serial 对应的平台证书验签，验签失败时返回 *VerifySignError
+func (c *ClientV3) verifySignInfo(si *SignInfo) (err error) {
+	if c.smPrivateKey != nil {
+		return c.verifySignInfoSM(si)
+	}
+	pubKey := c.platformPublicKey(si.HeaderSerial)
+	if pubKey == nil {
+		if pubKey = c.refreshUnknownCert(si.HeaderSerial); pubKey == nil {
+			return &VerifySignError{SerialNo: si.HeaderSerial, Timestamp: si.HeaderTimestamp, Nonce: si.HeaderNonce, Err: ErrPlatformCertNotFound}
+		}
+	}
+	str := si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n"
+	signBytes, _ := base64.StdEncoding.DecodeString(si.HeaderSignature)
+
+	h := sha256.New()
+	h.Write([]byte(str))
+	if err = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, h.Sum(nil), signBytes); err != nil {
+		return &VerifySignError{SerialNo: si.HeaderSerial, Timestamp: si.HeaderTimestamp, Nonce: si.HeaderNonce, Err: err}
+	}
+	return nil
+}
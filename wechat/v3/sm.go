@@ -0,0 +1,109 @@
+package wechat
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/sm2"
+	"github.com/cedarwu/gopay/pkg/sm4"
+)
+
+// ErrSMModeUnsupported 国密模式（NewClientV3SM 初始化的 client）不支持的功能，
+// 包括敏感信息加解密、调起支付签名及自动刷新平台证书
+var ErrSMModeUnsupported = errors.New("wechat: not supported by the SM mode client")
+
+// NewClientV3SM 初始化微信客户端 V3（国密模式）
+//	请求使用 SM3 摘要、SM2 签名（WECHATPAY2-SM2-WITH-SM3），应答及回调使用 SM2 平台证书验签
+//	mchid：商户ID 或者服务商模式的 sp_mchid
+//	serialNo：商户国密API证书的证书序列号
+//	apiV3Key：APIv3Key，商户平台获取
+//	privateKey：国密私钥 apiclient_key.pem 读取后的字符串内容
+//	注意：国密模式仅支持请求签名、应答及回调验签、AEAD_SM4_GCM 回调解密
+//	注意：仅支持通过 client.SetPlatformCertSM() 设置的单张国密平台证书，平台证书轮换时需重新设置
+//	注意：不支持 client.AutoRefreshPlatformCerts()、敏感信息加解密（V3EncryptText、V3DecryptText）及调起支付签名（PaySignOf*），调用返回 ErrSMModeUnsupported
+func NewClientV3SM(mchid, serialNo, apiV3Key, privateKey string) (client *ClientV3, err error) {
+	priKey, err := sm2.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	client = &ClientV3{
+		Mchid:        mchid,
+		SerialNo:     serialNo,
+		apiV3Key:     []byte(apiV3Key),
+		smPrivateKey: priKey,
+		DebugSwitch:  gopay.DebugOff,
+	}
+	return client, nil
+}
+
+// 设置 国密平台证书 和 证书序列号（国密模式）
+//	仅保存一张平台证书，应答或回调的 Wechatpay-Serial 与之不一致时验签失败
+//	wxCertContent：国密平台证书或公钥内容
+//	wxSerialNo：平台证书序列号，为空时使用证书中的序列号
+func (c *ClientV3) SetPlatformCertSM(wxCertContent []byte, wxSerialNo string) (err error) {
+	pubKey, serialNo, err := sm2.ParsePublicKey(wxCertContent)
+	if err != nil {
+		return err
+	}
+	if wxSerialNo == "" {
+		wxSerialNo = serialNo
+	}
+	c.mu.Lock()
+	c.wxSMPublicKey = pubKey
+	c.wxSerialNo = wxSerialNo
+	c.mu.Unlock()
+	return nil
+}
+
+// 设置回调解密使用的 SM4 密钥（国密模式），用于解密 algorithm 为 AEAD_SM4_GCM 的回调
+//	key：16字节 SM4 密钥，请按微信支付国密接入文档获取
+func (c *ClientV3) SetSM4Key(key []byte) (client *ClientV3) {
+	c.mu.Lock()
+	c.sm4Key = key
+	c.mu.Unlock()
+	return c
+}
+
+// 解密 AEAD_SM4_GCM 加密的回调信息到 result
+//	result：接收解密结果的结构体指针
+func V3DecryptNotifyResourceSM4(resource *Resource, sm4Key []byte, result interface{}) (err error) {
+	if resource == nil {
+		return errors.New("notify data Resource is nil")
+	}
+	cipherBytes, _ := base64.StdEncoding.DecodeString(resource.Ciphertext)
+	decrypt, err := sm4.GCMDecrypt(cipherBytes, []byte(resource.Nonce), []byte(resource.AssociatedData), sm4Key)
+	if err != nil {
+		return fmt.Errorf("sm4.GCMDecrypt, err:%+v", err)
+	}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	}
+	return nil
+}
+
+func (c *ClientV3) sm2Sign(str string) (string, error) {
+	sign, err := sm2.Sign(c.smPrivateKey, nil, []byte(str))
+	if err != nil {
+		return "", fmt.Errorf("sm2.Sign(),err:%+v", err)
+	}
+	return base64.StdEncoding.EncodeToString(sign), nil
+}
+
+// verifySignInfoSM 使用国密平台证书验签，验签失败时返回 *VerifySignError
+func (c *ClientV3) verifySignInfoSM(si *SignInfo) (err error) {
+	c.mu.RLock()
+	pubKey, serialNo := c.wxSMPublicKey, c.wxSerialNo
+	c.mu.RUnlock()
+	if pubKey == nil || (si.HeaderSerial != "" && si.HeaderSerial != serialNo) {
+		return &VerifySignError{SerialNo: si.HeaderSerial, Timestamp: si.HeaderTimestamp, Nonce: si.HeaderNonce, Err: ErrPlatformCertNotFound}
+	}
+	str := si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n"
+	signBytes, _ := base64.StdEncoding.DecodeString(si.HeaderSignature)
+	if !sm2.Verify(pubKey, nil, []byte(str), signBytes) {
+		return &VerifySignError{SerialNo: si.HeaderSerial, Timestamp: si.HeaderTimestamp, Nonce: si.HeaderNonce, Err: errors.New("sm2 verify sign failed")}
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package wechat
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/cedarwu/gopay"
@@ -264,3 +266,13 @@ func (c *ClientV3) V3TransferDetailReceiptQuery(bm gopay.BodyMap) (*TransferDeta
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
+
+// 下载电子回单API，电子回单文件以流的方式写入 w
+//	hashType、hashValue、downloadUrl：查询转账电子回单API、查询转账明细电子回单受理结果API返回的 hash_type、hash_value、download_url
+//	hashValue 不为空时，按 hashType 校验电子回单文件，校验失败返回 ErrBillHashMismatch
+//	注意：校验失败时电子回单内容已写入 w，请丢弃 w 中的内容
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/pay/transfer/chapter4_3.shtml
+//	服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/pay/transfer_partner/chapter4_3.shtml
+func (c *ClientV3) V3TransferReceiptDownload(ctx context.Context, w io.Writer, hashType, hashValue, downloadUrl string) (n int64, err error) {
+	return c.V3BillDownLoadBillTo(ctx, w, &TradeBill{HashType: hashType, HashValue: hashValue, DownloadUrl: downloadUrl})
+}
@@ -1,3 +1,194 @@
 package wechat
 
-// 提现
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cedarwu/gopay"
+)
+
+// 特约商户余额提现API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/fund/chapter3_2.shtml
+func (c *ClientV3) V3EcommerceWithdraw(bm gopay.BodyMap) (wxRsp *WithdrawRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3Withdraw, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3Withdraw, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &WithdrawRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Withdraw)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 微信支付提现单号查询特约商户提现状态API
+//	bm：sub_mchid
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/fund/chapter3_3.shtml
+func (c *ClientV3) V3EcommerceWithdrawStatus(withdrawId string, bm gopay.BodyMap) (wxRsp *WithdrawStatusRsp, err error) {
+	uri := fmt.Sprintf(v3WithdrawStatus, withdrawId) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &WithdrawStatusRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(WithdrawStatus)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 商户提现单号查询特约商户提现状态API
+//	bm：sub_mchid
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/fund/chapter3_3.shtml
+func (c *ClientV3) V3EcommerceWithdrawStatusByOutReqNo(outRequestNo string, bm gopay.BodyMap) (wxRsp *WithdrawStatusRsp, err error) {
+	uri := fmt.Sprintf(v3WithdrawStatusByOutReqNo, outRequestNo) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &WithdrawStatusRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(WithdrawStatus)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 电商平台提现API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/fund/chapter3_5.shtml
+func (c *ClientV3) V3MerchantWithdraw(bm gopay.BodyMap) (wxRsp *WithdrawRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3MerchantWithdraw, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(bm, v3MerchantWithdraw, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &WithdrawRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Withdraw)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 微信支付提现单号查询电商平台提现状态API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/fund/chapter3_6.shtml
+func (c *ClientV3) V3MerchantWithdrawStatus(withdrawId string) (wxRsp *WithdrawStatusRsp, err error) {
+	uri := fmt.Sprintf(v3MerchantWithdrawStatus, withdrawId)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &WithdrawStatusRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(WithdrawStatus)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 商户提现单号查询电商平台提现状态API
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/fund/chapter3_6.shtml
+func (c *ClientV3) V3MerchantWithdrawStatusByOutReqNo(outRequestNo string) (wxRsp *WithdrawStatusRsp, err error) {
+	uri := fmt.Sprintf(v3MerchantWithdrawStatusByNo, outRequestNo)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &WithdrawStatusRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(WithdrawStatus)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 按日下载提现异常文件API
+//	billType：NO_SUCC，提现异常账单
+//	bm：bill_date、tar_type
+//	下载账单文件：client.V3BillDownLoadBill() 或 client.V3BillDownLoadBillTo()
+//	Code = 0 is success
+//	电商收付通文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wxpay/ecommerce/fund/chapter3_4.shtml
+func (c *ClientV3) V3WithdrawDownloadErrBill(billType string, bm gopay.BodyMap) (wxRsp *BillRsp, err error) {
+	uri := fmt.Sprintf(v3WithdrawDownloadErrBill, billType) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &BillRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(TradeBill)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
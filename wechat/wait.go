@@ -0,0 +1,143 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+var (
+	// 轮询查询的初始间隔及最大间隔，每次查询后间隔翻倍
+	waitInitialInterval = time.Second
+	waitMaxInterval     = 30 * time.Second
+)
+
+// 微信系统繁忙，可重试的错误码
+const errCodeSystemError = "SYSTEMERROR"
+
+// 轮询查询订单，直到订单进入终态（SUCCESS、REFUND、CLOSED、REVOKED、PAYERROR）
+//
+//	注意：请通过 ctx 设置超时时间，ctx 超时或取消时返回最后一次查询结果及 ctx.Err()
+//	注意：查询间隔从 1s 开始指数退避，最大 30s；仅网络错误、HTTP 5xx 及 err_code=SYSTEMERROR 时继续重试，其他错误立即返回
+//	bm：同 client.QueryOrderWithResult()，每次查询会重新生成 nonce_str
+func (w *Client) WaitForPayment(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryOrderResponse, err error) {
+	var (
+		rsp      *QueryOrderResponse
+		res      *APIResult
+		lastErr  error
+		interval = waitInitialInterval
+	)
+	for {
+		rsp, res, lastErr = w.QueryOrderWithResult(ctx, bm.Clone().Set("nonce_str", util.GetRandomString(32)))
+		if lastErr != nil {
+			if !isTransientError(res, lastErr) {
+				return wxRsp, lastErr
+			}
+		} else {
+			wxRsp = rsp
+			if rsp.ReturnCode == gopay.SUCCESS && rsp.ResultCode == gopay.SUCCESS && isTradeStateFinal(rsp.TradeState) {
+				return wxRsp, nil
+			}
+			if err = waitResultError(rsp.ReturnCode, rsp.ReturnMsg, rsp.ResultCode, rsp.ErrCode, rsp.ErrCodeDes); err != nil {
+				return wxRsp, err
+			}
+		}
+		if interval, err = waitNext(ctx, interval, lastErr); err != nil {
+			return wxRsp, err
+		}
+	}
+}
+
+// 轮询查询退款，直到退款进入终态（SUCCESS、REFUNDCLOSE、CHANGE）
+//
+//	注意：请通过 ctx 设置超时时间，ctx 超时或取消时返回最后一次查询结果及 ctx.Err()
+//	注意：查询间隔从 1s 开始指数退避，最大 30s；仅网络错误、HTTP 5xx 及 err_code=SYSTEMERROR 时继续重试，其他错误立即返回
+//	注意：以 refund_status_0 判断退款状态，建议传入 out_refund_no 或 refund_id 查询单笔退款
+//	bm：同 client.QueryRefundWithResult()，每次查询会重新生成 nonce_str
+func (w *Client) WaitForRefund(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryRefundResponse, err error) {
+	var (
+		rsp      *QueryRefundResponse
+		res      *APIResult
+		lastErr  error
+		interval = waitInitialInterval
+	)
+	for {
+		rsp, res, lastErr = w.QueryRefundWithResult(ctx, bm.Clone().Set("nonce_str", util.GetRandomString(32)))
+		if lastErr != nil {
+			if !isTransientError(res, lastErr) {
+				return wxRsp, lastErr
+			}
+		} else {
+			wxRsp = rsp
+			if rsp.ReturnCode == gopay.SUCCESS && rsp.ResultCode == gopay.SUCCESS && isRefundStatusFinal(rsp.RefundStatus0) {
+				return wxRsp, nil
+			}
+			if err = waitResultError(rsp.ReturnCode, rsp.ReturnMsg, rsp.ResultCode, rsp.ErrCode, rsp.ErrCodeDes); err != nil {
+				return wxRsp, err
+			}
+		}
+		if interval, err = waitNext(ctx, interval, lastErr); err != nil {
+			return wxRsp, err
+		}
+	}
+}
+
+// waitNext 等待 interval 后返回下一次的间隔，ctx 结束时返回错误
+func waitNext(ctx context.Context, interval time.Duration, lastErr error) (next time.Duration, err error) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		if lastErr != nil {
+			return 0, fmt.Errorf("%w, last error: %v", ctx.Err(), lastErr)
+		}
+		return 0, ctx.Err()
+	case <-timer.C:
+	}
+	if next = interval * 2; next > waitMaxInterval {
+		next = waitMaxInterval
+	}
+	return next, nil
+}
+
+// isTransientError 网络错误或 HTTP 5xx 视为临时错误，可重试
+func isTransientError(res *APIResult, err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return res != nil && res.StatusCode >= http.StatusInternalServerError
+}
+
+// waitResultError 查询结果为不可重试的失败时返回错误，SYSTEMERROR 返回 nil 以继续重试
+func waitResultError(returnCode, returnMsg, resultCode, errCode, errCodeDes string) error {
+	if returnCode != gopay.SUCCESS {
+		return fmt.Errorf("return_code = %s, return_msg = %s", returnCode, returnMsg)
+	}
+	if resultCode != gopay.SUCCESS && errCode != errCodeSystemError {
+		return fmt.Errorf("err_code = %s, err_code_des = %s", errCode, errCodeDes)
+	}
+	return nil
+}
+
+func isTradeStateFinal(state string) bool {
+	switch state {
+	case TradeState_Success, TradeState_Refund, TradeState_Closed, TradeState_Revoked, TradeState_PayError:
+		return true
+	}
+	return false
+}
+
+func isRefundStatusFinal(status string) bool {
+	switch status {
+	case RefundStatus_Success, RefundStatus_RefundClose, RefundStatus_Change:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+func TestWaitForPayment(t *testing.T) {
+	waitInitialInterval = time.Millisecond
+	defer func() { waitInitialInterval = time.Second }()
+
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := TradeState_UserPaying
+		if atomic.AddInt32(&count, 1) >= 3 {
+			state = TradeState_Success
+		}
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code><trade_state><![CDATA[" + state + "]]></trade_state></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_trade_no", "GOPAY_TEST")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	wxRsp, err := c.WaitForPayment(ctx, bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wxRsp.TradeState != TradeState_Success || atomic.LoadInt32(&count) != 3 {
+		t.Fatalf("TradeState = %s, count = %d", wxRsp.TradeState, count)
+	}
+}
+
+func TestWaitForRefundTimeout(t *testing.T) {
+	waitInitialInterval = time.Millisecond
+	defer func() { waitInitialInterval = time.Second }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code><refund_status_0><![CDATA[PROCESSING]]></refund_status_0></xml>"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32)).
+		Set("out_refund_no", "GOPAY_TEST")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	wxRsp, err := c.WaitForRefund(ctx, bm)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if wxRsp == nil || wxRsp.RefundStatus0 != RefundStatus_Processing {
+		t.Fatalf("wxRsp = %+v", wxRsp)
+	}
+}
+
+func TestWaitForPaymentRetry(t *testing.T) {
+	waitInitialInterval = time.Millisecond
+	defer func() { waitInitialInterval = time.Second }()
+
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&count, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[FAIL]]></result_code><err_code><![CDATA[SYSTEMERROR]]></err_code></xml>"))
+		case 3:
+			w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[FAIL]]></result_code><err_code><![CDATA[ORDERNOTEXIST]]></err_code><err_code_des><![CDATA[此交易订单号不存在]]></err_code_des></xml>"))
+		default:
+			w.Write([]byte("<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code><trade_state><![CDATA[SUCCESS]]></trade_state></xml>"))
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = ts.URL + "/"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 参数校验错误不重试
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.GetRandomString(32))
+	if _, err := c.WaitForPayment(ctx, bm); err == nil || atomic.LoadInt32(&count) != 0 {
+		t.Fatalf("err = %v, count = %d", err, count)
+	}
+	// HTTP 5xx、SYSTEMERROR 重试，ORDERNOTEXIST 立即返回
+	bm.Set("out_trade_no", "GOPAY_TEST")
+	wxRsp, err := c.WaitForPayment(ctx, bm)
+	if err == nil || wxRsp == nil || wxRsp.ErrCode != "ORDERNOTEXIST" {
+		t.Fatalf("wxRsp = %+v, err = %v", wxRsp, err)
+	}
+	if atomic.LoadInt32(&count) != 3 {
+		t.Fatalf("count = %d, want 3", count)
+	}
+}
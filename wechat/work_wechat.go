@@ -0,0 +1,174 @@
+/*
+	企业微信支付
+	文档：https://work.weixin.qq.com/api/doc/90000/90135/90278
+*/
+
+package wechat
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cedarwu/gopay"
+	"github.com/cedarwu/gopay/pkg/util"
+)
+
+var (
+	// 向员工付款 workwx_sign 参与签名的字段
+	workWxPocketSignKeys = []string{"amount", "appid", "desc", "mch_id", "nonce_str", "openid", "partner_trade_no", "ww_msg_type"}
+	// 企业红包 workwx_sign 参与签名的字段
+	workWxRedSignKeys = []string{"act_name", "mch_billno", "mch_id", "nonce_str", "re_openid", "total_amount", "wxappid"}
+)
+
+// GetWorkWxSign 获取企业微信 workwx_sign 值
+//
+//	secret：企业微信管理后台「应用管理」-「企业支付」中的 secret
+//	keys：参与签名的字段，按字典序拼接后追加 &secret=xxx，再做MD5并转大写
+//	文档：https://work.weixin.qq.com/api/doc/90000/90135/90281
+func GetWorkWxSign(secret string, bm gopay.BodyMap, keys ...string) (sign string) {
+	sbm := make(gopay.BodyMap)
+	for _, k := range keys {
+		sbm.Set(k, bm.GetInterface(k))
+	}
+	str := sbm.EncodeAliPaySignParams() + "&secret=" + secret
+	h := md5.New()
+	h.Write([]byte(str))
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+}
+
+// 向员工付款（正式）
+//
+//	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
+//	注意：workwx_sign、sign 均通过 secret、ApiKey 重新计算，并写入 bm 的副本，不会修改调用方传入的 bm
+//	文档：https://work.weixin.qq.com/api/doc/90000/90135/90278
+func (w *Client) PayWorkWxToPocket(ctx context.Context, bm gopay.BodyMap, secret string) (wxRsp *PayWorkWxToPocketResponse, header http.Header, err error) {
+	err = bm.CheckEmptyError("nonce_str", "partner_trade_no", "openid", "check_name", "amount", "desc", "spbill_create_ip", "ww_msg_type")
+	if err != nil {
+		return nil, nil, err
+	}
+	bm = bm.Clone()
+	if bm.GetString("appid") == util.NULL {
+		bm.Set("appid", w.AppId)
+	}
+	if bm.GetString("mch_id") == util.NULL {
+		bm.Set("mch_id", w.MchId)
+	}
+	bm.Set("workwx_sign", GetWorkWxSign(secret, bm, workWxPocketSignKeys...))
+
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bs, header, err := w.doProdPostPure(ctx, bm, payWorkWxToPocket, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, header, err
+	}
+	wxRsp = new(PayWorkWxToPocketResponse)
+	if err = xml.Unmarshal(bs, wxRsp); err != nil {
+		return nil, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return wxRsp, header, nil
+}
+
+// 查询向员工付款记录（正式）
+//
+//	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
+//	文档：https://work.weixin.qq.com/api/doc/90000/90135/90279
+func (w *Client) QueryWorkWxToPocket(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryWorkWxToPocketResponse, header http.Header, err error) {
+	err = bm.CheckEmptyError("nonce_str", "partner_trade_no")
+	if err != nil {
+		return nil, nil, err
+	}
+	bm = bm.Clone()
+	if bm.GetString("appid") == util.NULL {
+		bm.Set("appid", w.AppId)
+	}
+	if bm.GetString("mch_id") == util.NULL {
+		bm.Set("mch_id", w.MchId)
+	}
+
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bs, header, err := w.doProdPostPure(ctx, bm, queryWorkWxToPocket, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, header, err
+	}
+	wxRsp = new(QueryWorkWxToPocketResponse)
+	if err = xml.Unmarshal(bs, wxRsp); err != nil {
+		return nil, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return wxRsp, header, nil
+}
+
+// 发放企业红包（正式）
+//
+//	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
+//	注意：此处参数中的 wxappid 需要单独传参，不复用 NewClient 时的 appid
+//	注意：workwx_sign、sign 均通过 secret、ApiKey 重新计算，并写入 bm 的副本，不会修改调用方传入的 bm
+//	文档：https://work.weixin.qq.com/api/doc/90000/90135/90275
+func (w *Client) SendWorkWxRed(ctx context.Context, bm gopay.BodyMap, secret string) (wxRsp *SendWorkWxRedResponse, header http.Header, err error) {
+	err = bm.CheckEmptyError("nonce_str", "mch_billno", "wxappid", "re_openid", "total_amount", "wishing", "act_name", "remark")
+	if err != nil {
+		return nil, nil, err
+	}
+	bm = bm.Clone()
+	if bm.GetString("mch_id") == util.NULL {
+		bm.Set("mch_id", w.MchId)
+	}
+	bm.Set("workwx_sign", GetWorkWxSign(secret, bm, workWxRedSignKeys...))
+
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bs, header, err := w.doProdPostPure(ctx, bm, sendWorkWxRed, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, header, err
+	}
+	wxRsp = new(SendWorkWxRedResponse)
+	if err = xml.Unmarshal(bs, wxRsp); err != nil {
+		return nil, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return wxRsp, header, nil
+}
+
+// 查询企业红包记录（正式）
+//
+//	注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
+//	注意：此处参数中的 appid 需要单独传参，不复用 NewClient 时的 appid
+//	文档：https://work.weixin.qq.com/api/doc/90000/90135/90276
+func (w *Client) QueryWorkWxRed(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryWorkWxRedResponse, header http.Header, err error) {
+	err = bm.CheckEmptyError("nonce_str", "mch_billno", "appid")
+	if err != nil {
+		return nil, nil, err
+	}
+	bm = bm.Clone()
+	if bm.GetString("mch_id") == util.NULL {
+		bm.Set("mch_id", w.MchId)
+	}
+
+	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bs, header, err := w.doProdPostPure(ctx, bm, queryWorkWxRed, SignType_MD5, tlsConfig)
+	if err != nil {
+		return nil, header, err
+	}
+	wxRsp = new(QueryWorkWxRedResponse)
+	if err = xml.Unmarshal(bs, wxRsp); err != nil {
+		return nil, header, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return wxRsp, header, nil
+}
@@ -0,0 +1,41 @@
+package wechat
+
+import (
+	"testing"
+
+	"github.com/cedarwu/gopay"
+)
+
+func TestGetWorkWxSign(t *testing.T) {
+	secret := "IJUiSjGrInL4nTyYaymB1sjOJpwyJKKp0Ddhqxk1Aws"
+
+	bm := make(gopay.BodyMap)
+	bm.Set("appid", appId).
+		Set("mch_id", mchId).
+		Set("nonce_str", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS").
+		Set("partner_trade_no", "100000982014120919616").
+		Set("openid", "oxTWIuGaIt6gTKsQRLau2M0yL16E").
+		Set("check_name", "NO_CHECK").
+		Set("amount", 100).
+		Set("desc", "六月出差报销费用").
+		Set("spbill_create_ip", "10.2.3.10").
+		Set("ww_msg_type", "NORMAL_MSG")
+	// check_name、spbill_create_ip 不参与 workwx_sign 签名
+	if sign := GetWorkWxSign(secret, bm, workWxPocketSignKeys...); sign != "2D94BACDE98CD2940A2B5750120A2ADF" {
+		t.Fatalf("pocket workwx_sign = %s", sign)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("nonce_str", "5K8264ILTKCH16CQ2502SI8ZNMTM67VS").
+		Set("mch_billno", "126459841250106874").
+		Set("mch_id", mchId).
+		Set("wxappid", appId).
+		Set("re_openid", "oxTWIuGaIt6gTKsQRLau2M0yL16E").
+		Set("total_amount", 100).
+		Set("wishing", "感谢您参加猜灯谜活动，祝您元宵节快乐！").
+		Set("act_name", "新年红包").
+		Set("remark", "猜越多得越多，快来抢！")
+	if sign := GetWorkWxSign(secret, bm, workWxRedSignKeys...); sign != "A1797A1B4D15B2A8C2B0ED86EA94F1E2" {
+		t.Fatalf("red workwx_sign = %s", sign)
+	}
+}